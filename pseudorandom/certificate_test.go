@@ -0,0 +1,91 @@
+package pseudorandom_test
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/copartner6412/input/pseudorandom"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzCertificateChain(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed1, seed2 uint64, a, intermediates uint, unix int64) {
+		algorithm := pseudorandom.Algorithm(int(a % 8))
+		if algorithm >= pseudorandom.AlgorithmRSA4096 {
+			// Keep the fuzz iterations fast: RSA 4096 is replaced by RSA 2048.
+			algorithm = pseudorandom.AlgorithmRSA2048
+		}
+		now := time.Unix(unix%(1<<32), 0)
+
+		r1 := rand.New(rand.NewPCG(seed1, seed2))
+		chain1, leafKey1, err := pseudorandom.CertificateChain(r1, algorithm, intermediates%3, now)
+		if err != nil {
+			t.Fatalf("error generating a pseudo-random %s certificate chain: %v", algorithm, err)
+		}
+
+		err = validate.CertificateChain(chain1, now)
+		if err != nil {
+			t.Fatalf("invalid certificate chain: %v", err)
+		}
+
+		for _, certificate := range chain1 {
+			err = validate.SerialNumber(certificate.SerialNumber)
+			if err != nil {
+				t.Fatalf("invalid serial number: %v", err)
+			}
+		}
+
+		err = validate.CertificateSANs(chain1[0])
+		if err != nil {
+			t.Fatalf("invalid subject alternative names: %v", err)
+		}
+
+		err = validate.KeyPair(algorithm, chain1[0].PublicKey, leafKey1)
+		if err != nil {
+			t.Fatalf("leaf private key doesn't match certificate: %v", err)
+		}
+
+		r2 := rand.New(rand.NewPCG(seed1, seed2))
+		chain2, _, err := pseudorandom.CertificateChain(r2, algorithm, intermediates%3, now)
+		if err != nil {
+			t.Fatalf("error regenerating the pseudo-random certificate chain: %v", err)
+		}
+
+		for i := range chain1 {
+			if !bytes.Equal(chain1[i].Raw, chain2[i].Raw) {
+				t.Fatalf("certificate %d of chain not deterministic", i)
+			}
+		}
+	})
+}
+
+func TestCertificateChainDeterministic(t *testing.T) {
+	now := time.Date(2024, time.September, 21, 9, 24, 42, 0, time.UTC)
+
+	for _, algorithm := range []pseudorandom.Algorithm{pseudorandom.AlgorithmED25519, pseudorandom.AlgorithmECDSAP384, pseudorandom.AlgorithmRSA2048} {
+		t.Run(algorithm.String(), func(t *testing.T) {
+			t.Parallel()
+			chain1, _, err := pseudorandom.CertificateChain(rand.New(rand.NewPCG(6412, 1024)), algorithm, 1, now)
+			if err != nil {
+				t.Fatalf("error generating a pseudo-random %s certificate chain: %v", algorithm, err)
+			}
+
+			chain2, _, err := pseudorandom.CertificateChain(rand.New(rand.NewPCG(6412, 1024)), algorithm, 1, now)
+			if err != nil {
+				t.Fatalf("error regenerating the pseudo-random certificate chain: %v", err)
+			}
+
+			for i := range chain1 {
+				if !bytes.Equal(chain1[i].Raw, chain2[i].Raw) {
+					t.Fatalf("certificate %d of chain not deterministic", i)
+				}
+			}
+
+			if err := validate.CertificateChain(chain1, now); err != nil {
+				t.Fatalf("invalid certificate chain: %v", err)
+			}
+		})
+	}
+}
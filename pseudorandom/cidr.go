@@ -0,0 +1,45 @@
+package pseudorandom
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/netip"
+)
+
+// CIDR generates a deterministic pseudo-random IP network in canonical form within the parent prefix using the provided random source,
+// with a prefix length between minPrefix and maxPrefix. If both minPrefix and maxPrefix are 0, the prefix length ranges from
+// the length of the parent to the length of an address.
+func CIDR(r *rand.Rand, parent netip.Prefix, minPrefix, maxPrefix uint) (netip.Prefix, error) {
+	if !parent.IsValid() {
+		return netip.Prefix{}, errors.New("invalid parent prefix")
+	}
+
+	parentBits, bitLen := uint(parent.Bits()), uint(parent.Addr().BitLen())
+
+	if minPrefix == 0 && maxPrefix == 0 {
+		minPrefix, maxPrefix = parentBits, bitLen
+	} else {
+		if maxPrefix < minPrefix {
+			return netip.Prefix{}, errors.New("maximum prefix length can not be less than minimum prefix length")
+		}
+
+		var errs []error
+
+		if minPrefix < parentBits {
+			errs = append(errs, fmt.Errorf("minimum prefix length must not be less than %d of the parent prefix", parentBits))
+		}
+
+		if maxPrefix > bitLen {
+			errs = append(errs, fmt.Errorf("maximum prefix length must not exceed %d", bitLen))
+		}
+
+		if len(errs) > 0 {
+			return netip.Prefix{}, errors.Join(errs...)
+		}
+	}
+
+	bits := int(minPrefix) + r.IntN(int(maxPrefix-minPrefix)+1)
+
+	return netip.PrefixFrom(prefixAddr(r, parent), bits).Masked(), nil
+}
@@ -0,0 +1,40 @@
+package pseudorandom_test
+
+import (
+	"math/rand/v2"
+	"net/netip"
+	"testing"
+
+	"github.com/copartner6412/input/pseudorandom"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzCIDR(f *testing.F) {
+	parent := netip.MustParsePrefix("10.0.0.0/8")
+
+	f.Fuzz(func(t *testing.T, seed1, seed2 uint64) {
+		r1 := rand.New(rand.NewPCG(seed1, seed2))
+		cidr1, err := pseudorandom.CIDR(r1, parent, 16, 28)
+		if err != nil {
+			t.Fatalf("error generating a pseudo-random CIDR: %v", err)
+		}
+
+		if err := validate.CIDR(cidr1.String(), validate.IPFamilyIPv4, 16, 28); err != nil {
+			t.Fatalf("unexpected error for the pseudo-random CIDR \"%s\": %v", cidr1, err)
+		}
+
+		if err := validate.CIDRWithin(cidr1.String(), parent); err != nil {
+			t.Fatalf("unexpected error for the pseudo-random CIDR \"%s\" within \"%s\": %v", cidr1, parent, err)
+		}
+
+		r2 := rand.New(rand.NewPCG(seed1, seed2))
+		cidr2, err := pseudorandom.CIDR(r2, parent, 16, 28)
+		if err != nil {
+			t.Fatalf("error regenerating the pseudo-random CIDR: %v", err)
+		}
+
+		if cidr1 != cidr2 {
+			t.Fatal("not deterministic")
+		}
+	})
+}
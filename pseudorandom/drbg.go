@@ -0,0 +1,90 @@
+package pseudorandom
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"math/rand/v2"
+)
+
+const (
+	drbgEntropyLength    = 32    // bytes of entropy input, equal to the security strength of HMAC-SHA256
+	drbgNonceLength      = 16    // bytes of nonce, half of the security strength
+	drbgMaxRequestLength = 65536 // maximum number of bytes per generate request (2^19 bits)
+)
+
+// hmacDRBG is an HMAC_DRBG with SHA-256 as specified in NIST SP 800-90A Rev. 1, section 10.1.2.
+// It is instantiated from a deterministic pseudo-random source, so its output is reproducible for the same seed and personalization string.
+// It implements the io.Reader interface.
+type hmacDRBG struct {
+	k []byte
+	v []byte
+}
+
+// newHMACDRBG instantiates an HMAC_DRBG with entropy input and nonce read from r and the given personalization string.
+func newHMACDRBG(r *rand.Rand, personalization string) *hmacDRBG {
+	seed := make([]byte, 0, drbgEntropyLength+drbgNonceLength+len(personalization))
+	for i := 0; i < (drbgEntropyLength+drbgNonceLength)/8; i++ {
+		seed = binary.BigEndian.AppendUint64(seed, r.Uint64())
+	}
+	seed = append(seed, personalization...)
+
+	d := &hmacDRBG{
+		k: make([]byte, sha256.Size),
+		v: make([]byte, sha256.Size),
+	}
+	for i := range d.v {
+		d.v[i] = 0x01
+	}
+	d.update(seed)
+
+	return d
+}
+
+// update is the HMAC_DRBG_Update function.
+func (d *hmacDRBG) update(provided []byte) {
+	var mac hash.Hash
+
+	mac = hmac.New(sha256.New, d.k)
+	mac.Write(d.v)
+	mac.Write([]byte{0x00})
+	mac.Write(provided)
+	d.k = mac.Sum(nil)
+
+	mac = hmac.New(sha256.New, d.k)
+	mac.Write(d.v)
+	d.v = mac.Sum(nil)
+
+	if len(provided) == 0 {
+		return
+	}
+
+	mac = hmac.New(sha256.New, d.k)
+	mac.Write(d.v)
+	mac.Write([]byte{0x01})
+	mac.Write(provided)
+	d.k = mac.Sum(nil)
+
+	mac = hmac.New(sha256.New, d.k)
+	mac.Write(d.v)
+	d.v = mac.Sum(nil)
+}
+
+// Read implements the io.Reader interface by running the HMAC_DRBG_Generate function without additional input.
+func (d *hmacDRBG) Read(p []byte) (n int, err error) {
+	for n < len(p) {
+		request := min(len(p)-n, drbgMaxRequestLength)
+		mac := hmac.New(sha256.New, d.k)
+		for generated := 0; generated < request; {
+			mac.Reset()
+			mac.Write(d.v)
+			d.v = mac.Sum(d.v[:0])
+			generated += copy(p[n+generated:n+request], d.v)
+		}
+		d.update(nil)
+		n += request
+	}
+
+	return n, nil
+}
@@ -0,0 +1,79 @@
+package pseudorandom
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand/v2"
+	"net/netip"
+)
+
+// IPv4Addr generates a deterministic pseudo-random IPv4 address within the specified prefix using the provided random source,
+// or within the whole IPv4 address space if the prefix is the zero value. Unlike IPv4, it returns the 4-byte form.
+func IPv4Addr(r *rand.Rand, prefix netip.Prefix) (netip.Addr, error) {
+	if !prefix.IsValid() {
+		prefix = netip.PrefixFrom(netip.IPv4Unspecified(), 0)
+	}
+
+	if !prefix.Addr().Is4() {
+		return netip.Addr{}, fmt.Errorf("prefix %s is not an IPv4 prefix", prefix)
+	}
+
+	return prefixAddr(r, prefix), nil
+}
+
+// IPv6Addr generates a deterministic pseudo-random IPv6 address within the specified prefix using the provided random source,
+// or within the whole IPv6 address space if the prefix is the zero value. Unlike IPv6, it draws 64 host bits at a time instead of one.
+func IPv6Addr(r *rand.Rand, prefix netip.Prefix) (netip.Addr, error) {
+	if !prefix.IsValid() {
+		prefix = netip.PrefixFrom(netip.IPv6Unspecified(), 0)
+	}
+
+	if !prefix.Addr().Is6() {
+		return netip.Addr{}, fmt.Errorf("prefix %s is not an IPv6 prefix", prefix)
+	}
+
+	return prefixAddr(r, prefix), nil
+}
+
+// prefixAddr draws the host bits of a valid prefix from r and returns the resulting address.
+func prefixAddr(r *rand.Rand, prefix netip.Prefix) netip.Addr {
+	hostBits := prefix.Addr().BitLen() - prefix.Bits()
+
+	var host [16]byte
+	if hostBits > 0 {
+		binary.BigEndian.PutUint64(host[8:], r.Uint64())
+	}
+	if hostBits > 64 {
+		binary.BigEndian.PutUint64(host[:8], r.Uint64())
+	}
+
+	return hostAddr(prefix, host)
+}
+
+// hostAddr sets the host bits of the masked prefix to the low bits of host.
+func hostAddr(prefix netip.Prefix, host [16]byte) netip.Addr {
+	network := prefix.Masked().Addr()
+	ip := network.As16()
+
+	// The network bits of an IPv4 address in its 16-byte form include the 96 bits of the IPv4-mapped prefix.
+	ones := prefix.Bits()
+	if network.Is4() {
+		ones += 96
+	}
+
+	for i := range ip {
+		switch {
+		case ones >= (i+1)*8:
+		case ones <= i*8:
+			ip[i] = host[i]
+		default:
+			ip[i] |= host[i] & (0xff >> (ones - i*8))
+		}
+	}
+
+	if network.Is4() {
+		return netip.AddrFrom4([4]byte(ip[12:]))
+	}
+
+	return netip.AddrFrom16(ip)
+}
@@ -0,0 +1,61 @@
+package pseudorandom_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/copartner6412/input/pseudorandom"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzIPv4Addr(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed1, seed2 uint64) {
+		r1 := rand.New(rand.NewPCG(seed1, seed2))
+		prefix1 := pseudorandom.CIDRv4Prefix(r1)
+		addr1, err := pseudorandom.IPv4Addr(r1, prefix1)
+		if err != nil {
+			t.Fatalf("error generating a pseudo-random IPv4 address: %v", err)
+		}
+
+		if err := validate.IPAddr(addr1, prefix1); err != nil {
+			t.Fatalf("unexpected error for the pseudo-random IPv4 address \"%s\" within \"%s\": %v", addr1, prefix1, err)
+		}
+
+		r2 := rand.New(rand.NewPCG(seed1, seed2))
+		prefix2 := pseudorandom.CIDRv4Prefix(r2)
+		addr2, err := pseudorandom.IPv4Addr(r2, prefix2)
+		if err != nil {
+			t.Fatalf("error regenerating the pseudo-random IPv4 address: %v", err)
+		}
+
+		if prefix1 != prefix2 || addr1 != addr2 {
+			t.Fatal("not deterministic")
+		}
+	})
+}
+
+func FuzzIPv6Addr(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed1, seed2 uint64) {
+		r1 := rand.New(rand.NewPCG(seed1, seed2))
+		prefix1 := pseudorandom.CIDRv6Prefix(r1)
+		addr1, err := pseudorandom.IPv6Addr(r1, prefix1)
+		if err != nil {
+			t.Fatalf("error generating a pseudo-random IPv6 address: %v", err)
+		}
+
+		if err := validate.IPAddr(addr1, prefix1); err != nil {
+			t.Fatalf("unexpected error for the pseudo-random IPv6 address \"%s\" within \"%s\": %v", addr1, prefix1, err)
+		}
+
+		r2 := rand.New(rand.NewPCG(seed1, seed2))
+		prefix2 := pseudorandom.CIDRv6Prefix(r2)
+		addr2, err := pseudorandom.IPv6Addr(r2, prefix2)
+		if err != nil {
+			t.Fatalf("error regenerating the pseudo-random IPv6 address: %v", err)
+		}
+
+		if prefix1 != prefix2 || addr1 != addr2 {
+			t.Fatal("not deterministic")
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package pseudorandom
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net/netip"
+
+	"github.com/copartner6412/input/validate"
+)
+
+// IPClass defines the classes of IP addresses derived from the IANA special-purpose address registries.
+type IPClass = validate.IPClass
+
+// List of IP address classes.
+const (
+	IPClassGlobal        = validate.IPClassGlobal
+	IPClassPrivate       = validate.IPClassPrivate
+	IPClassLoopback      = validate.IPClassLoopback
+	IPClassLinkLocal     = validate.IPClassLinkLocal
+	IPClassDocumentation = validate.IPClassDocumentation
+	IPClassCGNAT         = validate.IPClassCGNAT
+	IPClassMulticast     = validate.IPClassMulticast
+	IPClassReserved      = validate.IPClassReserved
+)
+
+// IPv4AddrOfClass generates a deterministic pseudo-random IPv4 address of one of the specified classes using the provided random source,
+// as classified by validate.ClassifyIP. Global addresses are drawn from the whole address space, and addresses of other classes from
+// the registry blocks of the class, each block being equally likely.
+func IPv4AddrOfClass(r *rand.Rand, classes ...IPClass) (netip.Addr, error) {
+	return addrOfClass(r, false, classes)
+}
+
+// IPv6AddrOfClass generates a deterministic pseudo-random IPv6 address of one of the specified classes using the provided random source,
+// as classified by validate.ClassifyIP. Global addresses are drawn from 2000::/3, and addresses of other classes from
+// the registry blocks of the class, each block being equally likely.
+func IPv6AddrOfClass(r *rand.Rand, classes ...IPClass) (netip.Addr, error) {
+	return addrOfClass(r, true, classes)
+}
+
+func addrOfClass(r *rand.Rand, ipv6 bool, classes []IPClass) (netip.Addr, error) {
+	prefixes := classPrefixes(ipv6, classes)
+	if len(prefixes) == 0 {
+		return netip.Addr{}, errors.New("no IP address block for the specified classes")
+	}
+
+	for {
+		addr := prefixAddr(r, prefixes[r.IntN(len(prefixes))])
+		if validate.IPAddrClass(addr, classes...) == nil {
+			return addr, nil
+		}
+	}
+}
+
+// classPrefixes returns the blocks which addresses of the specified classes are drawn from.
+func classPrefixes(ipv6 bool, classes []IPClass) []netip.Prefix {
+	registry, global := validate.IPv4SpecialPurposeRegistry, netip.MustParsePrefix("0.0.0.0/0")
+	if ipv6 {
+		registry, global = validate.IPv6SpecialPurposeRegistry, netip.MustParsePrefix("2000::/3")
+	}
+
+	var prefixes []netip.Prefix
+
+	for _, class := range classes {
+		if class == IPClassGlobal {
+			prefixes = append(prefixes, global)
+			continue
+		}
+		for _, entry := range registry {
+			if entry.Class == class {
+				prefixes = append(prefixes, entry.Prefix)
+			}
+		}
+	}
+
+	return prefixes
+}
@@ -0,0 +1,47 @@
+package pseudorandom_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/copartner6412/input/pseudorandom"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzIPAddrOfClass(f *testing.F) {
+	classes := []pseudorandom.IPClass{
+		pseudorandom.IPClassGlobal,
+		pseudorandom.IPClassPrivate,
+		pseudorandom.IPClassDocumentation,
+		pseudorandom.IPClassMulticast,
+	}
+
+	f.Fuzz(func(t *testing.T, seed1, seed2 uint64) {
+		for _, class := range classes {
+			r1 := rand.New(rand.NewPCG(seed1, seed2))
+			ipv41, err := pseudorandom.IPv4AddrOfClass(r1, class)
+			if err != nil {
+				t.Fatalf("error generating a pseudo-random %s IPv4 address: %v", class, err)
+			}
+			ipv61, err := pseudorandom.IPv6AddrOfClass(r1, class)
+			if err != nil {
+				t.Fatalf("error generating a pseudo-random %s IPv6 address: %v", class, err)
+			}
+
+			if err := validate.IPAddrClass(ipv41, class); err != nil {
+				t.Fatalf("unexpected error for the pseudo-random %s IPv4 address \"%s\": %v", class, ipv41, err)
+			}
+			if err := validate.IPAddrClass(ipv61, class); err != nil {
+				t.Fatalf("unexpected error for the pseudo-random %s IPv6 address \"%s\": %v", class, ipv61, err)
+			}
+
+			r2 := rand.New(rand.NewPCG(seed1, seed2))
+			ipv42, _ := pseudorandom.IPv4AddrOfClass(r2, class)
+			ipv62, _ := pseudorandom.IPv6AddrOfClass(r2, class)
+
+			if ipv41 != ipv42 || ipv61 != ipv62 {
+				t.Fatal("not deterministic")
+			}
+		}
+	})
+}
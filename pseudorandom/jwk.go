@@ -0,0 +1,240 @@
+package pseudorandom
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/mlkem"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+
+	"github.com/copartner6412/input/validate"
+)
+
+// jwk holds the members of a JSON Web Key (RFC 7517) for the key types of RFC 7518 ("EC" and "RSA"), RFC 8037 ("OKP")
+// and the "AKP" key type of the JOSE post-quantum drafts, which holds ML-KEM keys.
+type jwk struct {
+	Kty  string `json:"kty"`
+	Kid  string `json:"kid,omitempty"`
+	Use  string `json:"use,omitempty"`
+	Alg  string `json:"alg,omitempty"`
+	Crv  string `json:"crv,omitempty"`
+	X    string `json:"x,omitempty"`
+	Y    string `json:"y,omitempty"`
+	N    string `json:"n,omitempty"`
+	E    string `json:"e,omitempty"`
+	D    string `json:"d,omitempty"`
+	P    string `json:"p,omitempty"`
+	Q    string `json:"q,omitempty"`
+	DP   string `json:"dp,omitempty"`
+	DQ   string `json:"dq,omitempty"`
+	QI   string `json:"qi,omitempty"`
+	Pub  string `json:"pub,omitempty"`
+	Priv string `json:"priv,omitempty"`
+}
+
+// jwks holds a JSON Web Key Set.
+type jwks struct {
+	Keys []json.RawMessage `json:"keys"`
+}
+
+// jwkEncryptionAlg holds the JWE key management algorithm of each key agreement algorithm.
+var jwkEncryptionAlg = map[Algorithm]string{
+	AlgorithmX25519:    "ECDH-ES",
+	AlgorithmECDHP256:  "ECDH-ES",
+	AlgorithmECDHP384:  "ECDH-ES",
+	AlgorithmMLKEM768:  "ML-KEM-768",
+	AlgorithmMLKEM1024: "ML-KEM-1024",
+}
+
+var jwkECDHCurve = map[string]ecdh.Curve{
+	"P-256": ecdh.P256(),
+	"P-384": ecdh.P384(),
+}
+
+var base64URL = base64.RawURLEncoding
+
+// JWKS generates count deterministic pseudo-random key pairs of the specified algorithm with KeyPair and encodes them as a JSON Web Key Set
+// for test fixtures. It returns the JWKS and the private keys in the order of the keys in the set.
+// If private is true, the JWKs hold the private keys, otherwise only the public keys.
+//
+// Each key has its RFC 7638 thumbprint as key ID ("kid"), and "alg" and "use" set from its algorithm: JWS algorithms for signature keys
+// (see Algorithm.JOSE), "ECDH-ES" for X25519 and ECDH keys, and "ML-KEM-768" or "ML-KEM-1024" for ML-KEM keys.
+// JOSE has no support for the P-224 curve, so AlgorithmECDSAP224 is not supported.
+func JWKS(r *rand.Rand, algorithm Algorithm, count uint, private bool) ([]byte, []crypto.PrivateKey, error) {
+	set := jwks{Keys: make([]json.RawMessage, 0, count)}
+	privateKeys := make([]crypto.PrivateKey, 0, count)
+
+	for i := uint(0); i < count; i++ {
+		publicKey, privateKey, err := KeyPair(r, algorithm)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var key any = publicKey
+		if private {
+			key = privateKey
+		}
+
+		k, err := newJWK(key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error encoding key %d: %w", i, err)
+		}
+
+		data, err := json.Marshal(k)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error marshaling JWK: %w", err)
+		}
+
+		set.Keys = append(set.Keys, data)
+		privateKeys = append(privateKeys, privateKey)
+	}
+
+	data, err := json.Marshal(set)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error marshaling JWKS: %w", err)
+	}
+
+	return data, privateKeys, nil
+}
+
+// newJWK converts a public or private key to a JWK with its thumbprint as key ID.
+func newJWK(key any) (jwk, error) {
+	publicKey, privateKey := key, crypto.PrivateKey(nil)
+
+	switch k := key.(type) {
+	case *mlkem.DecapsulationKey768:
+		publicKey, privateKey = k.EncapsulationKey(), k
+	case *mlkem.DecapsulationKey1024:
+		publicKey, privateKey = k.EncapsulationKey(), k
+	case interface{ Public() crypto.PublicKey }:
+		publicKey, privateKey = k.Public(), k
+	}
+
+	algorithm, err := validate.DetectAlgorithm(publicKey)
+	if err != nil {
+		return jwk{}, err
+	}
+
+	var k jwk
+
+	if alg, err := algorithm.JOSE(); err == nil {
+		k.Alg, k.Use = alg, "sig"
+	} else if alg, ok := jwkEncryptionAlg[algorithm]; ok {
+		k.Alg, k.Use = alg, "enc"
+	} else {
+		return jwk{}, err
+	}
+
+	switch publicKey := publicKey.(type) {
+	case ed25519.PublicKey:
+		k.Kty, k.Crv, k.X = "OKP", "Ed25519", base64URL.EncodeToString(publicKey)
+		if privateKey, ok := privateKey.(ed25519.PrivateKey); ok {
+			k.D = base64URL.EncodeToString(privateKey.Seed())
+		}
+	case *ecdsa.PublicKey:
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+		k.Kty, k.Crv = "EC", publicKey.Curve.Params().Name
+		k.X = base64URL.EncodeToString(publicKey.X.FillBytes(make([]byte, size)))
+		k.Y = base64URL.EncodeToString(publicKey.Y.FillBytes(make([]byte, size)))
+		if privateKey, ok := privateKey.(*ecdsa.PrivateKey); ok {
+			k.D = base64URL.EncodeToString(privateKey.D.FillBytes(make([]byte, size)))
+		}
+	case *rsa.PublicKey:
+		k.Kty = "RSA"
+		k.N = base64URL.EncodeToString(publicKey.N.Bytes())
+		k.E = base64URL.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		if privateKey, ok := privateKey.(*rsa.PrivateKey); ok {
+			if len(privateKey.Primes) != 2 {
+				return jwk{}, errors.New("multi-prime RSA keys not supported")
+			}
+			dp, dq, qinv := rsaCRTValues(privateKey)
+			k.D = base64URL.EncodeToString(privateKey.D.Bytes())
+			k.P = base64URL.EncodeToString(privateKey.Primes[0].Bytes())
+			k.Q = base64URL.EncodeToString(privateKey.Primes[1].Bytes())
+			k.DP = base64URL.EncodeToString(dp.Bytes())
+			k.DQ = base64URL.EncodeToString(dq.Bytes())
+			k.QI = base64URL.EncodeToString(qinv.Bytes())
+		}
+	case *ecdh.PublicKey:
+		if publicKey.Curve() == ecdh.X25519() {
+			k.Kty, k.Crv, k.X = "OKP", "X25519", base64URL.EncodeToString(publicKey.Bytes())
+		} else {
+			// The uncompressed point encoding is 0x04 followed by the coordinates.
+			point := publicKey.Bytes()[1:]
+			k.Kty, k.Crv = "EC", jwkCurveName(publicKey.Curve())
+			k.X = base64URL.EncodeToString(point[:len(point)/2])
+			k.Y = base64URL.EncodeToString(point[len(point)/2:])
+		}
+		if privateKey, ok := privateKey.(*ecdh.PrivateKey); ok {
+			k.D = base64URL.EncodeToString(privateKey.Bytes())
+		}
+	case *mlkem.EncapsulationKey768:
+		k.Kty, k.Pub = "AKP", base64URL.EncodeToString(publicKey.Bytes())
+		if privateKey, ok := privateKey.(*mlkem.DecapsulationKey768); ok {
+			k.Priv = base64URL.EncodeToString(privateKey.Bytes())
+		}
+	case *mlkem.EncapsulationKey1024:
+		k.Kty, k.Pub = "AKP", base64URL.EncodeToString(publicKey.Bytes())
+		if privateKey, ok := privateKey.(*mlkem.DecapsulationKey1024); ok {
+			k.Priv = base64URL.EncodeToString(privateKey.Bytes())
+		}
+	}
+
+	k.Kid = jwkThumbprint(k)
+
+	return k, nil
+}
+
+// jwkThumbprint computes the RFC 7638 thumbprint of a JWK from its required public members in lexicographic order.
+func jwkThumbprint(k jwk) string {
+	var members string
+
+	switch k.Kty {
+	case "EC":
+		members = fmt.Sprintf(`{"crv":"%s","kty":"EC","x":"%s","y":"%s"}`, k.Crv, k.X, k.Y)
+	case "RSA":
+		members = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, k.E, k.N)
+	case "OKP":
+		members = fmt.Sprintf(`{"crv":"%s","kty":"OKP","x":"%s"}`, k.Crv, k.X)
+	case "AKP":
+		members = fmt.Sprintf(`{"alg":"%s","kty":"AKP","pub":"%s"}`, k.Alg, k.Pub)
+	}
+
+	sum := sha256.Sum256([]byte(members))
+
+	return base64URL.EncodeToString(sum[:])
+}
+
+// rsaCRTValues returns the CRT exponents and coefficient of a two-prime RSA private key. They are taken from the precomputed values
+// if the key has them, and computed otherwise, so the caller's key isn't modified as by rsa.PrivateKey.Precompute.
+func rsaCRTValues(privateKey *rsa.PrivateKey) (dp, dq, qinv *big.Int) {
+	if privateKey.Precomputed.Dp != nil && privateKey.Precomputed.Dq != nil && privateKey.Precomputed.Qinv != nil {
+		return privateKey.Precomputed.Dp, privateKey.Precomputed.Dq, privateKey.Precomputed.Qinv
+	}
+
+	p, q := privateKey.Primes[0], privateKey.Primes[1]
+	one := big.NewInt(1)
+
+	dp = new(big.Int).Mod(privateKey.D, new(big.Int).Sub(p, one))
+	dq = new(big.Int).Mod(privateKey.D, new(big.Int).Sub(q, one))
+	qinv = new(big.Int).ModInverse(q, p)
+
+	return dp, dq, qinv
+}
+
+func jwkCurveName(curve ecdh.Curve) string {
+	for name, c := range jwkECDHCurve {
+		if c == curve {
+			return name
+		}
+	}
+	return ""
+}
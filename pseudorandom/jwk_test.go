@@ -0,0 +1,43 @@
+package pseudorandom_test
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/copartner6412/input/pseudorandom"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzJWKS(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed1, seed2 uint64, a uint, count uint8, private bool) {
+		algorithm := pseudorandom.Algorithm(int(a % 14))
+		if algorithm == pseudorandom.AlgorithmECDSAP224 {
+			t.Skip("JOSE doesn't support ECDSA P224")
+		}
+
+		n := uint(count % 4)
+
+		jwks1, privateKeys, err := pseudorandom.JWKS(rand.New(rand.NewPCG(seed1, seed2)), algorithm, n, private)
+		if err != nil {
+			t.Fatalf("error generating a pseudo-random %s JWKS: %v", algorithm, err)
+		}
+
+		if err := validate.JWKS(jwks1, private); err != nil {
+			t.Fatalf("invalid %s JWKS: %v", algorithm, err)
+		}
+
+		if uint(len(privateKeys)) != n {
+			t.Fatalf("expected %d private keys, but got %d", n, len(privateKeys))
+		}
+
+		jwks2, _, err := pseudorandom.JWKS(rand.New(rand.NewPCG(seed1, seed2)), algorithm, n, private)
+		if err != nil {
+			t.Fatalf("error generating a pseudo-random %s JWKS: %v", algorithm, err)
+		}
+
+		if !bytes.Equal(jwks1, jwks2) {
+			t.Fatalf("expected identical JWKS for identical seeds")
+		}
+	})
+}
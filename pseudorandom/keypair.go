@@ -0,0 +1,301 @@
+package pseudorandom
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/mlkem"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"math/rand/v2"
+
+	"github.com/copartner6412/input/validate"
+)
+
+// Algorithm defines the supported key generation algorithms.
+type Algorithm = validate.Algorithm
+
+// List of supported algorithms for key generation.
+const (
+	AlgorithmUntyped   = validate.AlgorithmUntyped
+	AlgorithmED25519   = validate.AlgorithmED25519
+	AlgorithmECDSAP521 = validate.AlgorithmECDSAP521
+	AlgorithmECDSAP384 = validate.AlgorithmECDSAP384
+	AlgorithmECDSAP256 = validate.AlgorithmECDSAP256
+	AlgorithmECDSAP224 = validate.AlgorithmECDSAP224
+	AlgorithmRSA4096   = validate.AlgorithmRSA4096
+	AlgorithmRSA2048   = validate.AlgorithmRSA2048
+	AlgorithmRSA1024   = validate.AlgorithmRSA1024
+	AlgorithmX25519    = validate.AlgorithmX25519
+	AlgorithmECDHP256  = validate.AlgorithmECDHP256
+	AlgorithmECDHP384  = validate.AlgorithmECDHP384
+	AlgorithmMLKEM768  = validate.AlgorithmMLKEM768
+	AlgorithmMLKEM1024 = validate.AlgorithmMLKEM1024
+)
+
+const (
+	rsa1024 int = 1024
+	rsa2048 int = 2048
+	rsa4096 int = 4096
+
+	rsaPublicExponent      int = 65537
+	millerRabinRounds      int = 5  // FIPS 186-5 Table B.1 for primes of 1024 bits and more, in addition to the Baillie-PSW test of big.Int.ProbablyPrime
+	ecdsaExtraRandBits     int = 64 // FIPS 186-5 A.2.1
+	keyPairPersonalization     = "github.com/copartner6412/input/pseudorandom KeyPair "
+)
+
+// KeyPair generates a deterministic pseudo-random public-private key pair based on the specified algorithm using the provided random source.
+// If you don't know what algorithm to use, insert zero to use the default (ED25519) key generation algorithm.
+//
+// The standard library key generation functions don't produce reproducible RSA and ECDSA keys from a reader,
+// so the keys are derived from an HMAC_DRBG (NIST SP 800-90A) seeded by r instead:
+//   - ED25519: the 32-byte seed is read from the DRBG.
+//   - ECDSA: the private scalar is derived from the DRBG using extra random bits (FIPS 186-5 A.2.1).
+//   - RSA: the primes are generated from the DRBG as random probable primes (FIPS 186-5 A.1.3) with the public exponent 65537.
+//   - X25519: the 32-byte private key is read from the DRBG.
+//   - ECDH: the private scalar is derived like an ECDSA private scalar.
+//   - ML-KEM: the 64-byte seed (d || z) of the decapsulation key is read from the DRBG (FIPS 203).
+//
+// The same seed always produces the same key pair, independent of the Go version.
+func KeyPair(r *rand.Rand, algorithm Algorithm) (crypto.PublicKey, crypto.PrivateKey, error) {
+	switch algorithm {
+	case AlgorithmUntyped:
+		algorithm = AlgorithmED25519
+	case AlgorithmED25519, AlgorithmECDSAP521, AlgorithmECDSAP384, AlgorithmECDSAP256, AlgorithmECDSAP224, AlgorithmRSA4096, AlgorithmRSA2048, AlgorithmRSA1024,
+		AlgorithmX25519, AlgorithmECDHP256, AlgorithmECDHP384, AlgorithmMLKEM768, AlgorithmMLKEM1024:
+	default:
+		return nil, nil, errors.New("unsupported key generation algorithm")
+	}
+
+	drbg := newHMACDRBG(r, keyPairPersonalization+algorithm.String())
+
+	switch algorithm {
+	case AlgorithmED25519:
+		return generateED25519KeyPair(drbg)
+	case AlgorithmECDSAP521:
+		return generateECDSAKeyPair(drbg, elliptic.P521())
+	case AlgorithmECDSAP384:
+		return generateECDSAKeyPair(drbg, elliptic.P384())
+	case AlgorithmECDSAP256:
+		return generateECDSAKeyPair(drbg, elliptic.P256())
+	case AlgorithmECDSAP224:
+		return generateECDSAKeyPair(drbg, elliptic.P224())
+	case AlgorithmRSA4096:
+		return generateRSAKeyPair(drbg, rsa4096)
+	case AlgorithmRSA2048:
+		return generateRSAKeyPair(drbg, rsa2048)
+	case AlgorithmRSA1024:
+		return generateRSAKeyPair(drbg, rsa1024)
+	case AlgorithmX25519:
+		return generateX25519KeyPair(drbg)
+	case AlgorithmECDHP256:
+		return generateECDHKeyPair(drbg, ecdh.P256(), elliptic.P256())
+	case AlgorithmECDHP384:
+		return generateECDHKeyPair(drbg, ecdh.P384(), elliptic.P384())
+	case AlgorithmMLKEM768:
+		return generateMLKEM768KeyPair(drbg)
+	default:
+		return generateMLKEM1024KeyPair(drbg)
+	}
+}
+
+// generateED25519KeyPair derives an ED25519 public-private key pair from a seed read from drbg.
+func generateED25519KeyPair(drbg io.Reader) (ed25519.PublicKey, ed25519.PrivateKey, error) {
+	seed := make([]byte, ed25519.SeedSize)
+	if _, err := io.ReadFull(drbg, seed); err != nil {
+		return nil, nil, fmt.Errorf("error reading ED25519 seed: %w", err)
+	}
+
+	privateKey := ed25519.NewKeyFromSeed(seed)
+
+	return privateKey.Public().(ed25519.PublicKey), privateKey, nil
+}
+
+// generateECDSAKeyPair derives an ECDSA public-private key pair on the specified curve from a private scalar read from drbg.
+func generateECDSAKeyPair(drbg io.Reader, curve elliptic.Curve) (*ecdsa.PublicKey, *ecdsa.PrivateKey, error) {
+	d, err := generatePrivateScalar(drbg, curve)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating ECDSA key pair: %w", err)
+	}
+
+	privateKey := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: curve},
+		D:         d,
+	}
+	privateKey.PublicKey.X, privateKey.PublicKey.Y = curve.ScalarBaseMult(d.Bytes())
+
+	return &privateKey.PublicKey, privateKey, nil
+}
+
+// generateECDHKeyPair derives an ECDH public-private key pair on the specified NIST curve from a private scalar read from drbg.
+func generateECDHKeyPair(drbg io.Reader, curve ecdh.Curve, params elliptic.Curve) (*ecdh.PublicKey, *ecdh.PrivateKey, error) {
+	d, err := generatePrivateScalar(drbg, params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating ECDH key pair: %w", err)
+	}
+
+	privateKey, err := curve.NewPrivateKey(d.FillBytes(make([]byte, (params.Params().BitSize+7)/8)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating ECDH key pair: %w", err)
+	}
+
+	return privateKey.PublicKey(), privateKey, nil
+}
+
+// generatePrivateScalar derives a private scalar in [1, n-1] for the specified curve using extra random bits read from drbg (FIPS 186-5 A.2.1).
+func generatePrivateScalar(drbg io.Reader, curve elliptic.Curve) (*big.Int, error) {
+	n := curve.Params().N
+
+	// c is a random integer of len(n) + 64 bits, and d = (c mod (n - 1)) + 1.
+	randomBytes := make([]byte, (n.BitLen()+ecdsaExtraRandBits+7)/8)
+	if _, err := io.ReadFull(drbg, randomBytes); err != nil {
+		return nil, fmt.Errorf("error reading random bits: %w", err)
+	}
+
+	c := new(big.Int).SetBytes(randomBytes)
+	c.Rsh(c, uint(len(randomBytes)*8-n.BitLen()-ecdsaExtraRandBits))
+	d := c.Mod(c, new(big.Int).Sub(n, big.NewInt(1)))
+	d.Add(d, big.NewInt(1))
+
+	return d, nil
+}
+
+// generateX25519KeyPair derives an X25519 public-private key pair from a private key read from drbg.
+func generateX25519KeyPair(drbg io.Reader) (*ecdh.PublicKey, *ecdh.PrivateKey, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(drbg, key); err != nil {
+		return nil, nil, fmt.Errorf("error reading X25519 private key: %w", err)
+	}
+
+	privateKey, err := ecdh.X25519().NewPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating X25519 key pair: %w", err)
+	}
+
+	return privateKey.PublicKey(), privateKey, nil
+}
+
+// generateMLKEM768KeyPair derives an ML-KEM-768 encapsulation-decapsulation key pair from a seed read from drbg.
+func generateMLKEM768KeyPair(drbg io.Reader) (*mlkem.EncapsulationKey768, *mlkem.DecapsulationKey768, error) {
+	seed := make([]byte, mlkem.SeedSize)
+	if _, err := io.ReadFull(drbg, seed); err != nil {
+		return nil, nil, fmt.Errorf("error reading ML-KEM seed: %w", err)
+	}
+
+	decapsulationKey, err := mlkem.NewDecapsulationKey768(seed)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating ML-KEM-768 key pair: %w", err)
+	}
+
+	return decapsulationKey.EncapsulationKey(), decapsulationKey, nil
+}
+
+// generateMLKEM1024KeyPair derives an ML-KEM-1024 encapsulation-decapsulation key pair from a seed read from drbg.
+func generateMLKEM1024KeyPair(drbg io.Reader) (*mlkem.EncapsulationKey1024, *mlkem.DecapsulationKey1024, error) {
+	seed := make([]byte, mlkem.SeedSize)
+	if _, err := io.ReadFull(drbg, seed); err != nil {
+		return nil, nil, fmt.Errorf("error reading ML-KEM seed: %w", err)
+	}
+
+	decapsulationKey, err := mlkem.NewDecapsulationKey1024(seed)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating ML-KEM-1024 key pair: %w", err)
+	}
+
+	return decapsulationKey.EncapsulationKey(), decapsulationKey, nil
+}
+
+// generateRSAKeyPair derives an RSA public-private key pair of the specified modulus size from random probable primes generated from drbg (FIPS 186-5 A.1.3).
+func generateRSAKeyPair(drbg io.Reader, bits int) (*rsa.PublicKey, *rsa.PrivateKey, error) {
+	e := big.NewInt(int64(rsaPublicExponent))
+	one := big.NewInt(1)
+
+	for {
+		p, err := generateRSAPrime(drbg, bits, e, nil)
+		if errors.Is(err, errRSAPrimeNotFound) {
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		q, err := generateRSAPrime(drbg, bits, e, p)
+		if errors.Is(err, errRSAPrimeNotFound) {
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		pMinus1 := new(big.Int).Sub(p, one)
+		qMinus1 := new(big.Int).Sub(q, one)
+		gcd := new(big.Int).GCD(nil, nil, pMinus1, qMinus1)
+		lcm := new(big.Int).Div(new(big.Int).Mul(pMinus1, qMinus1), gcd)
+
+		// The private exponent must be larger than 2^(nlen/2) (FIPS 186-5 A.1.1).
+		d := new(big.Int).ModInverse(e, lcm)
+		if d == nil || d.BitLen() <= bits/2 {
+			continue
+		}
+
+		privateKey := &rsa.PrivateKey{
+			PublicKey: rsa.PublicKey{N: new(big.Int).Mul(p, q), E: rsaPublicExponent},
+			D:         d,
+			Primes:    []*big.Int{p, q},
+		}
+		privateKey.Precompute()
+
+		if err := privateKey.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("error generating RSA key pair: %w", err)
+		}
+
+		return &privateKey.PublicKey, privateKey, nil
+	}
+}
+
+// errRSAPrimeNotFound is returned by generateRSAPrime when no prime is found within the iteration limit of FIPS 186-5 A.1.3,
+// in which case generateRSAKeyPair starts over with the next DRBG output.
+var errRSAPrimeNotFound = errors.New("no RSA prime found within the iteration limit")
+
+// generateRSAPrime generates a random probable prime for an RSA modulus of the specified size (FIPS 186-5 A.1.3).
+// If p is not nil, the generated prime differs from p by more than 2^(nlen/2-100).
+func generateRSAPrime(drbg io.Reader, bits int, e, p *big.Int) (*big.Int, error) {
+	primeBits := bits / 2
+	one := big.NewInt(1)
+
+	// The prime must be at least sqrt(2) * 2^(nlen/2-1) so that the modulus has exactly nlen bits.
+	lowerBound := new(big.Int).Sqrt(new(big.Int).Lsh(one, uint(2*primeBits-1)))
+	minDistance := new(big.Int).Lsh(one, uint(primeBits-100))
+
+	candidateBytes := make([]byte, primeBits/8)
+
+	for i := 0; i < 5*primeBits; i++ {
+		if _, err := io.ReadFull(drbg, candidateBytes); err != nil {
+			return nil, fmt.Errorf("error reading RSA prime candidate: %w", err)
+		}
+
+		candidate := new(big.Int).SetBytes(candidateBytes)
+		candidate.SetBit(candidate, 0, 1)
+
+		if candidate.Cmp(lowerBound) <= 0 {
+			continue
+		}
+
+		if p != nil && new(big.Int).Abs(new(big.Int).Sub(candidate, p)).Cmp(minDistance) <= 0 {
+			continue
+		}
+
+		if new(big.Int).GCD(nil, nil, new(big.Int).Sub(candidate, one), e).Cmp(one) != 0 {
+			continue
+		}
+
+		if candidate.ProbablyPrime(millerRabinRounds) {
+			return candidate, nil
+		}
+	}
+
+	return nil, errRSAPrimeNotFound
+}
@@ -1,7 +1,11 @@
 package pseudorandom_test
 
 import (
-	"crypto/ed25519"
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
 	"math/rand/v2"
 	"testing"
 
@@ -9,31 +13,111 @@ import (
 	"github.com/copartner6412/input/validate"
 )
 
+var algorithms = []pseudorandom.Algorithm{
+	pseudorandom.AlgorithmUntyped,
+	pseudorandom.AlgorithmED25519,
+	pseudorandom.AlgorithmECDSAP521,
+	pseudorandom.AlgorithmECDSAP384,
+	pseudorandom.AlgorithmECDSAP256,
+	pseudorandom.AlgorithmECDSAP224,
+	pseudorandom.AlgorithmRSA4096,
+	pseudorandom.AlgorithmRSA2048,
+	pseudorandom.AlgorithmRSA1024,
+	pseudorandom.AlgorithmX25519,
+	pseudorandom.AlgorithmECDHP256,
+	pseudorandom.AlgorithmECDHP384,
+	pseudorandom.AlgorithmMLKEM768,
+	pseudorandom.AlgorithmMLKEM1024,
+}
+
+// privateKeyBytes returns the PKCS #8 encoding of a private key, or the seed of an ML-KEM decapsulation key which has no PKCS #8 encoding.
+func privateKeyBytes(t *testing.T, privateKey crypto.PrivateKey) []byte {
+	t.Helper()
+
+	if decapsulationKey, ok := privateKey.(interface{ Bytes() []byte }); ok {
+		return decapsulationKey.Bytes()
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("error marshaling private key: %v", err)
+	}
+
+	return der
+}
+
 func FuzzKeyPair(f *testing.F) {
-	f.Fuzz(func (t *testing.T, seed1, seed2 uint64)  {
+	f.Fuzz(func(t *testing.T, seed1, seed2 uint64, algorithmIndex uint) {
 		t.Parallel()
+		algorithm := algorithms[algorithmIndex%uint(len(algorithms))]
+
 		r1 := rand.New(rand.NewPCG(seed1, seed2))
-		reader1 := pseudorandom.New(r1)
-		publicKey1, privateKey1, err := ed25519.GenerateKey(reader1)
+		publicKey1, privateKey1, err := pseudorandom.KeyPair(r1, algorithm)
 		if err != nil {
-			t.Fatalf("error generating a pseudo-random ED25519 key pair: %v", err)
+			t.Fatalf("error generating a pseudo-random %s key pair: %v", algorithm, err)
 		}
 
-		err = validate.KeyPair(validate.AlgorithmED25519, publicKey1, privateKey1)
+		err = validate.KeyPair(algorithm, publicKey1, privateKey1)
 		if err != nil {
 			t.Fatalf("invalid key pair: %v", err)
 		}
 
 		r2 := rand.New(rand.NewPCG(seed1, seed2))
-		reader2 := pseudorandom.New(r2)
-
-		publicKey2, privateKey2, err := ed25519.GenerateKey(reader2)
+		publicKey2, privateKey2, err := pseudorandom.KeyPair(r2, algorithm)
 		if err != nil {
 			t.Fatalf("error regenerating the pseudo-random key pair: %v", err)
 		}
 
-		if !publicKey1.Equal(publicKey2) || !privateKey1.Equal(privateKey2) {
+		if !bytes.Equal(privateKeyBytes(t, privateKey1), privateKeyBytes(t, privateKey2)) {
+			t.Fatal("not deterministic")
+		}
+
+		if publicKey, ok := publicKey1.(interface{ Equal(crypto.PublicKey) bool }); ok && !publicKey.Equal(publicKey2) {
 			t.Fatal("not deterministic")
 		}
 	})
-}
\ No newline at end of file
+}
+
+// TestKeyPairGolden pins the SHA-256 hash of the encoding of the private key derived from a fixed seed,
+// so that a change of the derivation or of the Go version that alters the generated keys is detected.
+func TestKeyPairGolden(t *testing.T) {
+	testCases := map[pseudorandom.Algorithm]string{
+		pseudorandom.AlgorithmUntyped:   "cd7b53b83179c45e7e655c96b5c2b65b7808c6e9dc66e217c812c5f733b5214e",
+		pseudorandom.AlgorithmED25519:   "cd7b53b83179c45e7e655c96b5c2b65b7808c6e9dc66e217c812c5f733b5214e",
+		pseudorandom.AlgorithmECDSAP521: "9688af26386715ca792a1618b6104066d601de0366af42cf0b08ec4a73052973",
+		pseudorandom.AlgorithmECDSAP384: "41aadc028bb2588df65444208f18ae512d046ed076ebf02c46be110f30314086",
+		pseudorandom.AlgorithmECDSAP256: "d478e7e208b6062ccec4c615d1617b76b2943c23f7dec1155dd3cd0f237c6602",
+		pseudorandom.AlgorithmECDSAP224: "8e8c91f2f9f9365431ea88787054240524a9e75245715eebb88e512131c1e87b",
+		pseudorandom.AlgorithmRSA4096:   "4dce6ca722f8063aec1b1d3c4c25c71a699ecc1165f639ba554fe3447969f7b4",
+		pseudorandom.AlgorithmRSA2048:   "463cef5eb26eb81aba874f3566383d1183c0d9ba32ed954a228ee33f994eaa14",
+		pseudorandom.AlgorithmRSA1024:   "6a2a72f21a47bddb1f8198fefe64f6633dcd348786a9f810183c02ebdf7c23be",
+		pseudorandom.AlgorithmX25519:    "31eb685eeefdc58c69b628e61973bff9bd465315ec2cea398035ade5ccb5d765",
+		pseudorandom.AlgorithmECDHP256:  "7988dbcd837d1515da76ccf5255cc6493e5fe8fbc15bbe3a806e9633bf3b276a",
+		pseudorandom.AlgorithmECDHP384:  "3643b05e86ef2fbbd1f7265e326dc522f696439369ffa379f4fdcbf14ab39559",
+		pseudorandom.AlgorithmMLKEM768:  "6c395257d492db2b70a278439f774d1bfbaf28ebdd793e6e79a9b7828ed57b35",
+		pseudorandom.AlgorithmMLKEM1024: "e724345a0b54ba52e18df2243f72470d6a25d1d43e787662864da72daf0887ce",
+	}
+
+	for algorithm, expected := range testCases {
+		t.Run(algorithm.String(), func(t *testing.T) {
+			t.Parallel()
+			r := rand.New(rand.NewPCG(6412, 1024))
+			_, privateKey, err := pseudorandom.KeyPair(r, algorithm)
+			if err != nil {
+				t.Fatalf("error generating a pseudo-random %s key pair: %v", algorithm, err)
+			}
+
+			sum := sha256.Sum256(privateKeyBytes(t, privateKey))
+			if actual := hex.EncodeToString(sum[:]); actual != expected {
+				t.Fatalf("expected private key hash %s, but got %s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestKeyPairFailsForUnsupportedAlgorithm(t *testing.T) {
+	r := rand.New(rand.NewPCG(6412, 1024))
+	if _, _, err := pseudorandom.KeyPair(r, pseudorandom.Algorithm(-1)); err == nil {
+		t.Fatal("expected error for unsupported algorithm, but got nil")
+	}
+}
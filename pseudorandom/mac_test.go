@@ -0,0 +1,41 @@
+package pseudorandom_test
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/copartner6412/input/pseudorandom"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzMAC(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed1, seed2 uint64, local, multicast, eui64 bool) {
+		r1 := rand.New(rand.NewPCG(seed1, seed2))
+		mac1, err := pseudorandom.MAC(r1, local, multicast, eui64, nil)
+		if err != nil {
+			t.Fatalf("error generating a pseudo-random MAC address: %v", err)
+		}
+
+		expected := validate.MACFlags{Local: local, Multicast: multicast, EUI64: eui64}
+
+		flags, err := validate.MAC(mac1.String())
+		if err != nil {
+			t.Fatalf("unexpected error for the pseudo-random MAC address %s: %v", mac1, err)
+		}
+
+		if flags != expected {
+			t.Fatalf("expected flags %+v for the pseudo-random MAC address %s, but got %+v", expected, mac1, flags)
+		}
+
+		r2 := rand.New(rand.NewPCG(seed1, seed2))
+		mac2, err := pseudorandom.MAC(r2, local, multicast, eui64, nil)
+		if err != nil {
+			t.Fatalf("error regenerating the pseudo-random MAC address: %v", err)
+		}
+
+		if !bytes.Equal(mac1, mac2) {
+			t.Fatal("not deterministic")
+		}
+	})
+}
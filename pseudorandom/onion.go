@@ -0,0 +1,40 @@
+package pseudorandom
+
+import (
+	"crypto/ed25519"
+	"crypto/sha3"
+	"encoding/base32"
+	"math/rand/v2"
+	"strings"
+)
+
+const (
+	onionVersion        byte   = 3
+	onionChecksumPrefix string = ".onion checksum"
+	onionChecksumLength int    = 2
+)
+
+// OnionAddress generates a deterministic pseudo-random ED25519 key pair with KeyPair, using the provided random source, and returns it with the Tor v3 onion service address of its public key,
+// i.e. the lower-case base32 encoding of the public key, a two-byte SHA3-256 checksum and the version byte 0x03, followed by ".onion".
+func OnionAddress(r *rand.Rand) (ed25519.PublicKey, ed25519.PrivateKey, string, error) {
+	publicKey, privateKey, err := KeyPair(r, AlgorithmED25519)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	ed25519PublicKey := publicKey.(ed25519.PublicKey)
+
+	return ed25519PublicKey, privateKey.(ed25519.PrivateKey), onionAddress(ed25519PublicKey), nil
+}
+
+// onionAddress encodes an ED25519 public key as a Tor v3 onion address.
+func onionAddress(publicKey ed25519.PublicKey) string {
+	h := sha3.New256()
+	h.Write([]byte(onionChecksumPrefix))
+	h.Write(publicKey)
+	h.Write([]byte{onionVersion})
+
+	decoded := append(append([]byte(publicKey), h.Sum(nil)[:onionChecksumLength]...), onionVersion)
+
+	return strings.ToLower(base32.StdEncoding.EncodeToString(decoded)) + ".onion"
+}
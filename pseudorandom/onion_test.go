@@ -0,0 +1,35 @@
+package pseudorandom_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/copartner6412/input/pseudorandom"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzOnionAddress(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed1, seed2 uint64) {
+		publicKey, privateKey, address1, err := pseudorandom.OnionAddress(rand.New(rand.NewPCG(seed1, seed2)))
+		if err != nil {
+			t.Fatalf("error generating a pseudo-random onion address: %v", err)
+		}
+
+		if err := validate.OnionAddress(address1); err != nil {
+			t.Fatalf("invalid onion address \"%s\": %v", address1, err)
+		}
+
+		if err := validate.KeyPair(pseudorandom.AlgorithmED25519, publicKey, privateKey); err != nil {
+			t.Fatalf("invalid onion service key pair: %v", err)
+		}
+
+		_, _, address2, err := pseudorandom.OnionAddress(rand.New(rand.NewPCG(seed1, seed2)))
+		if err != nil {
+			t.Fatalf("error generating a pseudo-random onion address: %v", err)
+		}
+
+		if address1 != address2 {
+			t.Fatal("not deterministic")
+		}
+	})
+}
@@ -3,6 +3,8 @@ package pseudorandom
 import (
 	"fmt"
 	"math/rand/v2"
+
+	"github.com/copartner6412/input/validate"
 )
 
 const (
@@ -13,8 +15,18 @@ const (
 	maxPortAllowed       uint16 = 65535
 )
 
-// Port generates a deterministic pseudo-random port number in the range 0–65535.
+// PortRange is a set of ports, such as one parsed from "8000-8100,9000".
+type PortRange = validate.PortRange
+
+// Port generates a deterministic pseudo-random port number in the range [minPort, maxPort].
+// It is equivalent to PortBetween.
 func Port(r *rand.Rand, minPort, maxPort uint16) (uint16, error) {
+	return PortBetween(r, minPort, maxPort)
+}
+
+// PortBetween generates a deterministic pseudo-random port number in the range [minPort, maxPort].
+// A minPort and maxPort of 0 generate a port in the range [0, 65535].
+func PortBetween(r *rand.Rand, minPort, maxPort uint16) (uint16, error) {
 	if minPort == 0 && maxPort == 0 {
 		maxPort = maxPortAllowed
 	} else {
@@ -28,6 +40,24 @@ func Port(r *rand.Rand, minPort, maxPort uint16) (uint16, error) {
 	return port, nil
 }
 
+// PortInRange generates a deterministic pseudo-random port number of ports which is not in excluded.
+// If unregistered is true, ports registered to a service of validate.ServiceRegistry for any transport protocol are excluded too.
+// Every remaining port is equally likely.
+func PortInRange(r *rand.Rand, ports, excluded PortRange, unregistered bool) (uint16, error) {
+	if unregistered {
+		excluded = excluded.Union(validate.RegisteredPorts(""))
+	}
+
+	free := ports.Difference(excluded)
+	if free.Len() == 0 {
+		return 0, fmt.Errorf("no port left in %q after excluding %q", ports, excluded)
+	}
+
+	port, _ := free.At(r.IntN(free.Len()))
+
+	return port, nil
+}
+
 // PortWellKnown generates a deterministic pseudo-random well-known port number [0–1023].
 func PortWellKnown(r *rand.Rand) uint16 {
 	return uint16(r.UintN(limitPortsWellKnown))
@@ -71,3 +71,61 @@ func FuzzPortPrivate(f *testing.F) {
 		}
 	})
 }
+
+func FuzzPortBetween(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed1, seed2 uint64, minPort, maxPort uint16) {
+		if maxPort < minPort {
+			minPort, maxPort = maxPort, minPort
+		}
+		r1 := rand.New(rand.NewPCG(seed1, seed2))
+		port1, err := pseudorandom.PortBetween(r1, minPort, maxPort)
+		if err != nil {
+			t.Fatalf("error generating a pseudo-random port in [%d, %d]: %v", minPort, maxPort, err)
+		}
+		err = validate.Port(port1, minPort, maxPort)
+		if err != nil {
+			t.Errorf("expected no error for valid pseudo-random port %d, but got error: %v", port1, err)
+		}
+		r2 := rand.New(rand.NewPCG(seed1, seed2))
+		port2, err := pseudorandom.PortBetween(r2, minPort, maxPort)
+		if err != nil {
+			t.Fatalf("error generating a pseudo-random port in [%d, %d]: %v", minPort, maxPort, err)
+		}
+		if port1 != port2 {
+			t.Errorf("not deterministic, expected: %d, got: %d", port1, port2)
+		}
+	})
+}
+
+func FuzzPortInRange(f *testing.F) {
+	ports, err := validate.ParsePortRange("0-1023,8000-8100,9000")
+	if err != nil {
+		f.Fatalf("error parsing port range: %v", err)
+	}
+	excluded, err := validate.ParsePortRange("80,443,8000-8079")
+	if err != nil {
+		f.Fatalf("error parsing excluded port range: %v", err)
+	}
+
+	f.Fuzz(func(t *testing.T, seed1, seed2 uint64) {
+		r1 := rand.New(rand.NewPCG(seed1, seed2))
+		port1, err := pseudorandom.PortInRange(r1, ports, excluded, true)
+		if err != nil {
+			t.Fatalf("error generating a pseudo-random port in range: %v", err)
+		}
+		if err := validate.PortInRange(port1, ports, excluded); err != nil {
+			t.Errorf("expected no error for valid pseudo-random port %d, but got error: %v", port1, err)
+		}
+		if err := validate.PortNotRegistered(port1, ""); err != nil {
+			t.Errorf("expected no error for valid pseudo-random unregistered port %d, but got error: %v", port1, err)
+		}
+		r2 := rand.New(rand.NewPCG(seed1, seed2))
+		port2, err := pseudorandom.PortInRange(r2, ports, excluded, true)
+		if err != nil {
+			t.Fatalf("error generating a pseudo-random port in range: %v", err)
+		}
+		if port1 != port2 {
+			t.Errorf("not deterministic, expected: %d, got: %d", port1, port2)
+		}
+	})
+}
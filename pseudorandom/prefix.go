@@ -0,0 +1,18 @@
+package pseudorandom
+
+import (
+	"math/rand/v2"
+	"net/netip"
+)
+
+// CIDRv4Prefix generates a deterministic pseudo-random IPv4 network as a masked netip.Prefix with a prefix length between 8 and 30, like CIDRv4.
+func CIDRv4Prefix(r *rand.Rand) netip.Prefix {
+	addr := prefixAddr(r, netip.PrefixFrom(netip.IPv4Unspecified(), 0))
+	return netip.PrefixFrom(addr, r.IntN(23)+8).Masked()
+}
+
+// CIDRv6Prefix generates a deterministic pseudo-random IPv6 network as a masked netip.Prefix with a prefix length between 8 and 126, like CIDRv6.
+func CIDRv6Prefix(r *rand.Rand) netip.Prefix {
+	addr := prefixAddr(r, netip.PrefixFrom(netip.IPv6Unspecified(), 0))
+	return netip.PrefixFrom(addr, r.IntN(119)+8).Masked()
+}
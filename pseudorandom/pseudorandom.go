@@ -1,3 +1,7 @@
+// Package pseudorandom provides utilities for generating deterministic pseudo-random inputs.
+//
+// Enumerations and sets shared with the validate package, such as Algorithm and PortRange, are aliases of the validate types,
+// so a generated value can be passed to the matching validator as is.
 package pseudorandom
 
 var digitRunes = []rune("0123456789")
@@ -0,0 +1,134 @@
+package pseudorandom
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// rfc6979Signer is a crypto.Signer which signs with an ECDSA private key deterministically as specified in RFC 6979,
+// so signatures don't depend on a random source or on the Go version.
+type rfc6979Signer struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+// Public implements the crypto.Signer interface.
+func (s rfc6979Signer) Public() crypto.PublicKey {
+	return &s.privateKey.PublicKey
+}
+
+// Sign implements the crypto.Signer interface and returns an ASN.1 DER encoded signature of digest.
+// It ignores rand, and opts specifies the hash function used both for digest and for deriving the nonce.
+func (s rfc6979Signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	hash := opts.HashFunc()
+	if !hash.Available() {
+		return nil, fmt.Errorf("unavailable hash function %v", hash)
+	}
+	if len(digest) != hash.Size() {
+		return nil, fmt.Errorf("digest length %d doesn't match %v digest length of %d", len(digest), hash, hash.Size())
+	}
+
+	params := s.privateKey.Curve.Params()
+	n := params.N
+	e := bits2int(digest, n)
+
+	generate := newRFC6979Nonces(hash, s.privateKey.D, e, n)
+	for {
+		k := generate()
+
+		x, _ := s.privateKey.Curve.ScalarBaseMult(k.FillBytes(make([]byte, (n.BitLen()+7)/8)))
+		r := x.Mod(x, n)
+		if r.Sign() == 0 {
+			continue
+		}
+
+		// s = k^-1 * (e + r * d) mod n
+		sig := new(big.Int).Mul(r, s.privateKey.D)
+		sig.Add(sig, e)
+		sig.Mul(sig, new(big.Int).ModInverse(k, n))
+		sig.Mod(sig, n)
+		if sig.Sign() == 0 {
+			continue
+		}
+
+		return asn1.Marshal(struct{ R, S *big.Int }{r, sig})
+	}
+}
+
+// newRFC6979Nonces returns a function which generates the successive candidates of the nonce k in [1, n-1]
+// for the private key d and the hash e of the message with HMAC_DRBG (RFC 6979, section 3.2).
+func newRFC6979Nonces(hash crypto.Hash, d, e, n *big.Int) func() *big.Int {
+	length := (n.BitLen() + 7) / 8
+	privateKey := d.FillBytes(make([]byte, length))
+	message := new(big.Int).Mod(e, n).FillBytes(make([]byte, length))
+
+	k := make([]byte, hash.Size())
+	v := make([]byte, hash.Size())
+	for i := range v {
+		v[i] = 0x01
+	}
+
+	mac := func(key []byte, data ...[]byte) []byte {
+		h := hmac.New(hash.New, key)
+		for _, b := range data {
+			h.Write(b)
+		}
+		return h.Sum(nil)
+	}
+
+	k = mac(k, v, []byte{0x00}, privateKey, message)
+	v = mac(k, v)
+	k = mac(k, v, []byte{0x01}, privateKey, message)
+	v = mac(k, v)
+
+	first := true
+
+	return func() *big.Int {
+		for {
+			if !first {
+				k = mac(k, v, []byte{0x00})
+				v = mac(k, v)
+			}
+			first = false
+
+			var t []byte
+			for len(t) < length {
+				v = mac(k, v)
+				t = append(t, v...)
+			}
+
+			nonce := bits2int(t, n)
+			if nonce.Sign() > 0 && nonce.Cmp(n) < 0 {
+				return nonce
+			}
+		}
+	}
+}
+
+// bits2int converts b to an integer of at most the bit length of n by keeping its leftmost bits (RFC 6979, section 2.3.2).
+func bits2int(b []byte, n *big.Int) *big.Int {
+	i := new(big.Int).SetBytes(b)
+	if excess := len(b)*8 - n.BitLen(); excess > 0 {
+		i.Rsh(i, uint(excess))
+	}
+	return i
+}
+
+// deterministicSigner returns a signer which signs deterministically with privateKey,
+// or an error if privateKey is not a crypto.Signer.
+func deterministicSigner(privateKey crypto.PrivateKey) (crypto.Signer, error) {
+	switch privateKey := privateKey.(type) {
+	case *ecdsa.PrivateKey:
+		return rfc6979Signer{privateKey}, nil
+	case crypto.Signer:
+		// ED25519 and RSA PKCS #1 v1.5 signatures are deterministic.
+		return privateKey, nil
+	default:
+		return nil, errors.New("private key does not implement crypto.Signer")
+	}
+}
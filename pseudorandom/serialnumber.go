@@ -0,0 +1,34 @@
+package pseudorandom
+
+import (
+	"math/big"
+	"math/rand/v2"
+)
+
+const (
+	maxSerialNumberLength  int = 20 // RFC 5280 4.1.2.2
+	minSerialNumberBitSize int = 64 // CA/Browser Forum Baseline Requirements 7.1
+)
+
+// SerialNumber generates a deterministic pseudo-random X.509 certificate serial number in the format required by RFC 5280 and the CA/Browser Forum Baseline Requirements.
+// Pseudo-random serial numbers are only suitable for tests, since the Baseline Requirements demand output of a CSPRNG.
+// The serial number is generated as 20 octets with the most significant bit cleared, so it is positive,
+// holds 159 bits of randomness and its DER encoding fits in 20 octets without a leading zero octet.
+// Serial numbers shorter than 64 bits are discarded and generated again, so the DER encoding is at least 8 octets long.
+func SerialNumber(r *rand.Rand) *big.Int {
+	serialBytes := make([]byte, maxSerialNumberLength)
+
+	for {
+		for i := range serialBytes {
+			serialBytes[i] = byte(r.UintN(maxByteNumber))
+		}
+
+		// Clear the most significant bit so that the DER encoding doesn't need a leading zero octet.
+		serialBytes[0] &= 0x7f
+
+		serialNumber := new(big.Int).SetBytes(serialBytes)
+		if serialNumber.BitLen() >= minSerialNumberBitSize {
+			return serialNumber
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package pseudorandom_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/copartner6412/input/pseudorandom"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzSerialNumber(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed1, seed2 uint64) {
+		serialNumber1 := pseudorandom.SerialNumber(rand.New(rand.NewPCG(seed1, seed2)))
+
+		err := validate.SerialNumber(serialNumber1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		serialNumber2 := pseudorandom.SerialNumber(rand.New(rand.NewPCG(seed1, seed2)))
+
+		if serialNumber1.Cmp(serialNumber2) != 0 {
+			t.Fatal("not deterministic")
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package pseudorandom
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"net/netip"
+
+	"github.com/copartner6412/input/validate"
+)
+
+// SubnetAllocator carves deterministic pseudo-random non-overlapping subnets out of a supernet.
+// For a given seed, the same sequence of allocations returns the same subnets.
+type SubnetAllocator struct {
+	r        *rand.Rand
+	supernet netip.Prefix
+	free     validate.IPSet
+}
+
+// NewSubnetAllocator returns a SubnetAllocator for subnets of the supernet which don't overlap the excluded prefixes, using the provided random source.
+func NewSubnetAllocator(r *rand.Rand, supernet netip.Prefix, excluded ...netip.Prefix) (*SubnetAllocator, error) {
+	if !supernet.IsValid() {
+		return nil, fmt.Errorf("invalid supernet %s", supernet)
+	}
+
+	set, _ := validate.NewIPSet(supernet)
+
+	excludedSet, err := validate.NewIPSet(excluded...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid excluded prefix: %w", err)
+	}
+
+	return &SubnetAllocator{r: r, supernet: supernet.Masked(), free: set.Difference(excludedSet)}, nil
+}
+
+// Allocate returns a pseudo-random subnet with the specified prefix length which doesn't overlap the excluded prefixes or any subnet allocated before.
+// Every free aligned subnet of the length is equally likely. Allocating the largest subnets first avoids fragmenting the supernet.
+func (a *SubnetAllocator) Allocate(bits uint) (netip.Prefix, error) {
+	if bits < uint(a.supernet.Bits()) || bits > uint(a.supernet.Addr().BitLen()) {
+		return netip.Prefix{}, fmt.Errorf("prefix length %d outside of range [%d, %d] of supernet %s", bits, a.supernet.Bits(), a.supernet.Addr().BitLen(), a.supernet)
+	}
+
+	var candidates []netip.Prefix
+	total := new(big.Int)
+
+	for _, prefix := range a.free.Prefixes() {
+		if uint(prefix.Bits()) <= bits {
+			candidates = append(candidates, prefix)
+			total.Add(total, new(big.Int).Lsh(big.NewInt(1), bits-uint(prefix.Bits())))
+		}
+	}
+
+	if total.Sign() == 0 {
+		return netip.Prefix{}, fmt.Errorf("no free /%d subnet left in %s", bits, a.supernet)
+	}
+
+	n := bigIntN(a.r, total)
+
+	i := 0
+	for ; i < len(candidates)-1; i++ {
+		count := new(big.Int).Lsh(big.NewInt(1), bits-uint(candidates[i].Bits()))
+		if n.Cmp(count) < 0 {
+			break
+		}
+		n.Sub(n, count)
+	}
+
+	var host [16]byte
+	n.Lsh(n, uint(candidates[i].Addr().BitLen())-bits).FillBytes(host[:])
+	subnet := netip.PrefixFrom(hostAddr(candidates[i], host), int(bits))
+
+	used, _ := validate.NewIPSet(subnet)
+	a.free = a.free.Difference(used)
+
+	return subnet, nil
+}
+
+// Free returns the set of the addresses of the supernet which are neither excluded nor allocated.
+func (a *SubnetAllocator) Free() validate.IPSet {
+	return a.free
+}
+
+// bigIntN returns a uniform pseudo-random number in [0, max) by rejection sampling. max must be positive.
+func bigIntN(r *rand.Rand, max *big.Int) *big.Int {
+	bitLen := max.BitLen()
+
+	for {
+		n := new(big.Int)
+		for range (bitLen + 63) / 64 {
+			n.Lsh(n, 64).Or(n, new(big.Int).SetUint64(r.Uint64()))
+		}
+		n.Rsh(n, uint((bitLen+63)/64*64-bitLen))
+
+		if n.Cmp(max) < 0 {
+			return n
+		}
+	}
+}
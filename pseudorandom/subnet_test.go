@@ -0,0 +1,48 @@
+package pseudorandom_test
+
+import (
+	"math/rand/v2"
+	"net/netip"
+	"slices"
+	"testing"
+
+	"github.com/copartner6412/input/pseudorandom"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzSubnetAllocator(f *testing.F) {
+	supernet := netip.MustParsePrefix("fd00::/48")
+	excluded := netip.MustParsePrefix("fd00:0:0:8000::/49")
+
+	allocate := func(t *testing.T, r *rand.Rand) []string {
+		allocator, err := pseudorandom.NewSubnetAllocator(r, supernet, excluded)
+		if err != nil {
+			t.Fatalf("error creating subnet allocator: %v", err)
+		}
+
+		var subnets []string
+		for _, bits := range []uint{52, 56, 64, 64, 80, 128} {
+			subnet, err := allocator.Allocate(bits)
+			if err != nil {
+				t.Fatalf("error allocating a /%d subnet: %v", bits, err)
+			}
+			subnets = append(subnets, subnet.String())
+		}
+
+		return subnets
+	}
+
+	f.Fuzz(func(t *testing.T, seed1, seed2 uint64) {
+		subnets1 := allocate(t, rand.New(rand.NewPCG(seed1, seed2)))
+
+		if err := validate.Subnets(subnets1, supernet, excluded); err != nil {
+			t.Fatalf("unexpected error for the pseudo-random subnets %v: %v", subnets1, err)
+		}
+
+		subnets2 := allocate(t, rand.New(rand.NewPCG(seed1, seed2)))
+
+		if !slices.Equal(subnets1, subnets2) {
+			t.Fatal("not deterministic")
+		}
+	})
+}
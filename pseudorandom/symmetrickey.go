@@ -0,0 +1,44 @@
+package pseudorandom
+
+import (
+	"math/rand/v2"
+
+	"github.com/copartner6412/input/validate"
+)
+
+// SymmetricAlgorithm defines the supported symmetric key algorithms.
+type SymmetricAlgorithm = validate.SymmetricAlgorithm
+
+// List of supported symmetric key algorithms.
+const (
+	SymmetricAlgorithmAES128GCM        = validate.SymmetricAlgorithmAES128GCM
+	SymmetricAlgorithmAES256GCM        = validate.SymmetricAlgorithmAES256GCM
+	SymmetricAlgorithmChaCha20Poly1305 = validate.SymmetricAlgorithmChaCha20Poly1305
+	SymmetricAlgorithmHMACSHA256       = validate.SymmetricAlgorithmHMACSHA256
+	SymmetricAlgorithmHMACSHA512       = validate.SymmetricAlgorithmHMACSHA512
+	SymmetricAlgorithmHS256            = validate.SymmetricAlgorithmHS256
+	SymmetricAlgorithmHS384            = validate.SymmetricAlgorithmHS384
+	SymmetricAlgorithmHS512            = validate.SymmetricAlgorithmHS512
+)
+
+// SymmetricKey generates a deterministic pseudo-random key of the size required by the specified symmetric algorithm, using the provided random source.
+// Keys rejected by validate.SymmetricKey for low entropy are discarded and generated again.
+// Pseudo-random keys are only suitable for tests.
+func SymmetricKey(r *rand.Rand, algorithm SymmetricAlgorithm) ([]byte, error) {
+	size, err := algorithm.KeySize()
+	if err != nil {
+		return nil, err
+	}
+
+	key := make([]byte, size)
+
+	for {
+		for i := range key {
+			key[i] = byte(r.UintN(maxByteNumber))
+		}
+
+		if validate.SymmetricKey(key, algorithm) == nil {
+			return key, nil
+		}
+	}
+}
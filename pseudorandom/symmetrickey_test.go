@@ -0,0 +1,34 @@
+package pseudorandom_test
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/copartner6412/input/pseudorandom"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzSymmetricKey(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed1, seed2 uint64, a uint) {
+		algorithm := pseudorandom.SymmetricAlgorithm(int(a % 8))
+
+		key1, err := pseudorandom.SymmetricKey(rand.New(rand.NewPCG(seed1, seed2)), algorithm)
+		if err != nil {
+			t.Fatalf("error generating a pseudo-random %s key: %v", algorithm, err)
+		}
+
+		if err := validate.SymmetricKey(key1, algorithm); err != nil {
+			t.Fatalf("invalid %s key: %v", algorithm, err)
+		}
+
+		key2, err := pseudorandom.SymmetricKey(rand.New(rand.NewPCG(seed1, seed2)), algorithm)
+		if err != nil {
+			t.Fatalf("error generating a pseudo-random %s key: %v", algorithm, err)
+		}
+
+		if !bytes.Equal(key1, key2) {
+			t.Fatal("not deterministic")
+		}
+	})
+}
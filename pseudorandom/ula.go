@@ -0,0 +1,39 @@
+package pseudorandom
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net/netip"
+
+	"github.com/copartner6412/input/validate"
+)
+
+const (
+	ulaPrefixLength       int = 48
+	ulaSubnetPrefixLength int = 64
+)
+
+// ULAPrefix generates a deterministic pseudo-random unique local IPv6 /48 prefix in fd00::/8 with a 40-bit Global ID
+// using the provided random source (RFC 4193, section 3.2).
+func ULAPrefix(r *rand.Rand) netip.Prefix {
+	for {
+		prefix := netip.PrefixFrom(prefixAddr(r, netip.MustParsePrefix("fd00::/8")), ulaPrefixLength).Masked()
+		if validate.ULA(prefix) == nil {
+			return prefix
+		}
+	}
+}
+
+// ULASubnet generates a deterministic pseudo-random /64 subnet of the unique local IPv6 prefix using the provided random source,
+// i.e. a 16-bit Subnet ID for a /48 prefix.
+func ULASubnet(r *rand.Rand, prefix netip.Prefix) (netip.Prefix, error) {
+	if err := validate.ULA(prefix); err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid ULA prefix: %w", err)
+	}
+
+	if prefix.Bits() > ulaSubnetPrefixLength {
+		return netip.Prefix{}, fmt.Errorf("ULA prefix %s longer than /%d", prefix, ulaSubnetPrefixLength)
+	}
+
+	return netip.PrefixFrom(prefixAddr(r, prefix), ulaSubnetPrefixLength).Masked(), nil
+}
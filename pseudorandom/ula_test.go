@@ -0,0 +1,36 @@
+package pseudorandom_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/copartner6412/input/pseudorandom"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzULA(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed1, seed2 uint64) {
+		r1 := rand.New(rand.NewPCG(seed1, seed2))
+		prefix1 := pseudorandom.ULAPrefix(r1)
+		subnet1, err := pseudorandom.ULASubnet(r1, prefix1)
+		if err != nil {
+			t.Fatalf("error generating a pseudo-random ULA subnet: %v", err)
+		}
+
+		if err := validate.ULA(prefix1); err != nil || prefix1.Bits() != 48 {
+			t.Fatalf("unexpected error for the pseudo-random ULA prefix %s: %v", prefix1, err)
+		}
+
+		if err := validate.CIDRWithin(subnet1.String(), prefix1); err != nil {
+			t.Fatalf("unexpected error for the pseudo-random ULA subnet %s of %s: %v", subnet1, prefix1, err)
+		}
+
+		r2 := rand.New(rand.NewPCG(seed1, seed2))
+		prefix2 := pseudorandom.ULAPrefix(r2)
+		subnet2, _ := pseudorandom.ULASubnet(r2, prefix2)
+
+		if prefix1 != prefix2 || subnet1 != subnet2 {
+			t.Fatal("not deterministic")
+		}
+	})
+}
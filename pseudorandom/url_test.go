@@ -0,0 +1,37 @@
+package pseudorandom_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/copartner6412/input/pseudorandom"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzURL(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed1, seed2 uint64, minLength, maxLength uint, userinfo bool, hostPolicy uint8) {
+		minLength, maxLength = minLength%(8000+1), maxLength%(8000+1)
+		if maxLength < minLength {
+			minLength, maxLength = maxLength, minLength
+		}
+		host := pseudorandom.URLHost(hostPolicy % 3)
+
+		r1 := rand.New(rand.NewPCG(seed1, seed2))
+		url1, err := pseudorandom.URL(r1, minLength, maxLength, nil, host, userinfo)
+		if err != nil {
+			t.Skip(err)
+		}
+		if err := validate.URL(url1, minLength, maxLength, nil, host); err != nil {
+			t.Errorf("expected no error for valid pseudo-random URL %q, but got error: %v", url1, err)
+		}
+
+		r2 := rand.New(rand.NewPCG(seed1, seed2))
+		url2, err := pseudorandom.URL(r2, minLength, maxLength, nil, host, userinfo)
+		if err != nil {
+			t.Fatalf("error generating a pseudo-random URL: %v", err)
+		}
+		if url1 != url2 {
+			t.Errorf("not deterministic, expected: %q, got: %q", url1, url2)
+		}
+	})
+}
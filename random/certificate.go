@@ -0,0 +1,291 @@
+package random
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"time"
+)
+
+// CertificateType defines the kinds of X.509 certificates generated by Certificate.
+type CertificateType int
+
+// List of supported certificate types.
+const (
+	// Self-signed root CA certificate.
+	CertificateTypeRoot CertificateType = iota
+	// Intermediate CA certificate signed by a root or another intermediate CA.
+	CertificateTypeIntermediate
+	// Leaf certificate for TLS servers and clients with DNS, IP and email subject alternative names.
+	CertificateTypeLeaf
+)
+
+var certificateTypeString = map[CertificateType]string{
+	CertificateTypeRoot:         "root",
+	CertificateTypeIntermediate: "intermediate",
+	CertificateTypeLeaf:         "leaf",
+}
+
+func (c CertificateType) String() string {
+	return certificateTypeString[c]
+}
+
+const (
+	day  time.Duration = 24 * time.Hour
+	year time.Duration = 365 * day
+
+	minRootCertificateValidity         time.Duration = 10 * year
+	maxRootCertificateValidity         time.Duration = 20 * year
+	minIntermediateCertificateValidity time.Duration = 3 * year
+	maxIntermediateCertificateValidity time.Duration = 5 * year
+	minLeafCertificateValidity         time.Duration = day
+	maxLeafCertificateValidity         time.Duration = 397 * day // maximum validity of publicly trusted TLS certificates
+	maxCertificateBackdating           time.Duration = time.Hour // tolerance for clock skew between issuer and relying parties
+
+	maxCertificateDNSNames    int  = 3
+	maxCertificateIPAddresses int  = 2
+	minSANDomainLength        uint = 8
+	maxSANDomainLength        uint = 63
+	minSANEmailLength         uint = 16
+	maxSANEmailLength         uint = 64
+)
+
+// CertificateChain generates a root certificate, the specified number of intermediate certificates and a leaf certificate with Certificate,
+// all using the specified key generation algorithm. It returns the chain ordered from the leaf to the root and the private key of the leaf certificate.
+func CertificateChain(randomness io.Reader, algorithm Algorithm, intermediates uint) ([]*x509.Certificate, crypto.PrivateKey, error) {
+	issuer, issuerKey, err := Certificate(randomness, CertificateTypeRoot, algorithm, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chain := []*x509.Certificate{issuer}
+
+	for i := uint(0); i < intermediates; i++ {
+		issuer, issuerKey, err = Certificate(randomness, CertificateTypeIntermediate, algorithm, issuer, issuerKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		chain = append([]*x509.Certificate{issuer}, chain...)
+	}
+
+	leaf, leafKey, err := Certificate(randomness, CertificateTypeLeaf, algorithm, issuer, issuerKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return append([]*x509.Certificate{leaf}, chain...), leafKey, nil
+}
+
+// Certificate generates a key pair with KeyPair and an X.509 certificate of the specified type for it, and returns the certificate and its private key.
+// The certificate has a random serial number, a random subject and a random validity window starting up to an hour before now,
+// which lasts 10 to 20 years for root certificates, 3 to 5 years for intermediate certificates and 1 to 397 days for leaf certificates.
+// Leaf certificates get one to three random DNS names and up to two IP addresses and one email address as subject alternative names.
+//
+// Root certificates are self-signed and ignore issuer and issuerKey. Intermediate and leaf certificates are signed with issuerKey,
+// and their validity window is limited to the validity window of issuer. If issuer is nil, a leaf certificate is self-signed.
+// Only the signature algorithms (ED25519, ECDSA and RSA) are supported.
+func Certificate(randomness io.Reader, certificateType CertificateType, algorithm Algorithm, issuer *x509.Certificate, issuerKey crypto.PrivateKey) (*x509.Certificate, crypto.PrivateKey, error) {
+	switch algorithm {
+	case AlgorithmUntyped, AlgorithmED25519, AlgorithmECDSAP521, AlgorithmECDSAP384, AlgorithmECDSAP256, AlgorithmECDSAP224, AlgorithmRSA4096, AlgorithmRSA2048, AlgorithmRSA1024:
+	default:
+		return nil, nil, fmt.Errorf("algorithm %s can not be used for certificates", algorithm)
+	}
+
+	if certificateType == CertificateTypeRoot {
+		issuer, issuerKey = nil, nil
+	}
+
+	template, err := certificateTemplate(randomness, certificateType, issuer, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	publicKey, privateKey, err := KeyPair(randomness, algorithm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// RSA keys of TLS 1.2 servers can also be used for key transport.
+	if certificateType == CertificateTypeLeaf && isRSA(algorithm) {
+		template.KeyUsage |= x509.KeyUsageKeyEncipherment
+	}
+
+	parent, signer := issuer, issuerKey
+	if issuer == nil {
+		parent, signer = template, privateKey
+	}
+
+	der, err := x509.CreateCertificate(randomness, template, parent, publicKey, signer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating %s certificate: %w", certificateType, err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing %s certificate: %w", certificateType, err)
+	}
+
+	return certificate, privateKey, nil
+}
+
+// certificateTemplate generates the random fields of a certificate of the specified type issued by issuer at now.
+func certificateTemplate(randomness io.Reader, certificateType CertificateType, issuer *x509.Certificate, now time.Time) (*x509.Certificate, error) {
+	var minValidity, maxValidity time.Duration
+
+	switch certificateType {
+	case CertificateTypeRoot:
+		minValidity, maxValidity = minRootCertificateValidity, maxRootCertificateValidity
+	case CertificateTypeIntermediate:
+		if issuer == nil {
+			return nil, errors.New("nil issuer for intermediate certificate")
+		}
+		minValidity, maxValidity = minIntermediateCertificateValidity, maxIntermediateCertificateValidity
+	case CertificateTypeLeaf:
+		minValidity, maxValidity = minLeafCertificateValidity, maxLeafCertificateValidity
+	default:
+		return nil, errors.New("unsupported certificate type")
+	}
+
+	serialNumber, err := SerialNumber(randomness)
+	if err != nil {
+		return nil, err
+	}
+
+	backdating, err := Duration(randomness, 0, maxCertificateBackdating)
+	if err != nil {
+		return nil, fmt.Errorf("error generating backdating of validity window: %w", err)
+	}
+
+	validity, err := Duration(randomness, minValidity, maxValidity)
+	if err != nil {
+		return nil, fmt.Errorf("error generating validity period: %w", err)
+	}
+
+	notBefore := now.Add(-backdating).UTC().Truncate(time.Second)
+	notAfter := notBefore.Add(validity)
+
+	if issuer != nil {
+		if notBefore.Before(issuer.NotBefore) {
+			notBefore = issuer.NotBefore
+		}
+		if notAfter.After(issuer.NotAfter) {
+			notAfter = issuer.NotAfter
+		}
+	}
+
+	subject, err := certificateSubject(randomness, issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      subject,
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+
+	switch certificateType {
+	case CertificateTypeRoot:
+		template.Subject.CommonName = template.Subject.Organization[0] + " Root CA"
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+	case CertificateTypeIntermediate:
+		template.Subject.CommonName = template.Subject.Organization[0] + " Intermediate CA"
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+	case CertificateTypeLeaf:
+		if err := certificateSANs(randomness, template); err != nil {
+			return nil, err
+		}
+		template.Subject.CommonName = template.DNSNames[0]
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+		if len(template.EmailAddresses) > 0 {
+			template.ExtKeyUsage = append(template.ExtKeyUsage, x509.ExtKeyUsageEmailProtection)
+		}
+	}
+
+	return template, nil
+}
+
+// certificateSubject generates a random subject, which inherits the country and organization of issuer if issuer is not nil.
+func certificateSubject(randomness io.Reader, issuer *x509.Certificate) (pkix.Name, error) {
+	if issuer != nil && len(issuer.Subject.Organization) > 0 && len(issuer.Subject.Country) > 0 {
+		return pkix.Name{Country: issuer.Subject.Country, Organization: issuer.Subject.Organization}, nil
+	}
+
+	country, err := CountryCode2(randomness)
+	if err != nil {
+		return pkix.Name{}, fmt.Errorf("error generating subject country: %w", err)
+	}
+
+	organization, err := Username(randomness, true, false, nil)
+	if err != nil {
+		return pkix.Name{}, fmt.Errorf("error generating subject organization: %w", err)
+	}
+
+	return pkix.Name{Country: []string{country}, Organization: []string{organization}}, nil
+}
+
+// certificateSANs adds random DNS names, IP addresses and email addresses to the subject alternative names of template.
+func certificateSANs(randomness io.Reader, template *x509.Certificate) error {
+	dnsNameCount, err := rand.Int(randomness, big.NewInt(int64(maxCertificateDNSNames)))
+	if err != nil {
+		return fmt.Errorf("error generating a random number for DNS names count: %w", err)
+	}
+
+	for i := 0; i <= int(dnsNameCount.Int64()); i++ {
+		dnsName, err := DomainWithValidTLD(randomness, minSANDomainLength, maxSANDomainLength)
+		if err != nil {
+			return fmt.Errorf("error generating DNS name: %w", err)
+		}
+		template.DNSNames = append(template.DNSNames, dnsName)
+	}
+
+	ipAddressCount, err := rand.Int(randomness, big.NewInt(int64(maxCertificateIPAddresses)+1))
+	if err != nil {
+		return fmt.Errorf("error generating a random number for IP addresses count: %w", err)
+	}
+
+	for i := 0; i < int(ipAddressCount.Int64()); i++ {
+		var ip net.IP
+		if i%2 == 0 {
+			ip, err = IPv4(randomness, "")
+		} else {
+			ip, err = IPv6(randomness, "")
+		}
+		if err != nil {
+			return fmt.Errorf("error generating IP address: %w", err)
+		}
+		template.IPAddresses = append(template.IPAddresses, ip)
+	}
+
+	emailAddressCount, err := rand.Int(randomness, big.NewInt(2))
+	if err != nil {
+		return fmt.Errorf("error generating a random number for email addresses count: %w", err)
+	}
+
+	if emailAddressCount.Int64() == 1 {
+		email, err := Email(randomness, minSANEmailLength, maxSANEmailLength, false, false)
+		if err != nil {
+			return fmt.Errorf("error generating email address: %w", err)
+		}
+		template.EmailAddresses = append(template.EmailAddresses, email)
+	}
+
+	return nil
+}
+
+func isRSA(algorithm Algorithm) bool {
+	return algorithm == AlgorithmRSA4096 || algorithm == AlgorithmRSA2048 || algorithm == AlgorithmRSA1024
+}
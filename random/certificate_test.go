@@ -0,0 +1,51 @@
+package random_test
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzCertificateChain(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a, intermediates uint) {
+		algorithm := random.Algorithm(int(a % 8))
+		if algorithm >= random.AlgorithmRSA4096 {
+			// Keep the fuzz iterations fast: RSA 4096 is replaced by RSA 2048.
+			algorithm = random.AlgorithmRSA2048
+		}
+
+		chain, leafKey, err := random.CertificateChain(rand.Reader, algorithm, intermediates%3)
+		if err != nil {
+			t.Fatalf("error generating a random %s certificate chain: %v", algorithm, err)
+		}
+
+		if len(chain) != int(intermediates%3)+2 {
+			t.Fatalf("expected chain of %d certificates, but got %d", intermediates%3+2, len(chain))
+		}
+
+		err = validate.CertificateChain(chain, time.Time{})
+		if err != nil {
+			t.Fatalf("invalid certificate chain: %v", err)
+		}
+
+		for _, certificate := range chain {
+			err = validate.SerialNumber(certificate.SerialNumber)
+			if err != nil {
+				t.Fatalf("invalid serial number: %v", err)
+			}
+		}
+
+		err = validate.CertificateSANs(chain[0])
+		if err != nil {
+			t.Fatalf("invalid subject alternative names: %v", err)
+		}
+
+		err = validate.KeyPair(algorithm, chain[0].PublicKey, leafKey)
+		if err != nil {
+			t.Fatalf("leaf private key doesn't match certificate: %v", err)
+		}
+	})
+}
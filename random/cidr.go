@@ -0,0 +1,78 @@
+package random
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/netip"
+
+	"github.com/copartner6412/input/validate"
+)
+
+// IPFamily defines the address families of IP addresses and networks.
+type IPFamily = validate.IPFamily
+
+// List of address families.
+const (
+	IPFamilyAny  = validate.IPFamilyAny
+	IPFamilyIPv4 = validate.IPFamilyIPv4
+	IPFamilyIPv6 = validate.IPFamilyIPv6
+)
+
+// CIDR generates a random IP network in canonical form within the parent prefix, with a prefix length between minPrefix and maxPrefix.
+// If both minPrefix and maxPrefix are 0, the prefix length ranges from the length of the parent to the length of an address.
+// For example, a parent of "0.0.0.0/0" with lengths 16 to 24 generates any IPv4 network from /16 to /24.
+//
+// The prefix length is drawn first and the network bits are then drawn within the parent.
+func CIDR(randomness io.Reader, parent netip.Prefix, minPrefix, maxPrefix uint) (netip.Prefix, error) {
+	bits, err := cidrPrefixLength(randomness, parent, minPrefix, maxPrefix)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	addr, err := prefixAddr(randomness, parent)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	return netip.PrefixFrom(addr, bits).Masked(), nil
+}
+
+func cidrPrefixLength(randomness io.Reader, parent netip.Prefix, minPrefix, maxPrefix uint) (int, error) {
+	if !parent.IsValid() {
+		return 0, errors.New("invalid parent prefix")
+	}
+
+	parentBits, bitLen := uint(parent.Bits()), uint(parent.Addr().BitLen())
+
+	if minPrefix == 0 && maxPrefix == 0 {
+		minPrefix, maxPrefix = parentBits, bitLen
+	} else {
+		if maxPrefix < minPrefix {
+			return 0, errors.New("maximum prefix length can not be less than minimum prefix length")
+		}
+
+		var errs []error
+
+		if minPrefix < parentBits {
+			errs = append(errs, fmt.Errorf("minimum prefix length must not be less than %d of the parent prefix", parentBits))
+		}
+
+		if maxPrefix > bitLen {
+			errs = append(errs, fmt.Errorf("maximum prefix length must not exceed %d", bitLen))
+		}
+
+		if len(errs) > 0 {
+			return 0, errors.Join(errs...)
+		}
+	}
+
+	random1, err := rand.Int(randomness, big.NewInt(int64(maxPrefix-minPrefix)+1))
+	if err != nil {
+		return 0, fmt.Errorf("error generating a random number for prefix length: %w", err)
+	}
+
+	return int(minPrefix) + int(random1.Int64()), nil
+}
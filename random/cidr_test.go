@@ -0,0 +1,56 @@
+package random_test
+
+import (
+	"crypto/rand"
+	"net/netip"
+	"testing"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzCIDR(f *testing.F) {
+	f.Fuzz(func(t *testing.T, bits1, bits2 uint8) {
+		parent, err := random.CIDRv6Prefix(rand.Reader)
+		if err != nil {
+			t.Fatalf("error generating a random parent prefix: %v", err)
+		}
+
+		minPrefix := uint(parent.Bits()) + uint(bits1)%uint(129-parent.Bits())
+		maxPrefix := minPrefix + uint(bits2)%(129-minPrefix)
+
+		cidr, err := random.CIDR(rand.Reader, parent, minPrefix, maxPrefix)
+		if err != nil {
+			t.Fatalf("error generating a random CIDR within %s of /%d to /%d: %v", parent, minPrefix, maxPrefix, err)
+		}
+
+		if err := validate.CIDR(cidr.String(), validate.IPFamilyIPv6, minPrefix, maxPrefix); err != nil {
+			t.Fatalf("unexpected error for the random CIDR \"%s\": %v", cidr, err)
+		}
+
+		if err := validate.CIDRWithin(cidr.String(), parent); err != nil {
+			t.Fatalf("unexpected error for the random CIDR \"%s\" within \"%s\": %v", cidr, parent, err)
+		}
+	})
+}
+
+func TestCIDRFailsForInvalidInput(t *testing.T) {
+	testCases := map[string]struct {
+		parent    netip.Prefix
+		minPrefix uint
+		maxPrefix uint
+	}{
+		"Zero parent":                   {netip.Prefix{}, 0, 0},
+		"Inverted range":                {netip.MustParsePrefix("10.0.0.0/8"), 24, 16},
+		"Minimum shorter than parent":   {netip.MustParsePrefix("10.0.0.0/8"), 4, 16},
+		"Maximum longer than addresses": {netip.MustParsePrefix("10.0.0.0/8"), 16, 64},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := random.CIDR(rand.Reader, tc.parent, tc.minPrefix, tc.maxPrefix); err == nil {
+				t.Errorf("expected error, but got nil")
+			}
+		})
+	}
+}
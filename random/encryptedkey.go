@@ -0,0 +1,250 @@
+package random
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/ssh"
+)
+
+// KeyEncryption defines the supported formats for passphrase-encrypted private keys.
+type KeyEncryption int
+
+// List of supported formats for passphrase-encrypted private keys.
+const (
+	// OpenSSH private key format encrypted with AES-256-CTR and a key derived by the bcrypt KDF (16 rounds).
+	KeyEncryptionOpenSSH KeyEncryption = iota
+	// PKCS #8 PBES2 "ENCRYPTED PRIVATE KEY" encrypted with AES-256-CBC and a key derived by scrypt (N=2^14, r=8, p=5).
+	KeyEncryptionPKCS8Scrypt
+	// PKCS #8 PBES2 "ENCRYPTED PRIVATE KEY" encrypted with AES-256-CBC and a key derived by PBKDF2-HMAC-SHA256 (600,000 iterations).
+	KeyEncryptionPKCS8PBKDF2
+)
+
+var keyEncryptionString = map[KeyEncryption]string{
+	KeyEncryptionOpenSSH:     "OpenSSH bcrypt",
+	KeyEncryptionPKCS8Scrypt: "PKCS #8 scrypt",
+	KeyEncryptionPKCS8PBKDF2: "PKCS #8 PBKDF2",
+}
+
+func (e KeyEncryption) String() string {
+	return keyEncryptionString[e]
+}
+
+const (
+	pemTypeEncryptedPrivateKey string = "ENCRYPTED PRIVATE KEY"
+	pbes2SaltLength            int    = 16
+	pbes2KeyLength             int    = 32 // AES-256
+	pbkdf2Iterations           int    = 600000
+	scryptCostParameter        int    = 1 << 14 // OWASP N=2^17, r=8, p=1 equivalent that stays within the 32 MiB scrypt memory limit of OpenSSL
+	scryptBlockSize            int    = 8
+	scryptParallelization      int    = 5
+)
+
+var (
+	oidPBES2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidScrypt         = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11591, 4, 11}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidAES256CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+// encryptedPrivateKeyInfo is the ASN.1 structure of a PKCS #8 encrypted private key (RFC 5958).
+type encryptedPrivateKeyInfo struct {
+	EncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedData       []byte
+}
+
+// pbes2Params is the ASN.1 structure of PBES2 parameters (RFC 8018).
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params is the ASN.1 structure of PBKDF2 parameters (RFC 8018).
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier
+}
+
+// scryptParams is the ASN.1 structure of scrypt parameters (RFC 7914).
+type scryptParams struct {
+	Salt                     []byte
+	CostParameter            int
+	BlockSize                int
+	ParallelizationParameter int
+	KeyLength                int `asn1:"optional"`
+}
+
+// EncryptedKeyPair generates a key pair with KeyPair and a password with PasswordFor, and returns the public key, the private key encrypted with the password, and the password.
+//
+// The password profile matches the encryption format: PasswordProfileSSHKey (or PasswordProfileSSHCAKey if ca is true) for KeyEncryptionOpenSSH,
+// and PasswordProfileTLSKey (or PasswordProfileTLSCAKey if ca is true) for the PKCS #8 formats.
+func EncryptedKeyPair(randomness io.Reader, algorithm Algorithm, encryption KeyEncryption, ca bool) (publicKey crypto.PublicKey, encryptedPrivateKey []byte, password string, err error) {
+	var profile PasswordProfile
+
+	switch encryption {
+	case KeyEncryptionOpenSSH:
+		profile = PasswordProfileSSHKey
+		if ca {
+			profile = PasswordProfileSSHCAKey
+		}
+	case KeyEncryptionPKCS8Scrypt, KeyEncryptionPKCS8PBKDF2:
+		profile = PasswordProfileTLSKey
+		if ca {
+			profile = PasswordProfileTLSCAKey
+		}
+	default:
+		return nil, nil, "", errors.New("unsupported private key encryption format")
+	}
+
+	publicKey, privateKey, err := KeyPair(randomness, algorithm)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	password, err = PasswordFor(randomness, profile)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	encryptedPrivateKey, err = EncryptPrivateKey(randomness, privateKey, password, encryption)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	return publicKey, encryptedPrivateKey, password, nil
+}
+
+// EncryptPrivateKey encrypts a private key generated by KeyPair with the given password and returns it PEM-encoded in the specified format.
+// The salt and initialization vector of the PKCS #8 formats are read from randomness, while the OpenSSH format always takes its salt from crypto/rand.
+// Keys which EncodePrivateKeyOpenSSH or EncodePrivateKeyPEM can't encode can't be encrypted in the matching format either, and ErrUnsupportedAlgorithm is returned.
+func EncryptPrivateKey(randomness io.Reader, privateKey crypto.PrivateKey, password string, encryption KeyEncryption) ([]byte, error) {
+	if password == "" {
+		return nil, errors.New("empty password")
+	}
+
+	switch encryption {
+	case KeyEncryptionOpenSSH:
+		if err := checkOpenSSH(privateKey); err != nil {
+			return nil, err
+		}
+		block, err := ssh.MarshalPrivateKeyWithPassphrase(privateKey, "", []byte(password))
+		if err != nil {
+			return nil, fmt.Errorf("error encrypting private key in OpenSSH format: %w", err)
+		}
+		return pem.EncodeToMemory(block), nil
+	case KeyEncryptionPKCS8Scrypt, KeyEncryptionPKCS8PBKDF2:
+		if err := checkPKCS8(privateKey); err != nil {
+			return nil, err
+		}
+		der, err := encryptPKCS8(randomness, privateKey, password, encryption)
+		if err != nil {
+			return nil, fmt.Errorf("error encrypting private key in PKCS #8 format: %w", err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: pemTypeEncryptedPrivateKey, Bytes: der}), nil
+	default:
+		return nil, errors.New("unsupported private key encryption format")
+	}
+}
+
+// encryptPKCS8 encrypts the PKCS #8 encoding of a private key using PBES2 with AES-256-CBC and the key derivation function of the specified format.
+func encryptPKCS8(randomness io.Reader, privateKey crypto.PrivateKey, password string, encryption KeyEncryption) ([]byte, error) {
+	plaintext, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling private key to PKCS #8: %w", err)
+	}
+
+	salt := make([]byte, pbes2SaltLength)
+	if _, err := io.ReadFull(randomness, salt); err != nil {
+		return nil, fmt.Errorf("error generating random salt: %w", err)
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(randomness, iv); err != nil {
+		return nil, fmt.Errorf("error generating random initialization vector: %w", err)
+	}
+
+	var key []byte
+	var kdf pkix.AlgorithmIdentifier
+
+	switch encryption {
+	case KeyEncryptionPKCS8Scrypt:
+		key, err = scrypt.Key([]byte(password), salt, scryptCostParameter, scryptBlockSize, scryptParallelization, pbes2KeyLength)
+		if err != nil {
+			return nil, fmt.Errorf("error deriving key with scrypt: %w", err)
+		}
+
+		kdf, err = algorithmIdentifier(oidScrypt, scryptParams{
+			Salt:                     salt,
+			CostParameter:            scryptCostParameter,
+			BlockSize:                scryptBlockSize,
+			ParallelizationParameter: scryptParallelization,
+			KeyLength:                pbes2KeyLength,
+		})
+	case KeyEncryptionPKCS8PBKDF2:
+		key = pbkdf2.Key([]byte(password), salt, pbkdf2Iterations, pbes2KeyLength, sha256.New)
+
+		kdf, err = algorithmIdentifier(oidPBKDF2, pbkdf2Params{
+			Salt:           salt,
+			IterationCount: pbkdf2Iterations,
+			KeyLength:      pbes2KeyLength,
+			PRF:            pkix.AlgorithmIdentifier{Algorithm: oidHMACWithSHA256, Parameters: asn1.NullRawValue},
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, err := algorithmIdentifier(oidAES256CBC, iv)
+	if err != nil {
+		return nil, err
+	}
+
+	pbes2, err := algorithmIdentifier(oidPBES2, pbes2Params{KeyDerivationFunc: kdf, EncryptionScheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating AES cipher: %w", err)
+	}
+
+	// Pad the plaintext to a multiple of the block size as specified by PKCS #5.
+	padding := aes.BlockSize - len(plaintext)%aes.BlockSize
+	plaintext = append(plaintext, bytes.Repeat([]byte{byte(padding)}, padding)...)
+
+	ciphertext := make([]byte, len(plaintext))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, plaintext)
+
+	der, err := asn1.Marshal(encryptedPrivateKeyInfo{EncryptionAlgorithm: pbes2, EncryptedData: ciphertext})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling encrypted private key info: %w", err)
+	}
+
+	return der, nil
+}
+
+// algorithmIdentifier returns an AlgorithmIdentifier with the given OID and the DER encoding of parameters.
+func algorithmIdentifier(oid asn1.ObjectIdentifier, parameters any) (pkix.AlgorithmIdentifier, error) {
+	der, err := asn1.Marshal(parameters)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, fmt.Errorf("error marshaling parameters of algorithm %s: %w", oid, err)
+	}
+
+	return pkix.AlgorithmIdentifier{Algorithm: oid, Parameters: asn1.RawValue{FullBytes: der}}, nil
+}
@@ -0,0 +1,41 @@
+package random_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzEncryptedKeyPair(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a, e uint, ca bool) {
+		algorithm := random.Algorithm(int(a % 14))
+		encryption := random.KeyEncryption(int(e % 3))
+
+		var unsupported bool
+		if encryption == random.KeyEncryptionOpenSSH {
+			_, err := algorithm.OpenSSH()
+			unsupported = err != nil
+		} else {
+			unsupported = algorithm == random.AlgorithmMLKEM768 || algorithm == random.AlgorithmMLKEM1024
+		}
+
+		_, encryptedPrivateKey, password, err := random.EncryptedKeyPair(rand.Reader, algorithm, encryption, ca)
+		if unsupported {
+			if !errors.Is(err, random.ErrUnsupportedAlgorithm) {
+				t.Fatalf("expected ErrUnsupportedAlgorithm encrypting %s private key in %s format, but got %v", algorithm.String(), encryption.String(), err)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("error generating a random %s key pair encrypted in %s format: %v", algorithm.String(), encryption.String(), err)
+		}
+
+		err = validate.EncryptedPrivateKey(algorithm, encryptedPrivateKey, password)
+		if err != nil {
+			t.Fatalf("invalid encrypted private key: %v", err)
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package random
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/copartner6412/input/validate"
+	"golang.org/x/crypto/ssh"
+)
+
+// FingerprintFormat defines the supported public key fingerprint formats.
+type FingerprintFormat = validate.FingerprintFormat
+
+// List of supported fingerprint formats.
+const (
+	FingerprintFormatSHA256 = validate.FingerprintFormatSHA256
+	FingerprintFormatMD5    = validate.FingerprintFormatMD5
+	FingerprintFormatSPKI   = validate.FingerprintFormatSPKI
+	FingerprintFormatSSHFP  = validate.FingerprintFormatSSHFP
+)
+
+// Fingerprint returns the fingerprint of a public key generated by KeyPair in the specified format:
+//   - FingerprintFormatSHA256: "SHA256:" followed by the unpadded base64 SHA-256 hash of the OpenSSH key, as printed by ssh-keygen -l.
+//   - FingerprintFormatMD5: "MD5:" followed by the colon-separated hexadecimal MD5 hash of the OpenSSH key, as printed by ssh-keygen -l -E md5.
+//   - FingerprintFormatSPKI: the base64 SHA-256 hash of the DER-encoded SubjectPublicKeyInfo, as used by HPKP pin-sha256 directives.
+//   - FingerprintFormatSSHFP: the SSHFP record data with a SHA-256 fingerprint, e.g. "4 2 " followed by the hexadecimal hash.
+//
+// OpenSSH formats are only defined for ED25519, ECDSA (except P-224) and RSA keys. SPKI pins are defined for keys of every algorithm.
+func Fingerprint(publicKey crypto.PublicKey, format FingerprintFormat) (string, error) {
+	if publicKey == nil {
+		return "", errors.New("nil public key")
+	}
+
+	if format == FingerprintFormatSPKI {
+		spki, err := validate.MarshalPKIXPublicKey(publicKey)
+		if err != nil {
+			return "", err
+		}
+		sum := sha256.Sum256(spki)
+		return base64.StdEncoding.EncodeToString(sum[:]), nil
+	}
+
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("public key not supported by OpenSSH: %w", err)
+	}
+
+	switch format {
+	case FingerprintFormatSHA256:
+		return ssh.FingerprintSHA256(sshPublicKey), nil
+	case FingerprintFormatMD5:
+		return "MD5:" + ssh.FingerprintLegacyMD5(sshPublicKey), nil
+	case FingerprintFormatSSHFP:
+		algorithm, err := validate.DetectAlgorithm(publicKey)
+		if err != nil {
+			return "", err
+		}
+		number, err := algorithm.SSHFP()
+		if err != nil {
+			return "", err
+		}
+		sum := sha256.Sum256(sshPublicKey.Marshal())
+		return fmt.Sprintf("%d 2 %s", number, hex.EncodeToString(sum[:])), nil
+	default:
+		return "", errors.New("unsupported fingerprint format")
+	}
+}
@@ -0,0 +1,43 @@
+package random_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzFingerprint(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a uint, b uint) {
+		algorithm := random.Algorithm(int(a % 14))
+		format := random.FingerprintFormat(int(b % 4))
+
+		if _, err := algorithm.OpenSSH(); err != nil && format != random.FingerprintFormatSPKI {
+			t.Skipf("%s fingerprints not defined for %s keys", format, algorithm)
+		}
+
+		publicKey, _, err := random.KeyPair(rand.Reader, algorithm)
+		if err != nil {
+			t.Fatalf("error generating a random key pair of type %s: %v", algorithm, err)
+		}
+
+		fingerprint, err := random.Fingerprint(publicKey, format)
+		if err != nil {
+			t.Fatalf("error generating %s fingerprint of %s key: %v", format, algorithm, err)
+		}
+
+		if err := validate.Fingerprint(fingerprint, format, publicKey); err != nil {
+			t.Fatalf("invalid %s fingerprint \"%s\" of %s key: %v", format, fingerprint, algorithm, err)
+		}
+
+		otherPublicKey, _, err := random.KeyPair(rand.Reader, algorithm)
+		if err != nil {
+			t.Fatalf("error generating a random key pair of type %s: %v", algorithm, err)
+		}
+
+		if err := validate.Fingerprint(fingerprint, format, otherPublicKey); err == nil {
+			t.Fatalf("expected error for %s fingerprint of a different %s key, but got nil", format, algorithm)
+		}
+	})
+}
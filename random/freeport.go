@@ -0,0 +1,156 @@
+package random
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/copartner6412/input/validate"
+)
+
+// Transport defines the transport protocols on which FreePort binds a port.
+type Transport int
+
+// List of transport protocols.
+const (
+	TransportTCP Transport = iota
+	TransportUDP
+	TransportTCPAndUDP
+)
+
+var transportString = map[Transport]string{
+	TransportTCP:       "TCP",
+	TransportUDP:       "UDP",
+	TransportTCPAndUDP: "TCP and UDP",
+}
+
+func (t Transport) String() string {
+	return transportString[t]
+}
+
+const (
+	procUnprivilegedPortStart    = "/proc/sys/net/ipv4/ip_unprivileged_port_start"
+	defaultUnprivilegedPortStart = 1024
+	maxFreePortAttempts          = 100
+)
+
+var loopback = netip.AddrFrom4([4]byte{127, 0, 0, 1})
+
+// HeldPort is a local port bound on the loopback interface, which no other socket can bind until it is released.
+type HeldPort struct {
+	Port       uint16
+	Listener   net.Listener   // TCP listener, or nil for TransportUDP.
+	PacketConn net.PacketConn // UDP socket, or nil for TransportTCP.
+}
+
+// Release closes the sockets holding the port, so it can be bound again.
+func (p *HeldPort) Release() error {
+	var errs []error
+	if p.Listener != nil {
+		errs = append(errs, p.Listener.Close())
+	}
+	if p.PacketConn != nil {
+		errs = append(errs, p.PacketConn.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// FreePort tries random ports of ports which are not in excluded and returns the first one it can bind on 127.0.0.1
+// for the transport protocols, held by the bound sockets. Passing the held listener to the code which needs the port,
+// or releasing it just before, avoids the race of checking a port and binding it later, after another process may have taken it.
+//
+// Port 0 is never chosen. When the process doesn't run as root, ports below the threshold returned by UnprivilegedPortStart
+// are skipped, since binding them needs privileges. The threshold may be above 1024.
+func FreePort(randomness io.Reader, transport Transport, ports, excluded PortRange) (*HeldPort, error) {
+	if _, ok := transportString[transport]; !ok {
+		return nil, fmt.Errorf("invalid transport %d", transport)
+	}
+
+	zero, _ := validate.NewPortRange(0, 0)
+	free := ports.Difference(excluded).Difference(zero)
+
+	if os.Geteuid() != 0 {
+		start, err := UnprivilegedPortStart()
+		if err != nil {
+			return nil, err
+		}
+		if first, ok := free.At(0); ok && first < start {
+			privileged, _ := validate.NewPortRange(0, start-1)
+			free = free.Difference(privileged)
+		}
+	}
+
+	if free.Len() == 0 {
+		return nil, fmt.Errorf("no port left in %q after excluding %q and privileged ports", ports, excluded)
+	}
+
+	var errs []error
+	for attempt := 0; attempt < maxFreePortAttempts && free.Len() > 0; attempt++ {
+		port, err := PortInRange(randomness, free, PortRange{}, false)
+		if err != nil {
+			return nil, err
+		}
+
+		held, err := holdPort(transport, port)
+		if err == nil {
+			return held, nil
+		}
+		errs = append(errs, err)
+
+		tried, _ := validate.NewPortRange(port, port)
+		free = free.Difference(tried)
+	}
+
+	return nil, fmt.Errorf("no free %s port found in %d attempts: %w", transport, len(errs), errors.Join(errs...))
+}
+
+// holdPort binds the port on the loopback interface for the transport protocols.
+func holdPort(transport Transport, port uint16) (*HeldPort, error) {
+	address := netip.AddrPortFrom(loopback, port).String()
+	held := &HeldPort{Port: port}
+
+	if transport == TransportTCP || transport == TransportTCPAndUDP {
+		listener, err := net.Listen("tcp4", address)
+		if err != nil {
+			return nil, err
+		}
+		held.Listener = listener
+	}
+
+	if transport == TransportUDP || transport == TransportTCPAndUDP {
+		conn, err := net.ListenPacket("udp4", address)
+		if err != nil {
+			held.Release()
+			return nil, err
+		}
+		held.PacketConn = conn
+	}
+
+	return held, nil
+}
+
+// UnprivilegedPortStart returns the lowest port which unprivileged processes can bind, read from /proc/sys/net/ipv4/ip_unprivileged_port_start.
+// A missing file, as on systems other than Linux and on kernels before 4.11, is treated as the traditional threshold of 1024.
+func UnprivilegedPortStart() (uint16, error) {
+	data, err := os.ReadFile(procUnprivilegedPortStart)
+	if errors.Is(err, fs.ErrNotExist) {
+		return defaultUnprivilegedPortStart, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error reading unprivileged port threshold: %w", err)
+	}
+
+	start, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid unprivileged port threshold in %s: %w", procUnprivilegedPortStart, err)
+	}
+
+	return uint16(start), nil
+}
@@ -0,0 +1,157 @@
+package random_test
+
+import (
+	"crypto/rand"
+	"net"
+	"net/netip"
+	"os"
+	"testing"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+func TestFreePortHoldsPort(t *testing.T) {
+	ports, err := validate.ParsePortRange("20000-40000")
+	if err != nil {
+		t.Fatalf("error parsing port range: %v", err)
+	}
+
+	for _, transport := range []random.Transport{random.TransportTCP, random.TransportUDP, random.TransportTCPAndUDP} {
+		t.Run(transport.String(), func(t *testing.T) {
+			held, err := random.FreePort(rand.Reader, transport, ports, random.PortRange{})
+			if err != nil {
+				t.Fatalf("error reserving a free port: %v", err)
+			}
+
+			if !ports.Contains(held.Port) {
+				t.Errorf("port %d is not in %s", held.Port, ports)
+			}
+
+			address := netip.AddrPortFrom(netip.MustParseAddr("127.0.0.1"), held.Port).String()
+
+			if transport != random.TransportUDP {
+				if held.Listener == nil {
+					t.Fatal("expected a TCP listener")
+				}
+				if listener, err := net.Listen("tcp4", address); err == nil {
+					listener.Close()
+					t.Errorf("expected TCP port %d to be held", held.Port)
+				}
+			}
+
+			if transport != random.TransportTCP {
+				if held.PacketConn == nil {
+					t.Fatal("expected a UDP socket")
+				}
+				if conn, err := net.ListenPacket("udp4", address); err == nil {
+					conn.Close()
+					t.Errorf("expected UDP port %d to be held", held.Port)
+				}
+			}
+
+			if err := held.Release(); err != nil {
+				t.Fatalf("error releasing port %d: %v", held.Port, err)
+			}
+
+			if transport != random.TransportUDP {
+				listener, err := net.Listen("tcp4", address)
+				if err != nil {
+					t.Fatalf("expected TCP port %d to be free after release, but got error: %v", held.Port, err)
+				}
+				listener.Close()
+			}
+		})
+	}
+}
+
+func TestFreePortSkipsPortsInUse(t *testing.T) {
+	ports, err := validate.ParsePortRange("20000-40000")
+	if err != nil {
+		t.Fatalf("error parsing port range: %v", err)
+	}
+
+	first, err := random.FreePort(rand.Reader, random.TransportTCP, ports, random.PortRange{})
+	if err != nil {
+		t.Fatalf("error reserving a free port: %v", err)
+	}
+	defer first.Release()
+
+	single, _ := validate.NewPortRange(first.Port, first.Port)
+	if held, err := random.FreePort(rand.Reader, random.TransportTCP, single, random.PortRange{}); err == nil {
+		held.Release()
+		t.Fatalf("expected error for port %d in use, but got no error", first.Port)
+	}
+
+	pair, _ := validate.NewPortRange(first.Port, first.Port+1)
+	second, err := random.FreePort(rand.Reader, random.TransportTCP, pair, random.PortRange{})
+	if err != nil {
+		t.Skipf("port %d is in use by another process: %v", first.Port+1, err)
+	}
+	defer second.Release()
+
+	if second.Port != first.Port+1 {
+		t.Errorf("expected port %d, but got %d", first.Port+1, second.Port)
+	}
+}
+
+func TestFreePortFailsForInvalidInput(t *testing.T) {
+	ports, _ := validate.ParsePortRange("20000-20010")
+
+	testCases := map[string]struct {
+		transport random.Transport
+		ports     random.PortRange
+		excluded  random.PortRange
+	}{
+		"Invalid transport":   {random.Transport(-1), ports, random.PortRange{}},
+		"Empty range":         {random.TransportTCP, random.PortRange{}, random.PortRange{}},
+		"Every port excluded": {random.TransportTCP, ports, ports},
+		"Port 0":              {random.TransportTCP, mustNewPortRange(t, 0, 0), random.PortRange{}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if held, err := random.FreePort(rand.Reader, testCase.transport, testCase.ports, testCase.excluded); err == nil {
+				held.Release()
+				t.Error("expected error, but got no error")
+			}
+		})
+	}
+}
+
+func TestFreePortSkipsPrivilegedPorts(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root can bind privileged ports")
+	}
+
+	start, err := random.UnprivilegedPortStart()
+	if err != nil {
+		t.Fatalf("error reading the unprivileged port threshold: %v", err)
+	}
+	if start == 0 {
+		t.Skip("every port is unprivileged")
+	}
+
+	// The port just below the threshold is privileged, even when the threshold is above 1024.
+	if held, err := random.FreePort(rand.Reader, random.TransportTCP, mustNewPortRange(t, start-1, start-1), random.PortRange{}); err == nil {
+		held.Release()
+		t.Errorf("expected error for privileged port %d, but got no error", start-1)
+	}
+}
+
+func TestUnprivilegedPortStart(t *testing.T) {
+	if _, err := random.UnprivilegedPortStart(); err != nil {
+		t.Errorf("expected no error reading the unprivileged port threshold, but got error: %v", err)
+	}
+}
+
+func mustNewPortRange(t *testing.T, minPort, maxPort uint16) random.PortRange {
+	t.Helper()
+
+	ports, err := validate.NewPortRange(minPort, maxPort)
+	if err != nil {
+		t.Fatalf("error creating port range: %v", err)
+	}
+
+	return ports
+}
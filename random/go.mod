@@ -1,5 +1,12 @@
 module github.com/copartner6412/input/random
 
-go 1.23.0
+go 1.24.0
 
-require github.com/copartner6412/input/validate v0.0.0-20240921092442-f0c2b04579df
+require (
+	github.com/copartner6412/input/validate v0.0.0-20240921092442-f0c2b04579df
+	golang.org/x/crypto v0.40.0
+)
+
+require golang.org/x/sys v0.34.0 // indirect
+
+replace github.com/copartner6412/input/validate => ../validate
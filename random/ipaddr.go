@@ -0,0 +1,75 @@
+package random
+
+import (
+	"fmt"
+	"io"
+	"net/netip"
+)
+
+// IPv4Addr generates a random IPv4 address within the specified prefix, or within the whole IPv4 address space if the prefix is the zero value.
+// Unlike IPv4, it returns the 4-byte form and reads the host bits from randomness in a single draw.
+func IPv4Addr(randomness io.Reader, prefix netip.Prefix) (netip.Addr, error) {
+	if !prefix.IsValid() {
+		prefix = netip.PrefixFrom(netip.IPv4Unspecified(), 0)
+	}
+
+	if !prefix.Addr().Is4() {
+		return netip.Addr{}, fmt.Errorf("prefix %s is not an IPv4 prefix", prefix)
+	}
+
+	return prefixAddr(randomness, prefix)
+}
+
+// IPv6Addr generates a random IPv6 address within the specified prefix, or within the whole IPv6 address space if the prefix is the zero value.
+// Unlike IPv6, it reads the host bits from randomness in a single draw instead of one draw per bit.
+func IPv6Addr(randomness io.Reader, prefix netip.Prefix) (netip.Addr, error) {
+	if !prefix.IsValid() {
+		prefix = netip.PrefixFrom(netip.IPv6Unspecified(), 0)
+	}
+
+	if !prefix.Addr().Is6() {
+		return netip.Addr{}, fmt.Errorf("prefix %s is not an IPv6 prefix", prefix)
+	}
+
+	return prefixAddr(randomness, prefix)
+}
+
+// prefixAddr reads the host bits of a valid prefix from randomness and returns the resulting address.
+func prefixAddr(randomness io.Reader, prefix netip.Prefix) (netip.Addr, error) {
+	hostBits := prefix.Addr().BitLen() - prefix.Bits()
+
+	var host [16]byte
+	if _, err := io.ReadFull(randomness, host[16-(hostBits+7)/8:]); err != nil {
+		return netip.Addr{}, fmt.Errorf("error generating host bits of an address in %s: %w", prefix, err)
+	}
+
+	return hostAddr(prefix, host), nil
+}
+
+// hostAddr sets the host bits of the masked prefix to the low bits of host.
+func hostAddr(prefix netip.Prefix, host [16]byte) netip.Addr {
+	network := prefix.Masked().Addr()
+	ip := network.As16()
+
+	// The network bits of an IPv4 address in its 16-byte form include the 96 bits of the IPv4-mapped prefix.
+	ones := prefix.Bits()
+	if network.Is4() {
+		ones += 96
+	}
+
+	for i := range ip {
+		switch {
+		case ones >= (i+1)*8:
+		case ones <= i*8:
+			ip[i] = host[i]
+		default:
+			ip[i] |= host[i] & (0xff >> (ones - i*8))
+		}
+	}
+
+	if network.Is4() {
+		return netip.AddrFrom4([4]byte(ip[12:]))
+	}
+
+	return netip.AddrFrom16(ip)
+}
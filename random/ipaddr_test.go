@@ -0,0 +1,78 @@
+package random_test
+
+import (
+	"crypto/rand"
+	"net/netip"
+	"testing"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzIPv4Addr(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a int) {
+		prefix, err := random.CIDRv4Prefix(rand.Reader)
+		if err != nil {
+			t.Fatalf("error generating a random IPv4 prefix: %v", err)
+		}
+
+		if prefix != prefix.Masked() {
+			t.Fatalf("random IPv4 prefix %s has host bits set", prefix)
+		}
+
+		addr, err := random.IPv4Addr(rand.Reader, prefix)
+		if err != nil {
+			t.Fatalf("error generating a random IPv4 address: %v", err)
+		}
+
+		if !addr.Is4() {
+			t.Fatalf("random IPv4 address %s is not in 4-byte form", addr)
+		}
+
+		if err := validate.IPAddr(addr, prefix); err != nil {
+			t.Fatalf("unexpected error for the random IPv4 address \"%s\" within \"%s\": %v", addr, prefix, err)
+		}
+
+		unmasked := netip.PrefixFrom(addr, prefix.Bits())
+		addr, err = random.IPv4Addr(rand.Reader, unmasked)
+		if err != nil {
+			t.Fatalf("error generating a random IPv4 address: %v", err)
+		}
+
+		if err := validate.IPAddr(addr, prefix); err != nil {
+			t.Fatalf("unexpected error for the random IPv4 address \"%s\" within unmasked \"%s\": %v", addr, unmasked, err)
+		}
+	})
+}
+
+func FuzzIPv6Addr(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a int) {
+		prefix, err := random.CIDRv6Prefix(rand.Reader)
+		if err != nil {
+			t.Fatalf("error generating a random IPv6 prefix: %v", err)
+		}
+
+		if prefix != prefix.Masked() {
+			t.Fatalf("random IPv6 prefix %s has host bits set", prefix)
+		}
+
+		addr, err := random.IPv6Addr(rand.Reader, prefix)
+		if err != nil {
+			t.Fatalf("error generating a random IPv6 address: %v", err)
+		}
+
+		if err := validate.IPAddr(addr, prefix); err != nil {
+			t.Fatalf("unexpected error for the random IPv6 address \"%s\" within \"%s\": %v", addr, prefix, err)
+		}
+	})
+}
+
+func TestIPAddrFailsForWrongFamily(t *testing.T) {
+	if _, err := random.IPv4Addr(rand.Reader, netip.MustParsePrefix("2001:db8::/32")); err == nil {
+		t.Error("expected error for an IPv6 prefix, but got nil")
+	}
+
+	if _, err := random.IPv6Addr(rand.Reader, netip.MustParsePrefix("10.0.0.0/8")); err == nil {
+		t.Error("expected error for an IPv4 prefix, but got nil")
+	}
+}
@@ -0,0 +1,92 @@
+package random
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/netip"
+
+	"github.com/copartner6412/input/validate"
+)
+
+// IPClass defines the classes of IP addresses derived from the IANA special-purpose address registries.
+type IPClass = validate.IPClass
+
+// List of IP address classes.
+const (
+	IPClassGlobal        = validate.IPClassGlobal
+	IPClassPrivate       = validate.IPClassPrivate
+	IPClassLoopback      = validate.IPClassLoopback
+	IPClassLinkLocal     = validate.IPClassLinkLocal
+	IPClassDocumentation = validate.IPClassDocumentation
+	IPClassCGNAT         = validate.IPClassCGNAT
+	IPClassMulticast     = validate.IPClassMulticast
+	IPClassReserved      = validate.IPClassReserved
+)
+
+// IPv4AddrOfClass generates a random IPv4 address of one of the specified classes, as classified by validate.ClassifyIP.
+// For example, IPClassGlobal generates public unicast addresses only, and IPClassDocumentation generates addresses of RFC 5737 only.
+//
+// Global addresses are drawn from the whole address space, and addresses of other classes from the registry blocks of the class,
+// each block being equally likely. Addresses in a more specific block of another class are drawn again.
+func IPv4AddrOfClass(randomness io.Reader, classes ...IPClass) (netip.Addr, error) {
+	return addrOfClass(randomness, false, classes)
+}
+
+// IPv6AddrOfClass generates a random IPv6 address of one of the specified classes, as classified by validate.ClassifyIP.
+// For example, IPClassGlobal generates global unicast addresses in 2000::/3 only, and IPClassDocumentation generates addresses of RFC 3849 only.
+//
+// Global addresses are drawn from 2000::/3, and addresses of other classes from the registry blocks of the class,
+// each block being equally likely. Addresses in a more specific block of another class are drawn again.
+func IPv6AddrOfClass(randomness io.Reader, classes ...IPClass) (netip.Addr, error) {
+	return addrOfClass(randomness, true, classes)
+}
+
+func addrOfClass(randomness io.Reader, ipv6 bool, classes []IPClass) (netip.Addr, error) {
+	prefixes := classPrefixes(ipv6, classes)
+	if len(prefixes) == 0 {
+		return netip.Addr{}, errors.New("no IP address block for the specified classes")
+	}
+
+	for {
+		random1, err := rand.Int(randomness, big.NewInt(int64(len(prefixes))))
+		if err != nil {
+			return netip.Addr{}, fmt.Errorf("error generating a random number for choosing an address block: %w", err)
+		}
+
+		addr, err := prefixAddr(randomness, prefixes[random1.Int64()])
+		if err != nil {
+			return netip.Addr{}, err
+		}
+
+		if validate.IPAddrClass(addr, classes...) == nil {
+			return addr, nil
+		}
+	}
+}
+
+// classPrefixes returns the blocks which addresses of the specified classes are drawn from.
+func classPrefixes(ipv6 bool, classes []IPClass) []netip.Prefix {
+	registry, global := validate.IPv4SpecialPurposeRegistry, netip.MustParsePrefix("0.0.0.0/0")
+	if ipv6 {
+		registry, global = validate.IPv6SpecialPurposeRegistry, netip.MustParsePrefix("2000::/3")
+	}
+
+	var prefixes []netip.Prefix
+
+	for _, class := range classes {
+		if class == IPClassGlobal {
+			prefixes = append(prefixes, global)
+			continue
+		}
+		for _, entry := range registry {
+			if entry.Class == class {
+				prefixes = append(prefixes, entry.Prefix)
+			}
+		}
+	}
+
+	return prefixes
+}
@@ -0,0 +1,59 @@
+package random_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+var testIPClasses = []random.IPClass{
+	random.IPClassGlobal,
+	random.IPClassPrivate,
+	random.IPClassLoopback,
+	random.IPClassLinkLocal,
+	random.IPClassDocumentation,
+	random.IPClassMulticast,
+	random.IPClassReserved,
+}
+
+func FuzzIPv4AddrOfClass(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a int) {
+		for _, class := range append(testIPClasses, random.IPClassCGNAT) {
+			addr, err := random.IPv4AddrOfClass(rand.Reader, class)
+			if err != nil {
+				t.Fatalf("error generating a random %s IPv4 address: %v", class, err)
+			}
+
+			if !addr.Is4() {
+				t.Fatalf("random %s address %s is not an IPv4 address", class, addr)
+			}
+
+			if err := validate.IPAddrClass(addr, class); err != nil {
+				t.Fatalf("unexpected error for the random %s IPv4 address \"%s\": %v", class, addr, err)
+			}
+		}
+	})
+}
+
+func FuzzIPv6AddrOfClass(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a int) {
+		for _, class := range testIPClasses {
+			addr, err := random.IPv6AddrOfClass(rand.Reader, class)
+			if err != nil {
+				t.Fatalf("error generating a random %s IPv6 address: %v", class, err)
+			}
+
+			if err := validate.IPAddrClass(addr, class); err != nil {
+				t.Fatalf("unexpected error for the random %s IPv6 address \"%s\": %v", class, addr, err)
+			}
+		}
+	})
+}
+
+func TestIPv6AddrOfClassFailsForCGNAT(t *testing.T) {
+	if _, err := random.IPv6AddrOfClass(rand.Reader, random.IPClassCGNAT); err == nil {
+		t.Error("expected error for CGNAT IPv6 addresses, but got nil")
+	}
+}
@@ -0,0 +1,530 @@
+package random
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/mlkem"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/copartner6412/input/validate"
+)
+
+// jwk holds the members of a JSON Web Key (RFC 7517) for the key types of RFC 7518 ("EC" and "RSA"), RFC 8037 ("OKP")
+// and the "AKP" key type of the JOSE post-quantum drafts, which holds ML-KEM keys.
+type jwk struct {
+	Kty  string `json:"kty"`
+	Kid  string `json:"kid,omitempty"`
+	Use  string `json:"use,omitempty"`
+	Alg  string `json:"alg,omitempty"`
+	Crv  string `json:"crv,omitempty"`
+	X    string `json:"x,omitempty"`
+	Y    string `json:"y,omitempty"`
+	N    string `json:"n,omitempty"`
+	E    string `json:"e,omitempty"`
+	D    string `json:"d,omitempty"`
+	P    string `json:"p,omitempty"`
+	Q    string `json:"q,omitempty"`
+	DP   string `json:"dp,omitempty"`
+	DQ   string `json:"dq,omitempty"`
+	QI   string `json:"qi,omitempty"`
+	Pub  string `json:"pub,omitempty"`
+	Priv string `json:"priv,omitempty"`
+}
+
+// jwks holds a JSON Web Key Set.
+type jwks struct {
+	Keys []json.RawMessage `json:"keys"`
+}
+
+// jwkEncryptionAlg holds the JWE key management algorithm of each key agreement algorithm.
+var jwkEncryptionAlg = map[Algorithm]string{
+	AlgorithmX25519:    "ECDH-ES",
+	AlgorithmECDHP256:  "ECDH-ES",
+	AlgorithmECDHP384:  "ECDH-ES",
+	AlgorithmMLKEM768:  "ML-KEM-768",
+	AlgorithmMLKEM1024: "ML-KEM-1024",
+}
+
+var jwkCurve = map[string]elliptic.Curve{
+	"P-256": elliptic.P256(),
+	"P-384": elliptic.P384(),
+	"P-521": elliptic.P521(),
+}
+
+var jwkECDHCurve = map[string]ecdh.Curve{
+	"P-256": ecdh.P256(),
+	"P-384": ecdh.P384(),
+}
+
+var base64URL = base64.RawURLEncoding
+
+// EncodeJWK encodes a public or private key generated by KeyPair as a JSON Web Key.
+// The key ID ("kid") is the RFC 7638 thumbprint of the public key, and "alg" and "use" are set from the algorithm of the key:
+// JWS algorithms for signature keys (see Algorithm.JOSE), "ECDH-ES" for X25519 and ECDH keys, and "ML-KEM-768" or "ML-KEM-1024" for ML-KEM keys.
+//
+// A private JWK also holds the members of the public key. JOSE has no support for the P-224 curve, so keys generated with AlgorithmECDSAP224 can not be encoded.
+func EncodeJWK(key any) ([]byte, error) {
+	k, err := newJWK(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(k)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling JWK: %w", err)
+	}
+
+	return data, nil
+}
+
+// EncodeJWKS encodes public or private keys generated by KeyPair as a JSON Web Key Set with EncodeJWK.
+func EncodeJWKS(keys ...any) ([]byte, error) {
+	set := jwks{Keys: make([]json.RawMessage, 0, len(keys))}
+
+	for i, key := range keys {
+		data, err := EncodeJWK(key)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding key %d: %w", i, err)
+		}
+		set.Keys = append(set.Keys, data)
+	}
+
+	data, err := json.Marshal(set)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling JWKS: %w", err)
+	}
+
+	return data, nil
+}
+
+// DecodeJWK decodes a JSON Web Key encoded by EncodeJWK. The returned keys have the same types KeyPair returns for their algorithm.
+// If the JWK holds no private key, the returned private key is nil.
+//
+// "EC" keys are decoded as ECDH keys if their "alg" is "ECDH-ES" or their "use" is "enc", and as ECDSA keys otherwise.
+func DecodeJWK(data []byte) (crypto.PublicKey, crypto.PrivateKey, error) {
+	var k jwk
+	if err := json.Unmarshal(data, &k); err != nil {
+		return nil, nil, fmt.Errorf("error unmarshaling JWK: %w", err)
+	}
+
+	switch k.Kty {
+	case "OKP":
+		return decodeOKPJWK(k)
+	case "EC":
+		if k.Alg == "ECDH-ES" || k.Use == "enc" {
+			return decodeECDHJWK(k)
+		}
+		return decodeECDSAJWK(k)
+	case "RSA":
+		return decodeRSAJWK(k)
+	case "AKP":
+		return decodeAKPJWK(k)
+	default:
+		return nil, nil, fmt.Errorf("unsupported JWK key type \"%s\"", k.Kty)
+	}
+}
+
+// DecodeJWKS decodes a JSON Web Key Set with DecodeJWK. The private key of each public-only JWK is nil.
+func DecodeJWKS(data []byte) ([]crypto.PublicKey, []crypto.PrivateKey, error) {
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, nil, fmt.Errorf("error unmarshaling JWKS: %w", err)
+	}
+
+	publicKeys := make([]crypto.PublicKey, 0, len(set.Keys))
+	privateKeys := make([]crypto.PrivateKey, 0, len(set.Keys))
+
+	for i, data := range set.Keys {
+		publicKey, privateKey, err := DecodeJWK(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error decoding key %d: %w", i, err)
+		}
+		publicKeys = append(publicKeys, publicKey)
+		privateKeys = append(privateKeys, privateKey)
+	}
+
+	return publicKeys, privateKeys, nil
+}
+
+// JWKThumbprint returns the base64url-encoded RFC 7638 SHA-256 thumbprint of a public or private key generated by KeyPair.
+func JWKThumbprint(key any) (string, error) {
+	k, err := newJWK(key)
+	if err != nil {
+		return "", err
+	}
+
+	return k.Kid, nil
+}
+
+// newJWK converts a public or private key to a JWK with its thumbprint as key ID.
+func newJWK(key any) (jwk, error) {
+	publicKey, privateKey := key, crypto.PrivateKey(nil)
+
+	switch k := key.(type) {
+	case *mlkem.DecapsulationKey768:
+		publicKey, privateKey = k.EncapsulationKey(), k
+	case *mlkem.DecapsulationKey1024:
+		publicKey, privateKey = k.EncapsulationKey(), k
+	case interface{ Public() crypto.PublicKey }:
+		publicKey, privateKey = k.Public(), k
+	}
+
+	algorithm, err := validate.DetectAlgorithm(publicKey)
+	if err != nil {
+		return jwk{}, err
+	}
+
+	var k jwk
+
+	if alg, err := algorithm.JOSE(); err == nil {
+		k.Alg, k.Use = alg, "sig"
+	} else if alg, ok := jwkEncryptionAlg[algorithm]; ok {
+		k.Alg, k.Use = alg, "enc"
+	} else {
+		return jwk{}, err
+	}
+
+	switch publicKey := publicKey.(type) {
+	case ed25519.PublicKey:
+		k.Kty, k.Crv, k.X = "OKP", "Ed25519", base64URL.EncodeToString(publicKey)
+		if privateKey, ok := privateKey.(ed25519.PrivateKey); ok {
+			k.D = base64URL.EncodeToString(privateKey.Seed())
+		}
+	case *ecdsa.PublicKey:
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+		k.Kty, k.Crv = "EC", publicKey.Curve.Params().Name
+		k.X = base64URL.EncodeToString(publicKey.X.FillBytes(make([]byte, size)))
+		k.Y = base64URL.EncodeToString(publicKey.Y.FillBytes(make([]byte, size)))
+		if privateKey, ok := privateKey.(*ecdsa.PrivateKey); ok {
+			k.D = base64URL.EncodeToString(privateKey.D.FillBytes(make([]byte, size)))
+		}
+	case *rsa.PublicKey:
+		k.Kty = "RSA"
+		k.N = base64URL.EncodeToString(publicKey.N.Bytes())
+		k.E = base64URL.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		if privateKey, ok := privateKey.(*rsa.PrivateKey); ok {
+			if len(privateKey.Primes) != 2 {
+				return jwk{}, errors.New("multi-prime RSA keys not supported")
+			}
+			dp, dq, qinv := rsaCRTValues(privateKey)
+			k.D = base64URL.EncodeToString(privateKey.D.Bytes())
+			k.P = base64URL.EncodeToString(privateKey.Primes[0].Bytes())
+			k.Q = base64URL.EncodeToString(privateKey.Primes[1].Bytes())
+			k.DP = base64URL.EncodeToString(dp.Bytes())
+			k.DQ = base64URL.EncodeToString(dq.Bytes())
+			k.QI = base64URL.EncodeToString(qinv.Bytes())
+		}
+	case *ecdh.PublicKey:
+		if publicKey.Curve() == ecdh.X25519() {
+			k.Kty, k.Crv, k.X = "OKP", "X25519", base64URL.EncodeToString(publicKey.Bytes())
+		} else {
+			// The uncompressed point encoding is 0x04 followed by the coordinates.
+			point := publicKey.Bytes()[1:]
+			k.Kty, k.Crv = "EC", jwkCurveName(publicKey.Curve())
+			k.X = base64URL.EncodeToString(point[:len(point)/2])
+			k.Y = base64URL.EncodeToString(point[len(point)/2:])
+		}
+		if privateKey, ok := privateKey.(*ecdh.PrivateKey); ok {
+			k.D = base64URL.EncodeToString(privateKey.Bytes())
+		}
+	case *mlkem.EncapsulationKey768:
+		k.Kty, k.Pub = "AKP", base64URL.EncodeToString(publicKey.Bytes())
+		if privateKey, ok := privateKey.(*mlkem.DecapsulationKey768); ok {
+			k.Priv = base64URL.EncodeToString(privateKey.Bytes())
+		}
+	case *mlkem.EncapsulationKey1024:
+		k.Kty, k.Pub = "AKP", base64URL.EncodeToString(publicKey.Bytes())
+		if privateKey, ok := privateKey.(*mlkem.DecapsulationKey1024); ok {
+			k.Priv = base64URL.EncodeToString(privateKey.Bytes())
+		}
+	}
+
+	k.Kid = jwkThumbprint(k)
+
+	return k, nil
+}
+
+// jwkThumbprint computes the RFC 7638 thumbprint of a JWK from its required public members in lexicographic order.
+func jwkThumbprint(k jwk) string {
+	var members string
+
+	switch k.Kty {
+	case "EC":
+		members = fmt.Sprintf(`{"crv":"%s","kty":"EC","x":"%s","y":"%s"}`, k.Crv, k.X, k.Y)
+	case "RSA":
+		members = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, k.E, k.N)
+	case "OKP":
+		members = fmt.Sprintf(`{"crv":"%s","kty":"OKP","x":"%s"}`, k.Crv, k.X)
+	case "AKP":
+		members = fmt.Sprintf(`{"alg":"%s","kty":"AKP","pub":"%s"}`, k.Alg, k.Pub)
+	}
+
+	sum := sha256.Sum256([]byte(members))
+
+	return base64URL.EncodeToString(sum[:])
+}
+
+// rsaCRTValues returns the CRT exponents and coefficient of a two-prime RSA private key. They are taken from the precomputed values
+// if the key has them, and computed otherwise, so the caller's key isn't modified as by rsa.PrivateKey.Precompute.
+func rsaCRTValues(privateKey *rsa.PrivateKey) (dp, dq, qinv *big.Int) {
+	if privateKey.Precomputed.Dp != nil && privateKey.Precomputed.Dq != nil && privateKey.Precomputed.Qinv != nil {
+		return privateKey.Precomputed.Dp, privateKey.Precomputed.Dq, privateKey.Precomputed.Qinv
+	}
+
+	p, q := privateKey.Primes[0], privateKey.Primes[1]
+	one := big.NewInt(1)
+
+	dp = new(big.Int).Mod(privateKey.D, new(big.Int).Sub(p, one))
+	dq = new(big.Int).Mod(privateKey.D, new(big.Int).Sub(q, one))
+	qinv = new(big.Int).ModInverse(q, p)
+
+	return dp, dq, qinv
+}
+
+func jwkCurveName(curve ecdh.Curve) string {
+	for name, c := range jwkECDHCurve {
+		if c == curve {
+			return name
+		}
+	}
+	return ""
+}
+
+func decodeOKPJWK(k jwk) (crypto.PublicKey, crypto.PrivateKey, error) {
+	x, err := base64URL.DecodeString(k.X)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid JWK member \"x\": %w", err)
+	}
+
+	d, err := base64URL.DecodeString(k.D)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid JWK member \"d\": %w", err)
+	}
+
+	switch k.Crv {
+	case "Ed25519":
+		if len(x) != ed25519.PublicKeySize {
+			return nil, nil, fmt.Errorf("invalid Ed25519 public key length %d, expected %d", len(x), ed25519.PublicKeySize)
+		}
+		publicKey := ed25519.PublicKey(x)
+		if k.D == "" {
+			return publicKey, nil, nil
+		}
+		if len(d) != ed25519.SeedSize {
+			return nil, nil, fmt.Errorf("invalid Ed25519 private key length %d, expected %d", len(d), ed25519.SeedSize)
+		}
+		privateKey := ed25519.NewKeyFromSeed(d)
+		if !publicKey.Equal(privateKey.Public()) {
+			return nil, nil, errors.New("private key doesn't match public key")
+		}
+		return publicKey, privateKey, nil
+	case "X25519":
+		return decodeECDHKeys(ecdh.X25519(), x, d, k.D != "")
+	default:
+		return nil, nil, fmt.Errorf("unsupported OKP curve \"%s\"", k.Crv)
+	}
+}
+
+func decodeECDHJWK(k jwk) (crypto.PublicKey, crypto.PrivateKey, error) {
+	curve, ok := jwkECDHCurve[k.Crv]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported ECDH curve \"%s\"", k.Crv)
+	}
+
+	point, err := decodeECPoint(k)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := base64URL.DecodeString(k.D)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid JWK member \"d\": %w", err)
+	}
+
+	return decodeECDHKeys(curve, point, d, k.D != "")
+}
+
+func decodeECDHKeys(curve ecdh.Curve, publicKeyBytes, privateKeyBytes []byte, private bool) (crypto.PublicKey, crypto.PrivateKey, error) {
+	publicKey, err := curve.NewPublicKey(publicKeyBytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid %s public key: %w", curve, err)
+	}
+
+	if !private {
+		return publicKey, nil, nil
+	}
+
+	privateKey, err := curve.NewPrivateKey(privateKeyBytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid %s private key: %w", curve, err)
+	}
+
+	if !publicKey.Equal(privateKey.PublicKey()) {
+		return nil, nil, errors.New("private key doesn't match public key")
+	}
+
+	return publicKey, privateKey, nil
+}
+
+func decodeECDSAJWK(k jwk) (crypto.PublicKey, crypto.PrivateKey, error) {
+	curve, ok := jwkCurve[k.Crv]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported EC curve \"%s\"", k.Crv)
+	}
+
+	point, err := decodeECPoint(k)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	size := (curve.Params().BitSize + 7) / 8
+	if len(point) != 1+2*size {
+		return nil, nil, fmt.Errorf("invalid %s coordinate length, expected %d bytes", k.Crv, size)
+	}
+
+	x, y := new(big.Int).SetBytes(point[1:1+size]), new(big.Int).SetBytes(point[1+size:])
+	if !curve.IsOnCurve(x, y) {
+		return nil, nil, fmt.Errorf("point not on curve %s", k.Crv)
+	}
+
+	publicKey := &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+
+	if k.D == "" {
+		return publicKey, nil, nil
+	}
+
+	d, err := base64URL.DecodeString(k.D)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid JWK member \"d\": %w", err)
+	}
+
+	if len(d) != size {
+		return nil, nil, fmt.Errorf("invalid %s private key length %d, expected %d", k.Crv, len(d), size)
+	}
+
+	privateKey := &ecdsa.PrivateKey{PublicKey: *publicKey, D: new(big.Int).SetBytes(d)}
+
+	px, py := curve.ScalarBaseMult(d)
+	if px.Cmp(x) != 0 || py.Cmp(y) != 0 {
+		return nil, nil, errors.New("private key doesn't match public key")
+	}
+
+	return publicKey, privateKey, nil
+}
+
+// decodeECPoint returns the uncompressed encoding of the point of an "EC" JWK.
+func decodeECPoint(k jwk) ([]byte, error) {
+	x, err := base64URL.DecodeString(k.X)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWK member \"x\": %w", err)
+	}
+
+	y, err := base64URL.DecodeString(k.Y)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWK member \"y\": %w", err)
+	}
+
+	if len(x) != len(y) {
+		return nil, errors.New("coordinates of different lengths")
+	}
+
+	return append(append([]byte{4}, x...), y...), nil
+}
+
+func decodeRSAJWK(k jwk) (crypto.PublicKey, crypto.PrivateKey, error) {
+	members := map[string]string{"n": k.N, "e": k.E}
+	if k.D != "" {
+		members["d"], members["p"], members["q"] = k.D, k.P, k.Q
+	}
+
+	values := make(map[string]*big.Int, len(members))
+
+	for name, member := range members {
+		if member == "" {
+			return nil, nil, fmt.Errorf("missing JWK member \"%s\"", name)
+		}
+		b, err := base64URL.DecodeString(member)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid JWK member \"%s\": %w", name, err)
+		}
+		values[name] = new(big.Int).SetBytes(b)
+	}
+
+	if !values["e"].IsInt64() || values["e"].Int64() > 1<<31-1 {
+		return nil, nil, errors.New("RSA public exponent too large")
+	}
+
+	publicKey := &rsa.PublicKey{N: values["n"], E: int(values["e"].Int64())}
+
+	if k.D == "" {
+		return publicKey, nil, nil
+	}
+
+	privateKey := &rsa.PrivateKey{PublicKey: *publicKey, D: values["d"], Primes: []*big.Int{values["p"], values["q"]}}
+
+	if err := privateKey.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid RSA private key: %w", err)
+	}
+
+	privateKey.Precompute()
+
+	return publicKey, privateKey, nil
+}
+
+func decodeAKPJWK(k jwk) (crypto.PublicKey, crypto.PrivateKey, error) {
+	pub, err := base64URL.DecodeString(k.Pub)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid JWK member \"pub\": %w", err)
+	}
+
+	priv, err := base64URL.DecodeString(k.Priv)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid JWK member \"priv\": %w", err)
+	}
+
+	switch k.Alg {
+	case "ML-KEM-768":
+		publicKey, err := mlkem.NewEncapsulationKey768(pub)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid ML-KEM-768 public key: %w", err)
+		}
+		if k.Priv == "" {
+			return publicKey, nil, nil
+		}
+		privateKey, err := mlkem.NewDecapsulationKey768(priv)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid ML-KEM-768 private key: %w", err)
+		}
+		if string(privateKey.EncapsulationKey().Bytes()) != string(pub) {
+			return nil, nil, errors.New("private key doesn't match public key")
+		}
+		return publicKey, privateKey, nil
+	case "ML-KEM-1024":
+		publicKey, err := mlkem.NewEncapsulationKey1024(pub)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid ML-KEM-1024 public key: %w", err)
+		}
+		if k.Priv == "" {
+			return publicKey, nil, nil
+		}
+		privateKey, err := mlkem.NewDecapsulationKey1024(priv)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid ML-KEM-1024 private key: %w", err)
+		}
+		if string(privateKey.EncapsulationKey().Bytes()) != string(pub) {
+			return nil, nil, errors.New("private key doesn't match public key")
+		}
+		return publicKey, privateKey, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported AKP algorithm \"%s\"", k.Alg)
+	}
+}
@@ -0,0 +1,155 @@
+package random_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzJWK(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a uint) {
+		algorithm := random.Algorithm(int(a % 14))
+		if algorithm == random.AlgorithmECDSAP224 {
+			t.Skip("JOSE doesn't support ECDSA P224")
+		}
+
+		publicKey, privateKey, err := random.KeyPair(rand.Reader, algorithm)
+		if err != nil {
+			t.Fatalf("error generating a random key pair of type %s: %v", algorithm, err)
+		}
+
+		publicJWK, err := random.EncodeJWK(publicKey)
+		if err != nil {
+			t.Fatalf("error encoding %s public key to JWK: %v", algorithm, err)
+		}
+
+		if err := validate.JWK(publicJWK, false); err != nil {
+			t.Fatalf("invalid public JWK %s: %v", publicJWK, err)
+		}
+
+		privateJWK, err := random.EncodeJWK(privateKey)
+		if err != nil {
+			t.Fatalf("error encoding %s private key to JWK: %v", algorithm, err)
+		}
+
+		if err := validate.JWK(privateJWK, true); err != nil {
+			t.Fatalf("invalid private JWK: %v", err)
+		}
+
+		decodedPublicKey, decodedPrivateKey, err := random.DecodeJWK(privateJWK)
+		if err != nil {
+			t.Fatalf("error decoding %s private JWK: %v", algorithm, err)
+		}
+
+		if err := validate.KeyPair(algorithm, decodedPublicKey, decodedPrivateKey); err != nil {
+			t.Fatalf("invalid decoded key pair: %v", err)
+		}
+
+		_, noPrivateKey, err := random.DecodeJWK(publicJWK)
+		if err != nil {
+			t.Fatalf("error decoding %s public JWK: %v", algorithm, err)
+		}
+
+		if noPrivateKey != nil {
+			t.Fatalf("expected nil private key for public JWK, but got %T", noPrivateKey)
+		}
+
+		publicThumbprint, err := random.JWKThumbprint(decodedPublicKey)
+		if err != nil {
+			t.Fatalf("error computing thumbprint of %s public key: %v", algorithm, err)
+		}
+
+		privateThumbprint, err := random.JWKThumbprint(privateKey)
+		if err != nil {
+			t.Fatalf("error computing thumbprint of %s private key: %v", algorithm, err)
+		}
+
+		if publicThumbprint != privateThumbprint {
+			t.Fatalf("thumbprints of public key \"%s\" and private key \"%s\" differ", publicThumbprint, privateThumbprint)
+		}
+
+		jwks, err := random.EncodeJWKS(publicKey, privateKey)
+		if err != nil {
+			t.Fatalf("error encoding %s JWKS: %v", algorithm, err)
+		}
+
+		publicKeys, privateKeys, err := random.DecodeJWKS(jwks)
+		if err != nil {
+			t.Fatalf("error decoding %s JWKS: %v", algorithm, err)
+		}
+
+		if len(publicKeys) != 2 || privateKeys[0] != nil || privateKeys[1] == nil {
+			t.Fatalf("unexpected keys decoded from JWKS with one public and one private key")
+		}
+	})
+}
+
+func TestJWKThumbprint(t *testing.T) {
+	t.Parallel()
+
+	// Example of RFC 7638, section 3.1.
+	const jwk = `{"kty":"RSA","n":"0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw","e":"AQAB","alg":"RS256","kid":"2011-04-29"}`
+	const expected = "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"
+
+	publicKey, _, err := random.DecodeJWK([]byte(jwk))
+	if err != nil {
+		t.Fatalf("error decoding JWK: %v", err)
+	}
+
+	thumbprint, err := random.JWKThumbprint(publicKey)
+	if err != nil {
+		t.Fatalf("error computing thumbprint: %v", err)
+	}
+
+	if thumbprint != expected {
+		t.Fatalf("expected thumbprint \"%s\", but got \"%s\"", expected, thumbprint)
+	}
+}
+
+func TestEncodeJWKDoesNotModifyKey(t *testing.T) {
+	t.Parallel()
+
+	generated, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error generating RSA key: %v", err)
+	}
+
+	privateKey := &rsa.PrivateKey{PublicKey: generated.PublicKey, D: generated.D, Primes: generated.Primes}
+
+	encoded, err := random.EncodeJWK(privateKey)
+	if err != nil {
+		t.Fatalf("error encoding JWK: %v", err)
+	}
+
+	if privateKey.Precomputed.Dp != nil || privateKey.Precomputed.Dq != nil || privateKey.Precomputed.Qinv != nil {
+		t.Fatal("encoding modified the precomputed values of the private key")
+	}
+
+	var members struct{ DP, DQ, QI string }
+	if err := json.Unmarshal(encoded, &members); err != nil {
+		t.Fatalf("error unmarshaling JWK: %v", err)
+	}
+
+	for name, pair := range map[string]struct {
+		member   string
+		expected *big.Int
+	}{
+		"dp": {members.DP, generated.Precomputed.Dp},
+		"dq": {members.DQ, generated.Precomputed.Dq},
+		"qi": {members.QI, generated.Precomputed.Qinv},
+	} {
+		value, err := base64.RawURLEncoding.DecodeString(pair.member)
+		if err != nil {
+			t.Fatalf("invalid JWK member \"%s\": %v", name, err)
+		}
+		if new(big.Int).SetBytes(value).Cmp(pair.expected) != 0 {
+			t.Errorf("JWK member \"%s\" doesn't match the precomputed value", name)
+		}
+	}
+}
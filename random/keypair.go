@@ -2,47 +2,40 @@ package random
 
 import (
 	"crypto"
+	"crypto/ecdh"
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/elliptic"
+	"crypto/mlkem"
 	"crypto/rsa"
 	"errors"
 	"fmt"
 	"io"
+
+	"github.com/copartner6412/input/validate"
 )
 
 // Algorithm defines the supported key generation algorithms.
-type Algorithm int
+type Algorithm = validate.Algorithm
 
 // List of supported algorithms for key generation.
 const (
-	AlgorithmUntyped Algorithm = iota
-	AlgorithmED25519
-	AlgorithmECDSAP521
-	AlgorithmECDSAP384
-	AlgorithmECDSAP256
-	AlgorithmECDSAP224
-	AlgorithmRSA4096
-	AlgorithmRSA2048
-	AlgorithmRSA1024
+	AlgorithmUntyped   = validate.AlgorithmUntyped
+	AlgorithmED25519   = validate.AlgorithmED25519
+	AlgorithmECDSAP521 = validate.AlgorithmECDSAP521
+	AlgorithmECDSAP384 = validate.AlgorithmECDSAP384
+	AlgorithmECDSAP256 = validate.AlgorithmECDSAP256
+	AlgorithmECDSAP224 = validate.AlgorithmECDSAP224
+	AlgorithmRSA4096   = validate.AlgorithmRSA4096
+	AlgorithmRSA2048   = validate.AlgorithmRSA2048
+	AlgorithmRSA1024   = validate.AlgorithmRSA1024
+	AlgorithmX25519    = validate.AlgorithmX25519
+	AlgorithmECDHP256  = validate.AlgorithmECDHP256
+	AlgorithmECDHP384  = validate.AlgorithmECDHP384
+	AlgorithmMLKEM768  = validate.AlgorithmMLKEM768
+	AlgorithmMLKEM1024 = validate.AlgorithmMLKEM1024
 )
 
-var algorithmString = map[Algorithm]string{
-	AlgorithmUntyped:   "untyped",
-	AlgorithmED25519:   "ED25519",
-	AlgorithmECDSAP521: "ECDSA P521",
-	AlgorithmECDSAP384: "ECDSA P384",
-	AlgorithmECDSAP256: "ECDSA P256",
-	AlgorithmECDSAP224: "ECDSA P224",
-	AlgorithmRSA4096:   "RSA 4096",
-	AlgorithmRSA2048:   "RSA 2048",
-	AlgorithmRSA1024:   "RSA 1024",
-}
-
-func (a Algorithm) String() string {
-	return algorithmString[a]
-}
-
 const (
 	rsa1024 int = 1024
 	rsa2048 int = 2048
@@ -51,6 +44,9 @@ const (
 
 // KeyPair creates a public-private key pair based on the specified algorithm.
 // If you don't know what algorithm to use, insert zero to use the default (ED25519) key generation algorithm.
+//
+// Key agreement algorithms return *ecdh.PublicKey and *ecdh.PrivateKey for X25519 and ECDH,
+// and the encapsulation and decapsulation keys of the crypto/mlkem package for ML-KEM.
 func KeyPair(randomness io.Reader, algorithm Algorithm) (crypto.PublicKey, crypto.PrivateKey, error) {
 	switch algorithm {
 	case AlgorithmUntyped, AlgorithmED25519:
@@ -69,6 +65,16 @@ func KeyPair(randomness io.Reader, algorithm Algorithm) (crypto.PublicKey, crypt
 		return generateRSAKeyPair(randomness, rsa2048)
 	case AlgorithmRSA1024:
 		return generateRSAKeyPair(randomness, rsa1024)
+	case AlgorithmX25519:
+		return generateECDHKeyPair(randomness, ecdh.X25519())
+	case AlgorithmECDHP256:
+		return generateECDHKeyPair(randomness, ecdh.P256())
+	case AlgorithmECDHP384:
+		return generateECDHKeyPair(randomness, ecdh.P384())
+	case AlgorithmMLKEM768:
+		return generateMLKEM768KeyPair(randomness)
+	case AlgorithmMLKEM1024:
+		return generateMLKEM1024KeyPair(randomness)
 	default:
 		return nil, nil, errors.New("unsupported key generation algorithm")
 	}
@@ -101,3 +107,40 @@ func generateED25519KeyPair(randomness io.Reader) (ed25519.PublicKey, ed25519.Pr
 	}
 	return publicKey, privateKey, nil
 }
+
+// generateECDHKeyPair creates an ECDH public-private key pair on the specified curve.
+func generateECDHKeyPair(randomness io.Reader, curve ecdh.Curve) (*ecdh.PublicKey, *ecdh.PrivateKey, error) {
+	privateKey, err := curve.GenerateKey(randomness)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating ECDH key pair: %w", err)
+	}
+	return privateKey.PublicKey(), privateKey, nil
+}
+
+// generateMLKEM768KeyPair creates an ML-KEM-768 encapsulation-decapsulation key pair from a 64-byte seed read from randomness.
+func generateMLKEM768KeyPair(randomness io.Reader) (*mlkem.EncapsulationKey768, *mlkem.DecapsulationKey768, error) {
+	seed := make([]byte, mlkem.SeedSize)
+	if _, err := io.ReadFull(randomness, seed); err != nil {
+		return nil, nil, fmt.Errorf("error reading ML-KEM seed: %w", err)
+	}
+
+	decapsulationKey, err := mlkem.NewDecapsulationKey768(seed)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating ML-KEM-768 key pair: %w", err)
+	}
+	return decapsulationKey.EncapsulationKey(), decapsulationKey, nil
+}
+
+// generateMLKEM1024KeyPair creates an ML-KEM-1024 encapsulation-decapsulation key pair from a 64-byte seed read from randomness.
+func generateMLKEM1024KeyPair(randomness io.Reader) (*mlkem.EncapsulationKey1024, *mlkem.DecapsulationKey1024, error) {
+	seed := make([]byte, mlkem.SeedSize)
+	if _, err := io.ReadFull(randomness, seed); err != nil {
+		return nil, nil, fmt.Errorf("error reading ML-KEM seed: %w", err)
+	}
+
+	decapsulationKey, err := mlkem.NewDecapsulationKey1024(seed)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating ML-KEM-1024 key pair: %w", err)
+	}
+	return decapsulationKey.EncapsulationKey(), decapsulationKey, nil
+}
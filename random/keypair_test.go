@@ -10,14 +10,14 @@ import (
 
 func FuzzKeyPair(f *testing.F) {
 	f.Fuzz(func(t *testing.T, a uint) {
-		algorithm := random.Algorithm(int(a % 9))
+		algorithm := random.Algorithm(int(a % 14))
 
 		publicKey1, privateKey1, err := random.KeyPair(rand.Reader, algorithm)
 		if err != nil {
 			t.Fatalf("error generating a pseudo-random key pair of type %s: %v", algorithm.String(), err)
 		}
 
-		err = validate.KeyPair(validate.Algorithm(algorithm), publicKey1, privateKey1)
+		err = validate.KeyPair(algorithm, publicKey1, privateKey1)
 		if err != nil {
 			t.Fatalf("invalid key pair: %v", err)
 		}
@@ -0,0 +1,52 @@
+package random
+
+import (
+	"fmt"
+	"io"
+	"net"
+)
+
+const (
+	macMulticastBit byte = 0x01
+	macLocalBit     byte = 0x02
+
+	eui48Length int = 6
+	eui64Length int = 8
+)
+
+// MAC generates a random EUI-48 MAC address, or an EUI-64 address if eui64 is true.
+//
+// The U/L and I/G bits of the first octet are set for locally administered and multicast addresses, respectively.
+// If oui is not empty, the address starts with it, e.g. a 3-octet OUI such as 00:00:5E or 52:54:00 for QEMU/KVM virtual machines.
+// The flags of the OUI must then agree with local and multicast.
+func MAC(randomness io.Reader, local, multicast, eui64 bool, oui []byte) (net.HardwareAddr, error) {
+	length := eui48Length
+	if eui64 {
+		length = eui64Length
+	}
+
+	if len(oui) >= length {
+		return nil, fmt.Errorf("OUI of %d octets, expected less than %d", len(oui), length)
+	}
+
+	mac := make(net.HardwareAddr, length)
+	copy(mac, oui)
+
+	if _, err := io.ReadFull(randomness, mac[len(oui):]); err != nil {
+		return nil, fmt.Errorf("error generating MAC address: %w", err)
+	}
+
+	if len(oui) == 0 {
+		mac[0] &^= macLocalBit | macMulticastBit
+		if local {
+			mac[0] |= macLocalBit
+		}
+		if multicast {
+			mac[0] |= macMulticastBit
+		}
+	} else if (oui[0]&macLocalBit != 0) != local || (oui[0]&macMulticastBit != 0) != multicast {
+		return nil, fmt.Errorf("OUI %s does not have the requested U/L and I/G bits", net.HardwareAddr(oui))
+	}
+
+	return mac, nil
+}
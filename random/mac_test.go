@@ -0,0 +1,51 @@
+package random_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzMAC(f *testing.F) {
+	f.Fuzz(func(t *testing.T, local, multicast, eui64 bool) {
+		mac, err := random.MAC(rand.Reader, local, multicast, eui64, nil)
+		if err != nil {
+			t.Fatalf("error generating a random MAC address: %v", err)
+		}
+
+		expected := validate.MACFlags{Local: local, Multicast: multicast, EUI64: eui64}
+
+		flags, err := validate.MAC(mac.String())
+		if err != nil {
+			t.Fatalf("unexpected error for the random MAC address %s: %v", mac, err)
+		}
+
+		if flags != expected {
+			t.Fatalf("expected flags %+v for the random MAC address %s, but got %+v", expected, mac, flags)
+		}
+	})
+}
+
+func TestMACWithOUI(t *testing.T) {
+	oui := []byte{0x52, 0x54, 0x00}
+
+	mac, err := random.MAC(rand.Reader, true, false, false, oui)
+	if err != nil {
+		t.Fatalf("error generating a random MAC address with OUI: %v", err)
+	}
+
+	if !bytes.HasPrefix(mac, oui) {
+		t.Errorf("random MAC address %s does not start with OUI %x", mac, oui)
+	}
+
+	if _, err := random.MAC(rand.Reader, false, false, false, oui); err == nil {
+		t.Error("expected error for a local OUI of a universal address, but got nil")
+	}
+
+	if _, err := random.MAC(rand.Reader, true, false, false, make([]byte, 6)); err == nil {
+		t.Error("expected error for an OUI as long as the address, but got nil")
+	}
+}
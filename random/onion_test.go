@@ -0,0 +1,30 @@
+package random_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzOnionAddress(f *testing.F) {
+	f.Fuzz(func(t *testing.T, _ uint8) {
+		publicKey, privateKey, address, err := random.OnionAddress(rand.Reader)
+		if err != nil {
+			t.Fatalf("error generating a random onion address: %v", err)
+		}
+
+		if err := validate.OnionAddress(address); err != nil {
+			t.Fatalf("invalid onion address \"%s\": %v", address, err)
+		}
+
+		if err := validate.Domain(address, 0, 0); err != nil {
+			t.Fatalf("onion address \"%s\" not a valid domain: %v", address, err)
+		}
+
+		if err := validate.KeyPair(random.AlgorithmED25519, publicKey, privateKey); err != nil {
+			t.Fatalf("invalid onion service key pair: %v", err)
+		}
+	})
+}
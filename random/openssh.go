@@ -0,0 +1,118 @@
+package random
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/mlkem"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const pemTypeOpenSSHPrivateKey string = "OPENSSH PRIVATE KEY"
+
+// EncodePrivateKeyOpenSSH encodes a private key generated by KeyPair in the OpenSSH private key format ("OPENSSH PRIVATE KEY") with the given comment.
+// OpenSSH has no support for the P-224 curve and key agreement keys, so keys generated with AlgorithmECDSAP224, AlgorithmX25519,
+// the ECDH algorithms and the ML-KEM algorithms can not be encoded in this format and ErrUnsupportedAlgorithm is returned.
+func EncodePrivateKeyOpenSSH(privateKey crypto.PrivateKey, comment string) ([]byte, error) {
+	if err := checkOpenSSH(privateKey); err != nil {
+		return nil, err
+	}
+
+	block, err := ssh.MarshalPrivateKey(privateKey, comment)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling private key to OpenSSH format: %w", err)
+	}
+
+	return pem.EncodeToMemory(block), nil
+}
+
+// EncodeAuthorizedKey encodes a public key generated by KeyPair as a single authorized_keys line with the given comment.
+// If comment is empty, the line only contains the key type and the base64-encoded key.
+// OpenSSH has no support for the P-224 curve and key agreement keys, so keys generated with AlgorithmECDSAP224, AlgorithmX25519,
+// the ECDH algorithms and the ML-KEM algorithms can not be encoded in this format and ErrUnsupportedAlgorithm is returned.
+func EncodeAuthorizedKey(publicKey crypto.PublicKey, comment string) ([]byte, error) {
+	if err := checkOpenSSH(publicKey); err != nil {
+		return nil, err
+	}
+
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("error converting public key to OpenSSH format: %w", err)
+	}
+
+	line := bytes.TrimSuffix(ssh.MarshalAuthorizedKey(sshPublicKey), []byte("\n"))
+
+	if comment != "" {
+		line = append(line, ' ')
+		line = append(line, comment...)
+	}
+
+	return append(line, '\n'), nil
+}
+
+// DecodePrivateKeyOpenSSH decodes an unencrypted private key in the OpenSSH private key format.
+// The returned key has the same type KeyPair returns for its algorithm.
+func DecodePrivateKeyOpenSSH(data []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if block.Type != pemTypeOpenSSHPrivateKey {
+		return nil, fmt.Errorf("unexpected PEM block type \"%s\", expected \"%s\"", block.Type, pemTypeOpenSSHPrivateKey)
+	}
+
+	privateKey, err := ssh.ParseRawPrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing OpenSSH private key: %w", err)
+	}
+
+	// The ssh package returns ED25519 private keys as pointers, while KeyPair returns them as values.
+	if ed25519PrivateKey, ok := privateKey.(*ed25519.PrivateKey); ok {
+		return *ed25519PrivateKey, nil
+	}
+
+	return privateKey, nil
+}
+
+// DecodeAuthorizedKey decodes the first public key in authorized_keys format in data and returns it with its comment.
+// The returned key has the same type KeyPair returns for its algorithm.
+func DecodeAuthorizedKey(data []byte) (crypto.PublicKey, string, error) {
+	sshPublicKey, comment, _, _, err := ssh.ParseAuthorizedKey(data)
+	if err != nil {
+		return nil, "", fmt.Errorf("error parsing authorized key: %w", err)
+	}
+
+	cryptoPublicKey, ok := sshPublicKey.(ssh.CryptoPublicKey)
+	if !ok {
+		return nil, "", fmt.Errorf("unsupported authorized key type %s", sshPublicKey.Type())
+	}
+
+	return cryptoPublicKey.CryptoPublicKey(), comment, nil
+}
+
+// checkOpenSSH returns ErrUnsupportedAlgorithm for the keys which have no OpenSSH key type: ECDSA P-224 and key agreement keys.
+func checkOpenSSH(key any) error {
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey:
+		if key.Curve == elliptic.P224() {
+			return fmt.Errorf("%w: OpenSSH has no support for ECDSA P-224 keys", ErrUnsupportedAlgorithm)
+		}
+	case *ecdsa.PublicKey:
+		if key.Curve == elliptic.P224() {
+			return fmt.Errorf("%w: OpenSSH has no support for ECDSA P-224 keys", ErrUnsupportedAlgorithm)
+		}
+	case *ecdh.PrivateKey, *ecdh.PublicKey,
+		*mlkem.EncapsulationKey768, *mlkem.DecapsulationKey768, *mlkem.EncapsulationKey1024, *mlkem.DecapsulationKey1024:
+		return fmt.Errorf("%w: OpenSSH has no support for key agreement keys", ErrUnsupportedAlgorithm)
+	}
+
+	return nil
+}
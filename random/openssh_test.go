@@ -0,0 +1,70 @@
+package random_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzKeyPairOpenSSH(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a uint) {
+		algorithm := random.Algorithm(int(a % 14))
+
+		publicKey, privateKey, err := random.KeyPair(rand.Reader, algorithm)
+		if err != nil {
+			t.Fatalf("error generating a random key pair of type %s: %v", algorithm.String(), err)
+		}
+
+		if _, err := algorithm.OpenSSH(); err != nil {
+			if _, err := random.EncodeAuthorizedKey(publicKey, ""); !errors.Is(err, random.ErrUnsupportedAlgorithm) {
+				t.Fatalf("expected ErrUnsupportedAlgorithm encoding %s public key to authorized_keys format, but got %v", algorithm.String(), err)
+			}
+			if _, err := random.EncodePrivateKeyOpenSSH(privateKey, ""); !errors.Is(err, random.ErrUnsupportedAlgorithm) {
+				t.Fatalf("expected ErrUnsupportedAlgorithm encoding %s private key to OpenSSH format, but got %v", algorithm.String(), err)
+			}
+			return
+		}
+
+		comment, err := random.Username(rand.Reader, false, false, nil)
+		if err != nil {
+			t.Fatalf("error generating a random username for comment: %v", err)
+		}
+
+		authorizedKey, err := random.EncodeAuthorizedKey(publicKey, comment)
+		if err != nil {
+			t.Fatalf("error encoding %s public key to authorized_keys format: %v", algorithm.String(), err)
+		}
+
+		privateKeyOpenSSH, err := random.EncodePrivateKeyOpenSSH(privateKey, comment)
+		if err != nil {
+			t.Fatalf("error encoding %s private key to OpenSSH format: %v", algorithm.String(), err)
+		}
+
+		err = validate.KeyPairOpenSSH(algorithm, authorizedKey, privateKeyOpenSSH)
+		if err != nil {
+			t.Fatalf("invalid OpenSSH-encoded key pair: %v", err)
+		}
+
+		decodedPublicKey, decodedComment, err := random.DecodeAuthorizedKey(authorizedKey)
+		if err != nil {
+			t.Fatalf("error decoding %s public key from authorized_keys format: %v", algorithm.String(), err)
+		}
+
+		if decodedComment != comment {
+			t.Fatalf("expected comment \"%s\", but got \"%s\"", comment, decodedComment)
+		}
+
+		decodedPrivateKey, err := random.DecodePrivateKeyOpenSSH(privateKeyOpenSSH)
+		if err != nil {
+			t.Fatalf("error decoding %s private key from OpenSSH format: %v", algorithm.String(), err)
+		}
+
+		err = validate.KeyPair(algorithm, decodedPublicKey, decodedPrivateKey)
+		if err != nil {
+			t.Fatalf("invalid decoded key pair: %v", err)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package random
+
+import (
+	"crypto"
+	"crypto/mlkem"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+const (
+	pemTypePrivateKey string = "PRIVATE KEY"
+	pemTypePublicKey  string = "PUBLIC KEY"
+)
+
+// ErrUnsupportedAlgorithm is returned when a key generated by KeyPair can't be encoded in the requested format.
+var ErrUnsupportedAlgorithm = errors.New("unsupported algorithm")
+
+// EncodePrivateKeyPEM encodes a private key generated by KeyPair as a PKCS #8 PEM block of type "PRIVATE KEY".
+// crypto/x509 has no PKCS #8 encoding of ML-KEM keys, so keys generated with AlgorithmMLKEM768 and AlgorithmMLKEM1024
+// can not be encoded in this format and ErrUnsupportedAlgorithm is returned.
+func EncodePrivateKeyPEM(privateKey crypto.PrivateKey) ([]byte, error) {
+	if err := checkPKCS8(privateKey); err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling private key to PKCS #8: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypePrivateKey, Bytes: der}), nil
+}
+
+// EncodePublicKeyPEM encodes a public key generated by KeyPair as a PKIX (SubjectPublicKeyInfo) PEM block of type "PUBLIC KEY".
+// crypto/x509 has no PKIX encoding of ML-KEM keys, so keys generated with AlgorithmMLKEM768 and AlgorithmMLKEM1024
+// can not be encoded in this format and ErrUnsupportedAlgorithm is returned.
+func EncodePublicKeyPEM(publicKey crypto.PublicKey) ([]byte, error) {
+	if err := checkPKCS8(publicKey); err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling public key to PKIX: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypePublicKey, Bytes: der}), nil
+}
+
+// DecodePrivateKeyPEM decodes the first PKCS #8 "PRIVATE KEY" PEM block in data.
+// The returned key has the same type KeyPair returns for its algorithm, except for AlgorithmECDHP256 and AlgorithmECDHP384,
+// whose keys share the PKCS #8 key type of ECDSA keys and are returned as *ecdsa.PrivateKey. Their ECDH method converts them back.
+func DecodePrivateKeyPEM(data []byte) (crypto.PrivateKey, error) {
+	der, err := decodePEMBlock(data, pemTypePrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing PKCS #8 private key: %w", err)
+	}
+
+	return privateKey, nil
+}
+
+// DecodePublicKeyPEM decodes the first PKIX "PUBLIC KEY" PEM block in data.
+// The returned key has the same type KeyPair returns for its algorithm, except for AlgorithmECDHP256 and AlgorithmECDHP384,
+// whose keys share the PKIX key type of ECDSA keys and are returned as *ecdsa.PublicKey. Their ECDH method converts them back.
+func DecodePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	der, err := decodePEMBlock(data, pemTypePublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing PKIX public key: %w", err)
+	}
+
+	return publicKey, nil
+}
+
+// decodePEMBlock returns the bytes of the first PEM block in data and checks it has the expected type.
+func decodePEMBlock(data []byte, blockType string) ([]byte, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if block.Type != blockType {
+		return nil, fmt.Errorf("unexpected PEM block type \"%s\", expected \"%s\"", block.Type, blockType)
+	}
+
+	return block.Bytes, nil
+}
+
+// checkPKCS8 returns ErrUnsupportedAlgorithm for the ML-KEM keys, which crypto/x509 can't encode in PKCS #8 or PKIX.
+func checkPKCS8(key any) error {
+	switch key.(type) {
+	case *mlkem.EncapsulationKey768, *mlkem.DecapsulationKey768, *mlkem.EncapsulationKey1024, *mlkem.DecapsulationKey1024:
+		return fmt.Errorf("%w: ML-KEM keys can not be encoded in PKCS #8 or PKIX", ErrUnsupportedAlgorithm)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package random_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzKeyPairPEM(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a uint) {
+		algorithm := random.Algorithm(int(a % 14))
+
+		publicKey, privateKey, err := random.KeyPair(rand.Reader, algorithm)
+		if err != nil {
+			t.Fatalf("error generating a random key pair of type %s: %v", algorithm.String(), err)
+		}
+
+		if algorithm == random.AlgorithmMLKEM768 || algorithm == random.AlgorithmMLKEM1024 {
+			if _, err := random.EncodePublicKeyPEM(publicKey); !errors.Is(err, random.ErrUnsupportedAlgorithm) {
+				t.Fatalf("expected ErrUnsupportedAlgorithm encoding %s public key to PEM, but got %v", algorithm.String(), err)
+			}
+			if _, err := random.EncodePrivateKeyPEM(privateKey); !errors.Is(err, random.ErrUnsupportedAlgorithm) {
+				t.Fatalf("expected ErrUnsupportedAlgorithm encoding %s private key to PEM, but got %v", algorithm.String(), err)
+			}
+			return
+		}
+
+		publicKeyPEM, err := random.EncodePublicKeyPEM(publicKey)
+		if err != nil {
+			t.Fatalf("error encoding %s public key to PEM: %v", algorithm.String(), err)
+		}
+
+		privateKeyPEM, err := random.EncodePrivateKeyPEM(privateKey)
+		if err != nil {
+			t.Fatalf("error encoding %s private key to PEM: %v", algorithm.String(), err)
+		}
+
+		err = validate.KeyPairPEM(algorithm, publicKeyPEM, privateKeyPEM)
+		if err != nil {
+			t.Fatalf("invalid PEM-encoded key pair: %v", err)
+		}
+
+		decodedPublicKey, err := random.DecodePublicKeyPEM(publicKeyPEM)
+		if err != nil {
+			t.Fatalf("error decoding %s public key from PEM: %v", algorithm.String(), err)
+		}
+
+		decodedPrivateKey, err := random.DecodePrivateKeyPEM(privateKeyPEM)
+		if err != nil {
+			t.Fatalf("error decoding %s private key from PEM: %v", algorithm.String(), err)
+		}
+
+		if algorithm == random.AlgorithmECDHP256 || algorithm == random.AlgorithmECDHP384 {
+			decodedPublicKey, err = decodedPublicKey.(*ecdsa.PublicKey).ECDH()
+			if err != nil {
+				t.Fatalf("error converting decoded %s public key to ECDH: %v", algorithm.String(), err)
+			}
+			decodedPrivateKey, err = decodedPrivateKey.(*ecdsa.PrivateKey).ECDH()
+			if err != nil {
+				t.Fatalf("error converting decoded %s private key to ECDH: %v", algorithm.String(), err)
+			}
+		}
+
+		err = validate.KeyPair(algorithm, decodedPublicKey, decodedPrivateKey)
+		if err != nil {
+			t.Fatalf("invalid decoded key pair: %v", err)
+		}
+
+		if !decodedPublicKey.(interface{ Equal(crypto.PublicKey) bool }).Equal(publicKey) {
+			t.Fatal("decoded public key doesn't match the original public key")
+		}
+	})
+}
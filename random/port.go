@@ -5,14 +5,21 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+
+	"github.com/copartner6412/input/validate"
 )
 
 const (
-	limitPorts           uint = 1 << 16
-	limitPortsWellKnown  uint = 1 << 10
-	limitPortsRegistered uint = 49151 + 1
+	limitPorts           uint   = 1 << 16
+	limitPortsWellKnown  uint   = 1 << 10
+	limitPortsRegistered uint   = 49151 + 1
+	maxPortAllowed       uint16 = 65535
 )
 
+// PortRange is a set of ports, such as one parsed from "8000-8100,9000".
+type PortRange = validate.PortRange
+
+// Port generates a cryptographically-secure random port number [0–65535].
 func Port(randomness io.Reader) (uint16, error) {
 	random, err := rand.Int(randomness, big.NewInt(int64(limitPorts)))
 	if err != nil {
@@ -22,6 +29,45 @@ func Port(randomness io.Reader) (uint16, error) {
 	return port, nil
 }
 
+// PortBetween generates a cryptographically-secure random port number in the range [minPort, maxPort].
+// A minPort and maxPort of 0 generate a port in the range [0, 65535].
+func PortBetween(randomness io.Reader, minPort, maxPort uint16) (uint16, error) {
+	if minPort == 0 && maxPort == 0 {
+		maxPort = maxPortAllowed
+	} else if maxPort < minPort {
+		return 0, fmt.Errorf("maxPort can not be less than minPort")
+	}
+
+	random, err := rand.Int(randomness, big.NewInt(int64(maxPort-minPort)+1))
+	if err != nil {
+		return 0, fmt.Errorf("error generating a random number for port: %w", err)
+	}
+	port := uint16(random.Int64()) + minPort
+	return port, nil
+}
+
+// PortInRange generates a cryptographically-secure random port number of ports which is not in excluded.
+// If unregistered is true, ports registered to a service of validate.ServiceRegistry for any transport protocol are excluded too.
+// Every remaining port is equally likely.
+func PortInRange(randomness io.Reader, ports, excluded PortRange, unregistered bool) (uint16, error) {
+	if unregistered {
+		excluded = excluded.Union(validate.RegisteredPorts(""))
+	}
+
+	free := ports.Difference(excluded)
+	if free.Len() == 0 {
+		return 0, fmt.Errorf("no port left in %q after excluding %q", ports, excluded)
+	}
+
+	random, err := rand.Int(randomness, big.NewInt(int64(free.Len())))
+	if err != nil {
+		return 0, fmt.Errorf("error generating a random number for port: %w", err)
+	}
+
+	port, _ := free.At(int(random.Int64()))
+	return port, nil
+}
+
 // PortWellKnown generates a cryptographically-secure random well-known port number [0–1023].
 func PortWellKnown(randomness io.Reader) (uint16, error) {
 	random, err := rand.Int(randomness, big.NewInt(int64(limitPortsWellKnown)))
@@ -59,3 +59,60 @@ func FuzzPortPrivate(f *testing.F) {
 		}
 	})
 }
+
+func FuzzPortBetween(f *testing.F) {
+	f.Fuzz(func(t *testing.T, minPort, maxPort uint16) {
+		if maxPort < minPort {
+			minPort, maxPort = maxPort, minPort
+		}
+		port, err := random.PortBetween(rand.Reader, minPort, maxPort)
+		if err != nil {
+			t.Fatalf("error generating a random port in [%d, %d]: %v", minPort, maxPort, err)
+		}
+		err = validate.Port(port, minPort, maxPort)
+		if err != nil {
+			t.Fatalf("expected no error for valid random port %d, but got error: %v", port, err)
+		}
+	})
+}
+
+func FuzzPortInRange(f *testing.F) {
+	ports, err := validate.ParsePortRange("0-1023,8000-8100,9000")
+	if err != nil {
+		f.Fatalf("error parsing port range: %v", err)
+	}
+	excluded, err := validate.ParsePortRange("80,443,8000-8079")
+	if err != nil {
+		f.Fatalf("error parsing excluded port range: %v", err)
+	}
+
+	f.Fuzz(func(t *testing.T, a int) {
+		port, err := random.PortInRange(rand.Reader, ports, excluded, true)
+		if err != nil {
+			t.Fatalf("error generating a random port in range: %v", err)
+		}
+		err = validate.PortInRange(port, ports, excluded)
+		if err != nil {
+			t.Fatalf("expected no error for valid random port %d, but got error: %v", port, err)
+		}
+		err = validate.PortNotRegistered(port, "")
+		if err != nil {
+			t.Fatalf("expected no error for valid random unregistered port %d, but got error: %v", port, err)
+		}
+	})
+}
+
+func TestPortInRangeFailsWhenNoPortIsLeft(t *testing.T) {
+	ports, err := validate.ParsePortRange("80,443")
+	if err != nil {
+		t.Fatalf("error parsing port range: %v", err)
+	}
+
+	if _, err := random.PortInRange(rand.Reader, ports, random.PortRange{}, true); err == nil {
+		t.Error("expected error for registered ports only, but got no error")
+	}
+
+	if _, err := random.PortInRange(rand.Reader, random.PortRange{}, random.PortRange{}, false); err == nil {
+		t.Error("expected error for empty port range, but got no error")
+	}
+}
@@ -0,0 +1,41 @@
+package random
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+	"net/netip"
+)
+
+// CIDRv4Prefix generates a random IPv4 network as a netip.Prefix with a prefix length between 8 and 30, like CIDRv4.
+// The returned prefix is masked, i.e. its host bits are zero.
+func CIDRv4Prefix(randomness io.Reader) (netip.Prefix, error) {
+	addr, err := IPv4Addr(randomness, netip.Prefix{})
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	random1, err := rand.Int(randomness, big.NewInt(23))
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("error generating a random number for calculating prefix length: %w", err)
+	}
+
+	return netip.PrefixFrom(addr, int(random1.Int64())+8).Masked(), nil
+}
+
+// CIDRv6Prefix generates a random IPv6 network as a netip.Prefix with a prefix length between 8 and 126, like CIDRv6.
+// The returned prefix is masked, i.e. its host bits are zero.
+func CIDRv6Prefix(randomness io.Reader) (netip.Prefix, error) {
+	addr, err := IPv6Addr(randomness, netip.Prefix{})
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	random1, err := rand.Int(randomness, big.NewInt(119))
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("error generating a random number for calculating prefix length: %w", err)
+	}
+
+	return netip.PrefixFrom(addr, int(random1.Int64())+8).Masked(), nil
+}
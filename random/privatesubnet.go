@@ -0,0 +1,253 @@
+package random
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/bits"
+	"net"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/copartner6412/input/validate"
+)
+
+// PrefixSource returns prefixes which are in use on a host and must not be chosen for a new network.
+type PrefixSource func() ([]netip.Prefix, error)
+
+// Private address pools of RFC 1918 and the ULA pool fd00::/8 of RFC 4193, in which PrivateSubnet chooses networks.
+var (
+	privateIPv4Pools = []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("172.16.0.0/12"),
+		netip.MustParsePrefix("192.168.0.0/16"),
+	}
+	privateIPv6Pools = []netip.Prefix{
+		netip.MustParsePrefix("fd00::/8"),
+	}
+)
+
+const (
+	procNetRoute     = "/proc/net/route"
+	procNetIPv6Route = "/proc/net/ipv6_route"
+)
+
+// PrivateSubnet chooses a random private network with the specified prefix length, like the default address pools of Docker.
+// IPv4 networks are chosen in the RFC 1918 ranges and IPv6 networks in the ULA range fd00::/8, and every free network is equally likely.
+//
+// The network doesn't overlap any prefix returned by the sources. Without sources, the addresses of the local interfaces
+// and the routing table are used, i.e. InterfacePrefixes and RoutePrefixes. Default routes are ignored, since they cover every network.
+func PrivateSubnet(randomness io.Reader, family IPFamily, bits uint, sources ...PrefixSource) (netip.Prefix, error) {
+	var pools []netip.Prefix
+	switch family {
+	case IPFamilyIPv4:
+		pools = privateIPv4Pools
+	case IPFamilyIPv6:
+		pools = privateIPv6Pools
+	default:
+		return netip.Prefix{}, errors.New("address family must be IPv4 or IPv6")
+	}
+
+	if len(sources) == 0 {
+		sources = []PrefixSource{InterfacePrefixes, RoutePrefixes}
+	}
+
+	var used []netip.Prefix
+	for _, source := range sources {
+		prefixes, err := source()
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("error reading prefixes in use: %w", err)
+		}
+		for _, prefix := range prefixes {
+			if prefix.IsValid() && prefix.Bits() > 0 {
+				used = append(used, prefix)
+			}
+		}
+	}
+
+	poolSet, _ := validate.NewIPSet(pools...)
+	usedSet, _ := validate.NewIPSet(used...)
+
+	candidates, total := subnetCandidates(poolSet.Difference(usedSet), bits)
+	if total.Sign() == 0 {
+		return netip.Prefix{}, fmt.Errorf("no free private /%d %s network", bits, family)
+	}
+
+	n, err := rand.Int(randomness, total)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("error generating a random number for choosing a network: %w", err)
+	}
+
+	return chooseSubnet(candidates, n, bits), nil
+}
+
+// InterfacePrefixes returns the networks of the addresses assigned to the local network interfaces.
+func InterfacePrefixes() ([]netip.Prefix, error) {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("error listing network interfaces: %w", err)
+	}
+
+	var prefixes []netip.Prefix
+
+	for _, iface := range interfaces {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, fmt.Errorf("error listing addresses of interface %s: %w", iface.Name, err)
+		}
+
+		prefixes = append(prefixes, AddrPrefixes(addrs)...)
+	}
+
+	return prefixes, nil
+}
+
+// AddrPrefixes returns the networks of the addresses of type *net.IPNet, as returned by net.Interface.Addrs. Other addresses are skipped.
+// IPv4 addresses in their 16-byte form are unmapped, and their masks are shortened by 96 bits only if they are 16 bytes long too.
+func AddrPrefixes(addrs []net.Addr) []netip.Prefix {
+	var prefixes []netip.Prefix
+
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		ip, ok := netip.AddrFromSlice(ipNet.IP)
+		if !ok {
+			continue
+		}
+		ip = ip.Unmap()
+		ones, bits := ipNet.Mask.Size()
+		if bits == 0 {
+			continue
+		}
+		if len(ipNet.Mask) == net.IPv6len && ip.Is4() {
+			ones -= 96
+		}
+		prefix := netip.PrefixFrom(ip, ones)
+		if !prefix.IsValid() {
+			continue
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	return prefixes
+}
+
+// RoutePrefixes returns the destinations of the IPv4 and IPv6 routing tables read from /proc/net/route and /proc/net/ipv6_route.
+// Missing files, as on systems other than Linux, are treated as empty routing tables.
+func RoutePrefixes() ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+
+	for _, table := range []struct {
+		path  string
+		parse func(io.Reader) ([]netip.Prefix, error)
+	}{
+		{procNetRoute, ParseProcNetRoute},
+		{procNetIPv6Route, ParseProcNetIPv6Route},
+	} {
+		file, err := os.Open(table.path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error opening routing table: %w", err)
+		}
+
+		routes, err := table.parse(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", table.path, err)
+		}
+
+		prefixes = append(prefixes, routes...)
+	}
+
+	return prefixes, nil
+}
+
+// ParseProcNetRoute parses an IPv4 routing table in the format of /proc/net/route and returns the destination of every route.
+// Destinations and masks are hexadecimal numbers in host byte order.
+func ParseProcNetRoute(r io.Reader) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		fields := strings.Fields(scanner.Text())
+		if lineNumber == 1 || len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 8 {
+			return nil, fmt.Errorf("line %d: expected at least 8 fields", lineNumber)
+		}
+
+		destination, err := strconv.ParseUint(fields[1], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid destination \"%s\"", lineNumber, fields[1])
+		}
+
+		mask, err := strconv.ParseUint(fields[7], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid mask \"%s\"", lineNumber, fields[7])
+		}
+
+		var ip, maskBytes [4]byte
+		binary.NativeEndian.PutUint32(ip[:], uint32(destination))
+		binary.NativeEndian.PutUint32(maskBytes[:], uint32(mask))
+
+		ones := bits.OnesCount32(binary.BigEndian.Uint32(maskBytes[:]))
+		if bits.LeadingZeros32(^binary.BigEndian.Uint32(maskBytes[:])) != ones {
+			return nil, fmt.Errorf("line %d: non-contiguous mask \"%s\"", lineNumber, fields[7])
+		}
+
+		prefixes = append(prefixes, netip.PrefixFrom(netip.AddrFrom4(ip), ones).Masked())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return prefixes, nil
+}
+
+// ParseProcNetIPv6Route parses an IPv6 routing table in the format of /proc/net/ipv6_route and returns the destination of every route.
+// Each line starts with the destination as 32 hexadecimal digits and its prefix length as 2 hexadecimal digits.
+func ParseProcNetIPv6Route(r io.Reader) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: expected at least 2 fields", lineNumber)
+		}
+
+		destination, err := hex.DecodeString(fields[0])
+		if err != nil || len(destination) != 16 {
+			return nil, fmt.Errorf("line %d: invalid destination \"%s\"", lineNumber, fields[0])
+		}
+
+		length, err := strconv.ParseUint(fields[1], 16, 8)
+		if err != nil || length > 128 {
+			return nil, fmt.Errorf("line %d: invalid prefix length \"%s\"", lineNumber, fields[1])
+		}
+
+		prefixes = append(prefixes, netip.PrefixFrom(netip.AddrFrom16([16]byte(destination)), int(length)).Masked())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return prefixes, nil
+}
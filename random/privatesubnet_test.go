@@ -0,0 +1,181 @@
+package random_test
+
+import (
+	"crypto/rand"
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+const testProcNetRoute = `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+eth0	00000000	010011AC	0003	0	0	0	00000000	0	0	0
+eth0	000011AC	00000000	0001	0	0	0	0000FFFF	0	0	0
+docker0	000010AC	00000000	0001	0	0	0	0000F0FF	0	0	0
+`
+
+const testProcNetIPv6Route = `00000000000000000000000000000000 00 00000000000000000000000000000000 00 fe800000000000000000000000000001 00000400 00000001 00000000 00000003 eth0
+fd000000000000000000000000000000 09 00000000000000000000000000000000 00 00000000000000000000000000000000 00000100 00000001 00000000 00000001 eth0
+fe800000000000000000000000000000 40 00000000000000000000000000000000 00 00000000000000000000000000000000 00000100 00000001 00000000 00000001 eth0
+`
+
+func staticPrefixes(cidrs ...string) random.PrefixSource {
+	return func() ([]netip.Prefix, error) {
+		var prefixes []netip.Prefix
+		for _, cidr := range cidrs {
+			prefixes = append(prefixes, netip.MustParsePrefix(cidr))
+		}
+		return prefixes, nil
+	}
+}
+
+func TestParseProcNetRoute(t *testing.T) {
+	prefixes, err := random.ParseProcNetRoute(strings.NewReader(testProcNetRoute))
+	if err != nil {
+		t.Fatalf("error parsing routing table: %v", err)
+	}
+
+	expected := []netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/0"),
+		netip.MustParsePrefix("172.17.0.0/16"),
+		netip.MustParsePrefix("172.16.0.0/12"),
+	}
+
+	if len(prefixes) != len(expected) {
+		t.Fatalf("expected %v, but got %v", expected, prefixes)
+	}
+	for i := range expected {
+		if prefixes[i] != expected[i] {
+			t.Errorf("expected %s, but got %s", expected[i], prefixes[i])
+		}
+	}
+
+	if _, err := random.ParseProcNetRoute(strings.NewReader("Iface\tDestination\nbr0\t000011AC\t00000000\t0001\n")); err == nil {
+		t.Error("expected error for a truncated line, but got nil")
+	}
+}
+
+func TestParseProcNetIPv6Route(t *testing.T) {
+	prefixes, err := random.ParseProcNetIPv6Route(strings.NewReader(testProcNetIPv6Route))
+	if err != nil {
+		t.Fatalf("error parsing routing table: %v", err)
+	}
+
+	expected := []netip.Prefix{
+		netip.MustParsePrefix("::/0"),
+		netip.MustParsePrefix("fd00::/9"),
+		netip.MustParsePrefix("fe80::/64"),
+	}
+
+	if len(prefixes) != len(expected) {
+		t.Fatalf("expected %v, but got %v", expected, prefixes)
+	}
+	for i := range expected {
+		if prefixes[i] != expected[i] {
+			t.Errorf("expected %s, but got %s", expected[i], prefixes[i])
+		}
+	}
+}
+
+func TestAddrPrefixes(t *testing.T) {
+	addrs := []net.Addr{
+		&net.IPNet{IP: net.IPv4(192, 168, 1, 10), Mask: net.CIDRMask(24, 32)},
+		&net.IPNet{IP: net.IPv4(10, 1, 2, 3), Mask: net.CIDRMask(104, 128)},
+		&net.IPNet{IP: net.ParseIP("fd00::1"), Mask: net.CIDRMask(64, 128)},
+		&net.IPAddr{IP: net.IPv4(172, 16, 0, 1)},
+	}
+
+	expected := []netip.Prefix{
+		netip.MustParsePrefix("192.168.1.0/24"),
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("fd00::/64"),
+	}
+
+	prefixes := random.AddrPrefixes(addrs)
+
+	if len(prefixes) != len(expected) {
+		t.Fatalf("expected %v, but got %v", expected, prefixes)
+	}
+	for i := range expected {
+		if prefixes[i] != expected[i] {
+			t.Errorf("expected %s, but got %s", expected[i], prefixes[i])
+		}
+	}
+}
+
+func FuzzPrivateSubnet(f *testing.F) {
+	routes := func() ([]netip.Prefix, error) {
+		ipv4, err := random.ParseProcNetRoute(strings.NewReader(testProcNetRoute))
+		if err != nil {
+			return nil, err
+		}
+		ipv6, err := random.ParseProcNetIPv6Route(strings.NewReader(testProcNetIPv6Route))
+		return append(ipv4, ipv6...), err
+	}
+	interfaces := staticPrefixes("10.0.0.0/9", "192.168.1.0/24", "fd80::/10")
+
+	f.Fuzz(func(t *testing.T, a int) {
+		ipv4, err := random.PrivateSubnet(rand.Reader, random.IPFamilyIPv4, 24, routes, interfaces)
+		if err != nil {
+			t.Fatalf("error choosing a private IPv4 subnet: %v", err)
+		}
+
+		if err := validate.IPAddrClass(ipv4.Addr(), validate.IPClassPrivate); err != nil {
+			t.Fatalf("unexpected error for the private IPv4 subnet %s: %v", ipv4, err)
+		}
+
+		used, _ := routes()
+		used = append(used, netip.MustParsePrefix("10.0.0.0/9"), netip.MustParsePrefix("192.168.1.0/24"))
+		// The default route is ignored.
+		if err := validate.Subnets([]string{ipv4.String()}, netip.MustParsePrefix("0.0.0.0/0"), used[1:]...); err != nil {
+			t.Fatalf("private IPv4 subnet %s collides with a used prefix: %v", ipv4, err)
+		}
+
+		ipv6, err := random.PrivateSubnet(rand.Reader, random.IPFamilyIPv6, 64, routes, interfaces)
+		if err != nil {
+			t.Fatalf("error choosing a private IPv6 subnet: %v", err)
+		}
+
+		if err := validate.CIDRWithin(ipv6.String(), netip.MustParsePrefix("fd00::/8")); err != nil {
+			t.Fatalf("unexpected error for the private IPv6 subnet %s: %v", ipv6, err)
+		}
+
+		if !ipv6.Addr().Is6() || netip.MustParsePrefix("fd00::/9").Overlaps(ipv6) || netip.MustParsePrefix("fd80::/10").Overlaps(ipv6) {
+			t.Fatalf("private IPv6 subnet %s collides with a used prefix", ipv6)
+		}
+	})
+}
+
+func TestPrivateSubnetFailsWhenExhausted(t *testing.T) {
+	used := staticPrefixes("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/17", "192.168.128.0/18", "192.168.192.0/19", "192.168.224.0/20", "192.168.240.0/21")
+
+	if subnet, err := random.PrivateSubnet(rand.Reader, random.IPFamilyIPv4, 20, used); err == nil {
+		t.Errorf("expected error when every /20 is used, but got %s", subnet)
+	}
+
+	subnet, err := random.PrivateSubnet(rand.Reader, random.IPFamilyIPv4, 21, used)
+	if err != nil {
+		t.Fatalf("error choosing the last free /21 subnet: %v", err)
+	}
+	if subnet != netip.MustParsePrefix("192.168.248.0/21") {
+		t.Errorf("expected the last free /21 subnet, but got %s", subnet)
+	}
+
+	if _, err := random.PrivateSubnet(rand.Reader, random.IPFamilyAny, 24, used); err == nil {
+		t.Error("expected error for any address family, but got nil")
+	}
+}
+
+func TestPrivateSubnetOnHost(t *testing.T) {
+	subnet, err := random.PrivateSubnet(rand.Reader, random.IPFamilyIPv4, 24)
+	if err != nil {
+		t.Skipf("no free private subnet on this host: %v", err)
+	}
+
+	if err := validate.IPAddrClass(subnet.Addr(), validate.IPClassPrivate); err != nil {
+		t.Errorf("unexpected error for the private IPv4 subnet %s: %v", subnet, err)
+	}
+}
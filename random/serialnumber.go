@@ -0,0 +1,34 @@
+package random
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+)
+
+const (
+	maxSerialNumberLength  int = 20 // RFC 5280 4.1.2.2
+	minSerialNumberBitSize int = 64 // CA/Browser Forum Baseline Requirements 7.1
+)
+
+// SerialNumber generates a random X.509 certificate serial number that conforms to RFC 5280 and the CA/Browser Forum Baseline Requirements.
+// The serial number is read from randomness as 20 octets with the most significant bit cleared, so it is positive,
+// holds 159 bits of randomness and its DER encoding fits in 20 octets without a leading zero octet.
+// Serial numbers shorter than 64 bits are discarded and generated again, so the DER encoding is at least 8 octets long.
+func SerialNumber(randomness io.Reader) (*big.Int, error) {
+	serialBytes := make([]byte, maxSerialNumberLength)
+
+	for {
+		if _, err := io.ReadFull(randomness, serialBytes); err != nil {
+			return nil, fmt.Errorf("error generating random serial number: %w", err)
+		}
+
+		// Clear the most significant bit so that the DER encoding doesn't need a leading zero octet.
+		serialBytes[0] &= 0x7f
+
+		serialNumber := new(big.Int).SetBytes(serialBytes)
+		if serialNumber.BitLen() >= minSerialNumberBitSize {
+			return serialNumber, nil
+		}
+	}
+}
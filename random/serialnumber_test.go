@@ -0,0 +1,23 @@
+package random_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzSerialNumber(f *testing.F) {
+	f.Fuzz(func(t *testing.T, _ uint) {
+		serialNumber, err := random.SerialNumber(rand.Reader)
+		if err != nil {
+			t.Fatalf("error generating a random serial number: %v", err)
+		}
+
+		err = validate.SerialNumber(serialNumber)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,236 @@
+// Package shamir splits secrets into shares with Shamir's secret sharing scheme over GF(256), so that any threshold of the shares
+// reconstruct the secret while fewer shares reveal nothing about it. It is intended for M-of-N custody of high-value secrets,
+// such as passwords generated with random.PasswordProfileTLSCAKey or random.PasswordProfileSSHCAKey and private key material.
+//
+// Shares are encoded in a human-typable text format: the share is encoded with the Crockford base32 alphabet in upper-case groups of
+// five characters separated by hyphens. The encoded bytes are a version byte, the threshold, the index of the share, a random four-byte
+// identifier shared by all shares of a secret, the share value, and the first four bytes of the SHA-256 hash of everything before them as checksum.
+// When decoding, letters are case-insensitive, hyphens and spaces are ignored, and O, I and L are read as 0, 1 and 1.
+package shamir
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	shareVersion   byte = 1
+	headerLength   int  = 7 // version, threshold, index and identifier
+	checksumLength int  = 4
+	groupLength    int  = 5
+
+	minThreshold    uint = 2
+	maxShares       uint = 255
+	minSecretLength int  = 1
+	maxSecretLength int  = 8192
+)
+
+var encoding = base32.NewEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ").WithPadding(base32.NoPadding)
+
+var decodingReplacer = strings.NewReplacer("-", "", " ", "", "O", "0", "I", "1", "L", "1")
+
+// share holds a decoded share.
+type share struct {
+	threshold  byte
+	index      byte
+	identifier [4]byte
+	value      []byte
+}
+
+// Split splits secret into the specified number of shares, any threshold of which reconstruct the secret with Combine.
+// The random coefficients of the polynomials and the identifier of the shares are read from randomness.
+//
+// The threshold must be at least 2 and at most the number of shares, which must be at most 255.
+// The secret must be between 1 and 8192 bytes long.
+func Split(randomness io.Reader, secret []byte, shares, threshold uint) ([]string, error) {
+	if len(secret) < minSecretLength || len(secret) > maxSecretLength {
+		return nil, fmt.Errorf("secret of %d bytes, expected %d to %d bytes", len(secret), minSecretLength, maxSecretLength)
+	}
+
+	if threshold < minThreshold {
+		return nil, fmt.Errorf("threshold %d less than %d", threshold, minThreshold)
+	}
+
+	if shares < threshold || shares > maxShares {
+		return nil, fmt.Errorf("%d shares, expected %d to %d shares for threshold %d", shares, threshold, maxShares, threshold)
+	}
+
+	var identifier [4]byte
+	if _, err := io.ReadFull(randomness, identifier[:]); err != nil {
+		return nil, fmt.Errorf("error generating share identifier: %w", err)
+	}
+
+	values := make([][]byte, shares)
+	for i := range values {
+		values[i] = make([]byte, len(secret))
+	}
+
+	// Each byte of the secret is the constant term of a random polynomial of degree threshold-1, which is evaluated at x = 1..shares.
+	coefficients := make([]byte, threshold)
+	defer clear(coefficients)
+
+	for i, b := range secret {
+		coefficients[0] = b
+		if _, err := io.ReadFull(randomness, coefficients[1:]); err != nil {
+			return nil, fmt.Errorf("error generating polynomial coefficients: %w", err)
+		}
+
+		for j := range values {
+			values[j][i] = evaluate(coefficients, byte(j+1))
+		}
+	}
+
+	encoded := make([]string, 0, shares)
+	for j, value := range values {
+		encoded = append(encoded, encode(share{threshold: byte(threshold), index: byte(j + 1), identifier: identifier, value: value}))
+		clear(value)
+	}
+
+	return encoded, nil
+}
+
+// Combine reconstructs a secret from shares generated by Split. At least threshold shares of the same secret with different indexes are required.
+func Combine(shares []string) ([]byte, error) {
+	decoded := make([]share, 0, len(shares))
+
+	for i, s := range shares {
+		d, err := decode(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid share %d: %w", i, err)
+		}
+		decoded = append(decoded, d)
+	}
+
+	if len(decoded) == 0 {
+		return nil, errors.New("no shares")
+	}
+
+	first := decoded[0]
+	indexes := make(map[byte]struct{}, len(decoded))
+
+	for i, d := range decoded {
+		if d.identifier != first.identifier {
+			return nil, fmt.Errorf("share %d belongs to a different secret", i)
+		}
+		if d.threshold != first.threshold || len(d.value) != len(first.value) {
+			return nil, fmt.Errorf("share %d doesn't match the threshold or length of share 0", i)
+		}
+		if _, ok := indexes[d.index]; ok {
+			return nil, fmt.Errorf("share %d has the same index %d as another share", i, d.index)
+		}
+		indexes[d.index] = struct{}{}
+	}
+
+	if len(decoded) < int(first.threshold) {
+		return nil, fmt.Errorf("%d shares, expected at least %d", len(decoded), first.threshold)
+	}
+
+	// Lagrange interpolation at x = 0, in which subtraction is addition (XOR) in GF(256).
+	secret := make([]byte, len(first.value))
+
+	for i, d := range decoded {
+		basis := byte(1)
+		for j, other := range decoded {
+			if i != j {
+				basis = mul(basis, div(other.index, other.index^d.index))
+			}
+		}
+
+		for k := range secret {
+			secret[k] ^= mul(d.value[k], basis)
+		}
+	}
+
+	return secret, nil
+}
+
+// encode encodes a share in the text format of the package.
+func encode(s share) string {
+	data := make([]byte, 0, headerLength+len(s.value)+checksumLength)
+	data = append(data, shareVersion, s.threshold, s.index)
+	data = append(data, s.identifier[:]...)
+	data = append(data, s.value...)
+
+	checksum := sha256.Sum256(data)
+	data = append(data, checksum[:checksumLength]...)
+
+	text := encoding.EncodeToString(data)
+	clear(data)
+
+	groups := make([]string, 0, len(text)/groupLength+1)
+	for len(text) > groupLength {
+		groups = append(groups, text[:groupLength])
+		text = text[groupLength:]
+	}
+
+	return strings.Join(append(groups, text), "-")
+}
+
+// decode decodes and checks a share in the text format of the package.
+func decode(text string) (share, error) {
+	data, err := encoding.DecodeString(decodingReplacer.Replace(strings.ToUpper(text)))
+	if err != nil {
+		return share{}, fmt.Errorf("invalid base32 share: %w", err)
+	}
+
+	if len(data) < headerLength+minSecretLength+checksumLength {
+		return share{}, fmt.Errorf("share of %d bytes too short", len(data))
+	}
+
+	payload, checksum := data[:len(data)-checksumLength], data[len(data)-checksumLength:]
+	if sum := sha256.Sum256(payload); !bytes.Equal(sum[:checksumLength], checksum) {
+		return share{}, errors.New("invalid share checksum, the share is mistyped or corrupted")
+	}
+
+	if payload[0] != shareVersion {
+		return share{}, fmt.Errorf("unsupported share version %d", payload[0])
+	}
+
+	s := share{threshold: payload[1], index: payload[2], value: payload[headerLength:]}
+	copy(s.identifier[:], payload[3:headerLength])
+
+	if uint(s.threshold) < minThreshold {
+		return share{}, fmt.Errorf("threshold %d less than %d", s.threshold, minThreshold)
+	}
+
+	if s.index == 0 {
+		return share{}, errors.New("share index 0 would reveal the secret")
+	}
+
+	return s, nil
+}
+
+// evaluate evaluates the polynomial with the specified coefficients at x with Horner's method.
+func evaluate(coefficients []byte, x byte) byte {
+	var y byte
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		y = mul(y, x) ^ coefficients[i]
+	}
+	return y
+}
+
+// mul multiplies a and b in GF(256) with the AES reduction polynomial x^8 + x^4 + x^3 + x + 1.
+// It has no branches or table lookups depending on its arguments, so it runs in constant time.
+func mul(a, b byte) byte {
+	var p byte
+	for i := 0; i < 8; i++ {
+		p ^= a & -(b & 1)
+		a = a<<1 ^ 0x1b&-(a>>7)
+		b >>= 1
+	}
+	return p
+}
+
+// div divides a by b in GF(256), using b^254 as the inverse of b. b must not be zero.
+func div(a, b byte) byte {
+	inverse := b
+	for i := 0; i < 6; i++ {
+		inverse = mul(mul(inverse, inverse), b)
+	}
+	return mul(a, mul(inverse, inverse))
+}
@@ -0,0 +1,146 @@
+package shamir_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/random/shamir"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzSplit(f *testing.F) {
+	f.Fuzz(func(t *testing.T, secret []byte, n, m uint8) {
+		if len(secret) == 0 || len(secret) > 8192 {
+			t.Skip("secret length out of range")
+		}
+
+		shares := uint(n%16) + 2
+		threshold := uint(m)%(shares-1) + 2
+
+		encoded, err := shamir.Split(rand.Reader, secret, shares, threshold)
+		if err != nil {
+			t.Fatalf("error splitting secret into %d shares with threshold %d: %v", shares, threshold, err)
+		}
+
+		if uint(len(encoded)) != shares {
+			t.Fatalf("expected %d shares, but got %d", shares, len(encoded))
+		}
+
+		// Any threshold shares reconstruct the secret, here the last ones in reverse order.
+		subset := make([]string, 0, threshold)
+		for i := len(encoded) - 1; uint(len(subset)) < threshold; i-- {
+			subset = append(subset, encoded[i])
+		}
+
+		if err := validate.ShamirShares(subset); err != nil {
+			t.Fatalf("invalid shares: %v", err)
+		}
+
+		combined, err := shamir.Combine(subset)
+		if err != nil {
+			t.Fatalf("error combining shares: %v", err)
+		}
+
+		if !bytes.Equal(combined, secret) {
+			t.Fatal("combined secret differs from the original secret")
+		}
+
+		if err := validate.ShamirShares(subset[:threshold-1]); err == nil {
+			t.Fatal("expected error for fewer shares than the threshold, but got nil")
+		}
+	})
+}
+
+func TestSplitPassword(t *testing.T) {
+	t.Parallel()
+
+	password, err := random.PasswordFor(rand.Reader, random.PasswordProfileTLSCAKey)
+	if err != nil {
+		t.Fatalf("error generating password: %v", err)
+	}
+
+	shares, err := shamir.Split(rand.Reader, []byte(password), 5, 3)
+	if err != nil {
+		t.Fatalf("error splitting password: %v", err)
+	}
+
+	// Shares are typed case-insensitively and with confusable characters.
+	typed := []string{strings.ToLower(shares[4]), strings.ReplaceAll(shares[0], "-", " "), strings.ReplaceAll(shares[2], "1", "I")}
+
+	combined, err := shamir.Combine(typed)
+	if err != nil {
+		t.Fatalf("error combining typed shares: %v", err)
+	}
+
+	if string(combined) != password {
+		t.Fatal("combined password differs from the original password")
+	}
+}
+
+func TestCombineFailsForInvalidShares(t *testing.T) {
+	t.Parallel()
+
+	shares, err := shamir.Split(rand.Reader, []byte("secret"), 3, 2)
+	if err != nil {
+		t.Fatalf("error splitting secret: %v", err)
+	}
+
+	otherShares, err := shamir.Split(rand.Reader, []byte("secret"), 3, 2)
+	if err != nil {
+		t.Fatalf("error splitting secret: %v", err)
+	}
+
+	mistyped := []byte(shares[1])
+	if mistyped[0] == 'A' {
+		mistyped[0] = 'B'
+	} else {
+		mistyped[0] = 'A'
+	}
+
+	testCases := map[string][]string{
+		"No shares":             nil,
+		"Below threshold":       shares[:1],
+		"Duplicate share":       {shares[0], shares[0]},
+		"Shares of two secrets": {shares[0], otherShares[1]},
+		"Mistyped share":        {shares[0], string(mistyped)},
+		"Not base32":            {shares[0], "not a share!"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := shamir.Combine(tc); err == nil {
+				t.Errorf("expected error for %q, but got nil", name)
+			}
+			if err := validate.ShamirShares(tc); err == nil {
+				t.Errorf("expected validation error for %q, but got nil", name)
+			}
+		})
+	}
+}
+
+func TestSplitFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		secret            []byte
+		shares, threshold uint
+	}{
+		"Empty secret":           {nil, 3, 2},
+		"Threshold of one":       {[]byte("secret"), 3, 1},
+		"Threshold above shares": {[]byte("secret"), 3, 4},
+		"Too many shares":        {[]byte("secret"), 256, 2},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := shamir.Split(rand.Reader, tc.secret, tc.shares, tc.threshold); err == nil {
+				t.Errorf("expected error for %q, but got nil", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package random
+
+import (
+	"crypto"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/copartner6412/input/validate"
+	"golang.org/x/crypto/ssh"
+)
+
+// SSHCertificateType defines the types of OpenSSH certificates.
+type SSHCertificateType = validate.SSHCertificateType
+
+// List of OpenSSH certificate types.
+const (
+	SSHCertificateTypeUser = validate.SSHCertificateTypeUser
+	SSHCertificateTypeHost = validate.SSHCertificateTypeHost
+)
+
+const (
+	minSSHCertificateValidity   time.Duration = time.Hour
+	maxSSHCertificateValidity   time.Duration = 52 * 7 * day
+	maxSSHCertificateBackdating time.Duration = 5 * time.Minute // tolerance for clock skew between the CA and servers
+
+	maxSSHCertificatePrincipals int  = 3
+	minSSHHostPrincipalLength   uint = 4
+	maxSSHHostPrincipalLength   uint = 32
+)
+
+// defaultSSHUserExtensions are the extensions ssh-keygen adds to user certificates by default.
+var defaultSSHUserExtensions = map[string]string{
+	"permit-X11-forwarding":   "",
+	"permit-agent-forwarding": "",
+	"permit-port-forwarding":  "",
+	"permit-pty":              "",
+	"permit-user-rc":          "",
+}
+
+// SSHCertificate generates a key pair with KeyPair and an OpenSSH certificate of the specified type for its public key signed by caKey,
+// and returns the certificate in authorized_keys format and the private key of the certificate.
+//
+// Empty arguments are replaced by random values:
+//   - keyID: the certificate type followed by the random serial number of the certificate.
+//   - principals: one to three random usernames generated by Username for user certificates, or hostnames generated by LinuxHostname for host certificates.
+//   - validity: a random duration between an hour and 52 weeks. The validity window starts up to five minutes before now.
+//   - extensions: the default extensions of ssh-keygen for user certificates. Host certificates have no extensions.
+//
+// criticalOptions are added as they are. caKey must be a signature private key generated by KeyPair, and algorithm a signature algorithm
+// supported by OpenSSH, i.e. not AlgorithmECDSAP224 or a key agreement algorithm, otherwise ErrUnsupportedAlgorithm is returned.
+func SSHCertificate(randomness io.Reader, certificateType SSHCertificateType, algorithm Algorithm, caKey crypto.PrivateKey, keyID string, principals []string, validity time.Duration, criticalOptions, extensions map[string]string) ([]byte, crypto.PrivateKey, error) {
+	var wireType uint32
+
+	switch certificateType {
+	case SSHCertificateTypeUser:
+		wireType = ssh.UserCert
+	case SSHCertificateTypeHost:
+		wireType = ssh.HostCert
+	default:
+		return nil, nil, errors.New("unsupported SSH certificate type")
+	}
+
+	if _, err := algorithm.OpenSSH(); err != nil {
+		return nil, nil, fmt.Errorf("%w for SSH certificates: %w", ErrUnsupportedAlgorithm, err)
+	}
+
+	if err := checkOpenSSH(caKey); err != nil {
+		return nil, nil, fmt.Errorf("invalid CA private key: %w", err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(caKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CA private key: %w", err)
+	}
+
+	publicKey, privateKey, err := KeyPair(randomness, algorithm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error converting public key to OpenSSH format: %w", err)
+	}
+
+	var serialBytes [8]byte
+	if _, err := io.ReadFull(randomness, serialBytes[:]); err != nil {
+		return nil, nil, fmt.Errorf("error generating serial number: %w", err)
+	}
+	serial := binary.BigEndian.Uint64(serialBytes[:])
+
+	if keyID == "" {
+		keyID = fmt.Sprintf("%s-%016x", certificateType, serial)
+	}
+
+	if len(principals) == 0 {
+		principals, err = sshPrincipals(randomness, certificateType)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if validity == 0 {
+		validity, err = Duration(randomness, minSSHCertificateValidity, maxSSHCertificateValidity)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error generating validity period: %w", err)
+		}
+	}
+
+	backdating, err := Duration(randomness, 0, maxSSHCertificateBackdating)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating backdating of validity window: %w", err)
+	}
+
+	validAfter := time.Now().Add(-backdating)
+	validBefore := validAfter.Add(validity)
+
+	if extensions == nil && certificateType == SSHCertificateTypeUser {
+		extensions = make(map[string]string, len(defaultSSHUserExtensions))
+		for extension, value := range defaultSSHUserExtensions {
+			extensions[extension] = value
+		}
+	}
+
+	certificate := &ssh.Certificate{
+		Key:             sshPublicKey,
+		Serial:          serial,
+		CertType:        wireType,
+		KeyId:           keyID,
+		ValidPrincipals: principals,
+		ValidAfter:      uint64(validAfter.Unix()),
+		ValidBefore:     uint64(validBefore.Unix()),
+		Permissions: ssh.Permissions{
+			CriticalOptions: criticalOptions,
+			Extensions:      extensions,
+		},
+	}
+
+	if err := certificate.SignCert(randomness, signer); err != nil {
+		return nil, nil, fmt.Errorf("error signing %s certificate: %w", certificateType, err)
+	}
+
+	return ssh.MarshalAuthorizedKey(certificate), privateKey, nil
+}
+
+// sshPrincipals generates one to three random principals for a certificate of the specified type.
+func sshPrincipals(randomness io.Reader, certificateType SSHCertificateType) ([]string, error) {
+	principalCount, err := rand.Int(randomness, big.NewInt(int64(maxSSHCertificatePrincipals)))
+	if err != nil {
+		return nil, fmt.Errorf("error generating a random number for principals count: %w", err)
+	}
+
+	principals := make([]string, 0, principalCount.Int64()+1)
+
+	for i := 0; i <= int(principalCount.Int64()); i++ {
+		var principal string
+		if certificateType == SSHCertificateTypeUser {
+			principal, err = Username(randomness, false, false, nil)
+		} else {
+			principal, err = LinuxHostname(randomness, minSSHHostPrincipalLength, maxSSHHostPrincipalLength)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error generating principal: %w", err)
+		}
+		principals = append(principals, principal)
+	}
+
+	return principals, nil
+}
@@ -0,0 +1,45 @@
+package random_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzSSHCertificate(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a uint, c uint, host bool) {
+		algorithm := random.Algorithm(int(a % 14))
+		caAlgorithm := random.Algorithm(int(c % 14))
+		_, err1 := algorithm.OpenSSH()
+		_, err2 := caAlgorithm.OpenSSH()
+
+		certificateType := random.SSHCertificateTypeUser
+		if host {
+			certificateType = random.SSHCertificateTypeHost
+		}
+
+		caPublicKey, caKey, err := random.KeyPair(rand.Reader, caAlgorithm)
+		if err != nil {
+			t.Fatalf("error generating a random %s CA key pair: %v", caAlgorithm, err)
+		}
+
+		certificate, _, err := random.SSHCertificate(rand.Reader, certificateType, algorithm, caKey, "", nil, 0, nil, nil)
+		if err1 != nil || err2 != nil {
+			if !errors.Is(err, random.ErrUnsupportedAlgorithm) {
+				t.Fatalf("expected ErrUnsupportedAlgorithm generating a %s certificate of type %s signed by a %s CA key, but got %v", certificateType, algorithm, caAlgorithm, err)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("error generating a random %s certificate: %v", certificateType, err)
+		}
+
+		if err := validate.SSHCertificate(certificate, certificateType, caPublicKey, "", time.Now()); err != nil {
+			t.Fatalf("invalid %s certificate: %v", certificateType, err)
+		}
+	})
+}
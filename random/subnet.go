@@ -0,0 +1,116 @@
+package random
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+	"net/netip"
+	"sync"
+
+	"github.com/copartner6412/input/validate"
+)
+
+// SubnetAllocator carves random non-overlapping subnets out of a supernet. It is safe for concurrent use.
+type SubnetAllocator struct {
+	mu         sync.Mutex
+	randomness io.Reader
+	supernet   netip.Prefix
+	free       validate.IPSet
+}
+
+// NewSubnetAllocator returns a SubnetAllocator for subnets of the supernet which don't overlap the excluded prefixes.
+func NewSubnetAllocator(randomness io.Reader, supernet netip.Prefix, excluded ...netip.Prefix) (*SubnetAllocator, error) {
+	free, err := freeSubnets(supernet, excluded)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubnetAllocator{randomness: randomness, supernet: supernet.Masked(), free: free}, nil
+}
+
+// Allocate returns a random subnet with the specified prefix length which doesn't overlap the excluded prefixes or any subnet allocated before.
+// Every free aligned subnet of the length is equally likely. Allocating the largest subnets first avoids fragmenting the supernet.
+func (a *SubnetAllocator) Allocate(bits uint) (netip.Prefix, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if bits < uint(a.supernet.Bits()) || bits > uint(a.supernet.Addr().BitLen()) {
+		return netip.Prefix{}, fmt.Errorf("prefix length %d outside of range [%d, %d] of supernet %s", bits, a.supernet.Bits(), a.supernet.Addr().BitLen(), a.supernet)
+	}
+
+	candidates, total := subnetCandidates(a.free, bits)
+	if total.Sign() == 0 {
+		return netip.Prefix{}, fmt.Errorf("no free /%d subnet left in %s", bits, a.supernet)
+	}
+
+	n, err := rand.Int(a.randomness, total)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("error generating a random number for choosing a subnet: %w", err)
+	}
+
+	subnet := chooseSubnet(candidates, n, bits)
+
+	used, _ := validate.NewIPSet(subnet)
+	a.free = a.free.Difference(used)
+
+	return subnet, nil
+}
+
+// Free returns the set of the addresses of the supernet which are neither excluded nor allocated.
+func (a *SubnetAllocator) Free() validate.IPSet {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.free
+}
+
+// freeSubnets returns the addresses of the supernet which are not in the excluded prefixes.
+func freeSubnets(supernet netip.Prefix, excluded []netip.Prefix) (validate.IPSet, error) {
+	if !supernet.IsValid() {
+		return validate.IPSet{}, fmt.Errorf("invalid supernet %s", supernet)
+	}
+
+	set, _ := validate.NewIPSet(supernet)
+
+	excludedSet, err := validate.NewIPSet(excluded...)
+	if err != nil {
+		return validate.IPSet{}, fmt.Errorf("invalid excluded prefix: %w", err)
+	}
+
+	return set.Difference(excludedSet), nil
+}
+
+// subnetCandidates returns the free prefixes which can hold a subnet of the specified length, and the total number of such subnets.
+func subnetCandidates(free validate.IPSet, bits uint) ([]netip.Prefix, *big.Int) {
+	var candidates []netip.Prefix
+	total := new(big.Int)
+
+	for _, prefix := range free.Prefixes() {
+		if uint(prefix.Bits()) <= bits && bits <= uint(prefix.Addr().BitLen()) {
+			candidates = append(candidates, prefix)
+			total.Add(total, new(big.Int).Lsh(big.NewInt(1), bits-uint(prefix.Bits())))
+		}
+	}
+
+	return candidates, total
+}
+
+// chooseSubnet returns the subnet of index n, which is less than the total number of subnets, among the subnets of the specified length in the candidates.
+func chooseSubnet(candidates []netip.Prefix, n *big.Int, bits uint) netip.Prefix {
+	n = new(big.Int).Set(n)
+
+	i := 0
+	for ; i < len(candidates)-1; i++ {
+		count := new(big.Int).Lsh(big.NewInt(1), bits-uint(candidates[i].Bits()))
+		if n.Cmp(count) < 0 {
+			break
+		}
+		n.Sub(n, count)
+	}
+
+	var host [16]byte
+	n.Lsh(n, uint(candidates[i].Addr().BitLen())-bits).FillBytes(host[:])
+
+	return netip.PrefixFrom(hostAddr(candidates[i], host), int(bits))
+}
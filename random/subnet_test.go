@@ -0,0 +1,69 @@
+package random_test
+
+import (
+	"crypto/rand"
+	"net/netip"
+	"testing"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzSubnetAllocator(f *testing.F) {
+	supernet := netip.MustParsePrefix("10.0.0.0/16")
+	excluded := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/20"), netip.MustParsePrefix("10.0.77.0/24")}
+
+	f.Fuzz(func(t *testing.T, a int) {
+		allocator, err := random.NewSubnetAllocator(rand.Reader, supernet, excluded...)
+		if err != nil {
+			t.Fatalf("error creating subnet allocator: %v", err)
+		}
+
+		var subnets []string
+		for _, bits := range []uint{18, 20, 24, 24, 26, 28, 32} {
+			subnet, err := allocator.Allocate(bits)
+			if err != nil {
+				t.Fatalf("error allocating a /%d subnet: %v", bits, err)
+			}
+			subnets = append(subnets, subnet.String())
+		}
+
+		if err := validate.Subnets(subnets, supernet, excluded...); err != nil {
+			t.Fatalf("unexpected error for the random subnets %v: %v", subnets, err)
+		}
+	})
+}
+
+func TestSubnetAllocatorExhaustsSupernet(t *testing.T) {
+	supernet := netip.MustParsePrefix("2001:db8::/62")
+
+	allocator, err := random.NewSubnetAllocator(rand.Reader, supernet, netip.MustParsePrefix("2001:db8:0:1::/64"))
+	if err != nil {
+		t.Fatalf("error creating subnet allocator: %v", err)
+	}
+
+	var subnets []string
+	for range 3 {
+		subnet, err := allocator.Allocate(64)
+		if err != nil {
+			t.Fatalf("error allocating a /64 subnet: %v", err)
+		}
+		subnets = append(subnets, subnet.String())
+	}
+
+	if err := validate.Subnets(subnets, supernet, netip.MustParsePrefix("2001:db8:0:1::/64")); err != nil {
+		t.Fatalf("unexpected error for the random subnets %v: %v", subnets, err)
+	}
+
+	if !allocator.Free().IsEmpty() {
+		t.Errorf("expected no free addresses, but got %s", allocator.Free())
+	}
+
+	if _, err := allocator.Allocate(64); err == nil {
+		t.Error("expected error allocating from a full supernet, but got nil")
+	}
+
+	if _, err := allocator.Allocate(60); err == nil {
+		t.Error("expected error allocating a subnet larger than the supernet, but got nil")
+	}
+}
@@ -0,0 +1,179 @@
+package random
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"sync"
+
+	"github.com/copartner6412/input/validate"
+)
+
+// SymmetricAlgorithm defines the supported symmetric key algorithms.
+type SymmetricAlgorithm = validate.SymmetricAlgorithm
+
+// List of supported symmetric key algorithms.
+const (
+	SymmetricAlgorithmAES128GCM        = validate.SymmetricAlgorithmAES128GCM
+	SymmetricAlgorithmAES256GCM        = validate.SymmetricAlgorithmAES256GCM
+	SymmetricAlgorithmChaCha20Poly1305 = validate.SymmetricAlgorithmChaCha20Poly1305
+	SymmetricAlgorithmHMACSHA256       = validate.SymmetricAlgorithmHMACSHA256
+	SymmetricAlgorithmHMACSHA512       = validate.SymmetricAlgorithmHMACSHA512
+	SymmetricAlgorithmHS256            = validate.SymmetricAlgorithmHS256
+	SymmetricAlgorithmHS384            = validate.SymmetricAlgorithmHS384
+	SymmetricAlgorithmHS512            = validate.SymmetricAlgorithmHS512
+)
+
+// NonceStrategy defines how a NonceGenerator generates nonces.
+type NonceStrategy int
+
+// List of supported nonce strategies.
+const (
+	// Random nonces. NIST SP 800-38D limits random 96-bit nonces to 2^32 per key.
+	NonceStrategyRandom NonceStrategy = iota
+	// A random 32-bit fixed field followed by a 64-bit invocation counter (NIST SP 800-38D, section 8.2.1).
+	// Nonces never repeat for a generator, but different generators for the same key must have different fixed fields.
+	NonceStrategyCounter
+)
+
+var nonceStrategyString = map[NonceStrategy]string{
+	NonceStrategyRandom:  "random",
+	NonceStrategyCounter: "counter",
+}
+
+func (s NonceStrategy) String() string {
+	return nonceStrategyString[s]
+}
+
+const (
+	maxRandomNonces  uint64 = 1 << 32
+	nonceFixedLength int    = 4
+
+	minMasterKeyLength int = 16
+
+	maxSymmetricKeyAttempts int = 8
+)
+
+// SymmetricKey generates a random key of the size required by the specified symmetric algorithm:
+// 16 bytes for AES-128-GCM, 32 bytes for AES-256-GCM, ChaCha20-Poly1305, HMAC-SHA256 and HS256, 48 bytes for HS384,
+// and 64 bytes for HMAC-SHA512 and HS512.
+//
+// The key is checked with validate.SymmetricKey. Keys rejected for low entropy are discarded and generated again up to 8 times,
+// so a broken source of randomness results in an error instead of a weak key.
+func SymmetricKey(randomness io.Reader, algorithm SymmetricAlgorithm) ([]byte, error) {
+	size, err := algorithm.KeySize()
+	if err != nil {
+		return nil, err
+	}
+
+	key := make([]byte, size)
+
+	for range maxSymmetricKeyAttempts {
+		if _, err := io.ReadFull(randomness, key); err != nil {
+			return nil, fmt.Errorf("error generating %s key: %w", algorithm, err)
+		}
+
+		err = validate.SymmetricKey(key, algorithm)
+		if err == nil {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("randomness generated an invalid %s key %d times: %w", algorithm, maxSymmetricKeyAttempts, err)
+}
+
+// DeriveSymmetricKey derives a subkey for the specified symmetric algorithm from a master key with HKDF (RFC 5869).
+// HKDF uses SHA-512 for HMAC-SHA512, HS384 and HS512 subkeys and SHA-256 otherwise. The salt is optional, and info
+// binds the subkey to its purpose, so different info strings derive independent subkeys from the same master key.
+// The master key must be at least 16 bytes long.
+func DeriveSymmetricKey(masterKey, salt []byte, info string, algorithm SymmetricAlgorithm) ([]byte, error) {
+	size, err := algorithm.KeySize()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(masterKey) < minMasterKeyLength {
+		return nil, fmt.Errorf("master key of %d bytes, expected at least %d bytes", len(masterKey), minMasterKeyLength)
+	}
+
+	var h func() hash.Hash = sha256.New
+	switch algorithm {
+	case SymmetricAlgorithmHMACSHA512, SymmetricAlgorithmHS384, SymmetricAlgorithmHS512:
+		h = sha512.New
+	}
+
+	key, err := hkdf.Key(h, masterKey, salt, info, size)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving %s key: %w", algorithm, err)
+	}
+
+	return key, nil
+}
+
+// NonceGenerator generates nonces for an AEAD algorithm. It is safe for concurrent use.
+type NonceGenerator struct {
+	mu         sync.Mutex
+	randomness io.Reader
+	strategy   NonceStrategy
+	size       int
+	fixed      []byte
+	count      uint64
+}
+
+// NewNonceGenerator returns a NonceGenerator for nonces of the specified AEAD algorithm with the specified strategy.
+// The fixed field of the counter strategy is read from randomness. HMAC algorithms have no nonce and are not supported.
+func NewNonceGenerator(randomness io.Reader, algorithm SymmetricAlgorithm, strategy NonceStrategy) (*NonceGenerator, error) {
+	size, err := algorithm.NonceSize()
+	if err != nil {
+		return nil, err
+	}
+
+	generator := &NonceGenerator{randomness: randomness, strategy: strategy, size: size}
+
+	switch strategy {
+	case NonceStrategyRandom:
+	case NonceStrategyCounter:
+		generator.fixed = make([]byte, nonceFixedLength)
+		if _, err := io.ReadFull(randomness, generator.fixed); err != nil {
+			return nil, fmt.Errorf("error generating fixed field of nonces: %w", err)
+		}
+	default:
+		return nil, errors.New("unsupported nonce strategy")
+	}
+
+	return generator, nil
+}
+
+// Next returns the next nonce. It returns an error when the generator has produced as many nonces as its strategy
+// allows for a single key, after which the key must be replaced.
+func (g *NonceGenerator) Next() ([]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	nonce := make([]byte, g.size)
+
+	switch g.strategy {
+	case NonceStrategyRandom:
+		if g.count == maxRandomNonces {
+			return nil, fmt.Errorf("limit of %d random nonces per key reached", maxRandomNonces)
+		}
+		if _, err := io.ReadFull(g.randomness, nonce); err != nil {
+			return nil, fmt.Errorf("error generating random nonce: %w", err)
+		}
+	case NonceStrategyCounter:
+		if g.count == ^uint64(0) {
+			return nil, errors.New("nonce counter exhausted")
+		}
+		copy(nonce, g.fixed)
+		binary.BigEndian.PutUint64(nonce[nonceFixedLength:], g.count)
+	}
+
+	g.count++
+
+	return nonce, nil
+}
@@ -0,0 +1,125 @@
+package random_test
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzSymmetricKey(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a uint, info string) {
+		algorithm := random.SymmetricAlgorithm(int(a % 8))
+
+		key, err := random.SymmetricKey(rand.Reader, algorithm)
+		if err != nil {
+			t.Fatalf("error generating a random %s key: %v", algorithm, err)
+		}
+
+		if err := validate.SymmetricKey(key, algorithm); err != nil {
+			t.Fatalf("invalid %s key: %v", algorithm, err)
+		}
+
+		subkey1, err := random.DeriveSymmetricKey(key, nil, info, algorithm)
+		if err != nil {
+			t.Fatalf("error deriving %s subkey: %v", algorithm, err)
+		}
+
+		if err := validate.SymmetricKey(subkey1, algorithm); err != nil {
+			t.Fatalf("invalid %s subkey: %v", algorithm, err)
+		}
+
+		subkey2, err := random.DeriveSymmetricKey(key, nil, info, algorithm)
+		if err != nil {
+			t.Fatalf("error deriving %s subkey: %v", algorithm, err)
+		}
+
+		if !bytes.Equal(subkey1, subkey2) {
+			t.Fatal("subkeys derived with the same info differ")
+		}
+
+		subkey3, err := random.DeriveSymmetricKey(key, nil, info+"other", algorithm)
+		if err != nil {
+			t.Fatalf("error deriving %s subkey: %v", algorithm, err)
+		}
+
+		if bytes.Equal(subkey1, subkey3) {
+			t.Fatal("subkeys derived with different info are equal")
+		}
+	})
+}
+
+func TestSymmetricKeyRedrawsRejectedKeys(t *testing.T) {
+	t.Parallel()
+
+	randomness := io.MultiReader(bytes.NewReader(make([]byte, 32)), rand.Reader)
+
+	key, err := random.SymmetricKey(randomness, random.SymmetricAlgorithmAES256GCM)
+	if err != nil {
+		t.Fatalf("expected no error after a rejected key, but got error: %v", err)
+	}
+
+	if bytes.Equal(key, make([]byte, 32)) {
+		t.Fatal("expected the rejected all-zero key to be generated again")
+	}
+
+	if _, err := random.SymmetricKey(bytes.NewReader(make([]byte, 1024)), random.SymmetricAlgorithmAES256GCM); err == nil {
+		t.Fatal("expected error for randomness generating only all-zero keys, but got nil")
+	}
+}
+
+func TestNonceGenerator(t *testing.T) {
+	t.Parallel()
+
+	for _, strategy := range []random.NonceStrategy{random.NonceStrategyRandom, random.NonceStrategyCounter} {
+		t.Run(strategy.String(), func(t *testing.T) {
+			t.Parallel()
+
+			key, err := random.SymmetricKey(rand.Reader, random.SymmetricAlgorithmAES256GCM)
+			if err != nil {
+				t.Fatalf("error generating key: %v", err)
+			}
+
+			generator, err := random.NewNonceGenerator(rand.Reader, random.SymmetricAlgorithmAES256GCM, strategy)
+			if err != nil {
+				t.Fatalf("error creating nonce generator: %v", err)
+			}
+
+			block, _ := aes.NewCipher(key)
+			aead, _ := cipher.NewGCM(block)
+
+			seen := make(map[string]struct{})
+
+			for i := 0; i < 1000; i++ {
+				nonce, err := generator.Next()
+				if err != nil {
+					t.Fatalf("error generating nonce: %v", err)
+				}
+
+				if len(nonce) != aead.NonceSize() {
+					t.Fatalf("expected nonce of %d bytes, but got %d", aead.NonceSize(), len(nonce))
+				}
+
+				if _, ok := seen[string(nonce)]; ok {
+					t.Fatalf("nonce %x repeated", nonce)
+				}
+				seen[string(nonce)] = struct{}{}
+
+				aead.Seal(nil, nonce, []byte("plaintext"), nil)
+			}
+		})
+	}
+}
+
+func TestNewNonceGeneratorFailsForHMAC(t *testing.T) {
+	t.Parallel()
+
+	if _, err := random.NewNonceGenerator(rand.Reader, random.SymmetricAlgorithmHS256, random.NonceStrategyRandom); err == nil {
+		t.Fatal("expected error for HMAC algorithm, but got nil")
+	}
+}
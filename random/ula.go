@@ -0,0 +1,176 @@
+package random
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"time"
+
+	"github.com/copartner6412/input/validate"
+)
+
+const (
+	ulaPrefixLength       int = 48
+	ulaSubnetPrefixLength int = 64
+	ulaGlobalIDLength     int = 5
+
+	minStablePrivacyKeyLength int = 16
+
+	// Seconds between the NTP epoch (1900) and the Unix epoch (1970).
+	ntpEpochOffset int64 = 2208988800
+)
+
+// ULAPrefix generates a unique local IPv6 /48 prefix in fd00::/8 with a random 40-bit Global ID (RFC 4193, section 3.2).
+func ULAPrefix(randomness io.Reader) (netip.Prefix, error) {
+	for {
+		var ip [16]byte
+		ip[0] = 0xfd
+		if _, err := io.ReadFull(randomness, ip[1:1+ulaGlobalIDLength]); err != nil {
+			return netip.Prefix{}, fmt.Errorf("error generating Global ID: %w", err)
+		}
+
+		prefix := netip.PrefixFrom(netip.AddrFrom16(ip), ulaPrefixLength)
+		if validate.ULA(prefix) == nil {
+			return prefix, nil
+		}
+	}
+}
+
+// DeriveULAPrefix derives a unique local IPv6 /48 prefix with the algorithm suggested in RFC 4193, section 3.2.2:
+// the Global ID is the least significant 40 bits of the SHA-1 digest of the time in NTP format followed by the
+// EUI-64 identifier of the MAC address.
+func DeriveULAPrefix(t time.Time, mac net.HardwareAddr) (netip.Prefix, error) {
+	eui64, err := eui64(mac)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	var key [16]byte
+	binary.BigEndian.PutUint32(key[:4], uint32(t.Unix()+ntpEpochOffset))
+	binary.BigEndian.PutUint32(key[4:8], uint32((uint64(t.Nanosecond())<<32)/uint64(time.Second)))
+	copy(key[8:], eui64[:])
+
+	digest := sha1.Sum(key[:])
+
+	var ip [16]byte
+	ip[0] = 0xfd
+	copy(ip[1:1+ulaGlobalIDLength], digest[len(digest)-ulaGlobalIDLength:])
+
+	prefix := netip.PrefixFrom(netip.AddrFrom16(ip), ulaPrefixLength)
+	if err := validate.ULA(prefix); err != nil {
+		return netip.Prefix{}, fmt.Errorf("derived an invalid ULA prefix: %w", err)
+	}
+
+	return prefix, nil
+}
+
+// ULASubnet generates a random /64 subnet of the unique local IPv6 prefix, i.e. a random 16-bit Subnet ID for a /48 prefix.
+func ULASubnet(randomness io.Reader, prefix netip.Prefix) (netip.Prefix, error) {
+	if err := validate.ULA(prefix); err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid ULA prefix: %w", err)
+	}
+
+	if prefix.Bits() > ulaSubnetPrefixLength {
+		return netip.Prefix{}, fmt.Errorf("ULA prefix %s longer than /%d", prefix, ulaSubnetPrefixLength)
+	}
+
+	addr, err := prefixAddr(randomness, prefix)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	return netip.PrefixFrom(addr, ulaSubnetPrefixLength).Masked(), nil
+}
+
+// EUI64Addr returns the address in the /64 subnet with the modified EUI-64 interface identifier of the MAC address (RFC 4291, appendix A).
+// A 48-bit MAC address is extended with FF:FE in the middle, and the universal/local bit is inverted.
+func EUI64Addr(subnet netip.Prefix, mac net.HardwareAddr) (netip.Addr, error) {
+	if err := checkSubnet64(subnet); err != nil {
+		return netip.Addr{}, err
+	}
+
+	eui64, err := eui64(mac)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	ip := subnet.Addr().As16()
+	copy(ip[8:], eui64[:])
+
+	return netip.AddrFrom16(ip), nil
+}
+
+// StablePrivacyAddr returns the address in the /64 subnet with the semantically opaque interface identifier of RFC 7217,
+// which is stable for a subnet, interface and network but doesn't reveal the hardware address.
+//
+// The identifier is the least significant 64 bits of SHA-256(Prefix | Net_Iface | Network_ID | DAD_Counter | secret_key).
+// The network ID, such as the SSID of a wireless network, is optional, and the secret key must be at least 16 bytes long.
+// The DAD counter starts at 0 and is incremented after an address conflict. It is also incremented while the identifier
+// is reserved (RFC 5453), so the returned counter is the one to increment after a conflict.
+func StablePrivacyAddr(subnet netip.Prefix, netIface string, networkID []byte, dadCounter uint8, secretKey []byte) (netip.Addr, uint8, error) {
+	if err := checkSubnet64(subnet); err != nil {
+		return netip.Addr{}, 0, err
+	}
+
+	if len(secretKey) < minStablePrivacyKeyLength {
+		return netip.Addr{}, 0, fmt.Errorf("secret key of %d bytes, expected at least %d bytes", len(secretKey), minStablePrivacyKeyLength)
+	}
+
+	ip := subnet.Addr().As16()
+
+	for {
+		h := sha256.New()
+		h.Write(ip[:8])
+		h.Write([]byte(netIface))
+		h.Write(networkID)
+		h.Write([]byte{dadCounter})
+		h.Write(secretKey)
+		rid := h.Sum(nil)
+
+		copy(ip[8:], rid[len(rid)-8:])
+		addr := netip.AddrFrom16(ip)
+
+		if validate.InterfaceIdentifier(addr) == nil {
+			return addr, dadCounter, nil
+		}
+
+		if dadCounter == ^uint8(0) {
+			return netip.Addr{}, 0, errors.New("DAD counter exhausted")
+		}
+		dadCounter++
+	}
+}
+
+// checkSubnet64 returns an error if the prefix is not an IPv6 /64 subnet.
+func checkSubnet64(subnet netip.Prefix) error {
+	if !subnet.IsValid() || !subnet.Addr().Is6() || subnet.Addr().Is4In6() || subnet.Bits() != ulaSubnetPrefixLength {
+		return fmt.Errorf("prefix %s is not an IPv6 /%d subnet", subnet, ulaSubnetPrefixLength)
+	}
+
+	return nil
+}
+
+// eui64 returns the modified EUI-64 identifier of a 48-bit or 64-bit MAC address.
+func eui64(mac net.HardwareAddr) ([8]byte, error) {
+	var id [8]byte
+
+	switch len(mac) {
+	case 6:
+		copy(id[:3], mac[:3])
+		id[3], id[4] = 0xff, 0xfe
+		copy(id[5:], mac[3:])
+	case 8:
+		copy(id[:], mac)
+	default:
+		return id, fmt.Errorf("MAC address of %d bytes, expected 6 or 8 bytes", len(mac))
+	}
+
+	id[0] ^= 0x02
+
+	return id, nil
+}
@@ -0,0 +1,121 @@
+package random_test
+
+import (
+	"crypto/rand"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzULA(f *testing.F) {
+	f.Fuzz(func(t *testing.T, iface string, networkID []byte) {
+		prefix, err := random.ULAPrefix(rand.Reader)
+		if err != nil {
+			t.Fatalf("error generating a random ULA prefix: %v", err)
+		}
+
+		if err := validate.ULA(prefix); err != nil {
+			t.Fatalf("unexpected error for the random ULA prefix %s: %v", prefix, err)
+		}
+
+		subnet, err := random.ULASubnet(rand.Reader, prefix)
+		if err != nil {
+			t.Fatalf("error generating a random ULA subnet: %v", err)
+		}
+
+		if err := validate.ULA(subnet); err != nil || subnet.Bits() != 64 {
+			t.Fatalf("unexpected error for the random ULA /64 subnet %s: %v", subnet, err)
+		}
+
+		if err := validate.CIDRWithin(subnet.String(), prefix); err != nil {
+			t.Fatalf("unexpected error for the random ULA subnet %s of %s: %v", subnet, prefix, err)
+		}
+
+		key := make([]byte, 32)
+		rand.Read(key)
+
+		addr, counter, err := random.StablePrivacyAddr(subnet, iface, networkID, 0, key)
+		if err != nil {
+			t.Fatalf("error generating a stable privacy address: %v", err)
+		}
+
+		if err := validate.IPAddr(addr, subnet); err != nil {
+			t.Fatalf("unexpected error for the stable privacy address %s: %v", addr, err)
+		}
+
+		if err := validate.InterfaceIdentifier(addr); err != nil {
+			t.Fatalf("unexpected error for the stable privacy address %s: %v", addr, err)
+		}
+
+		again, _, _ := random.StablePrivacyAddr(subnet, iface, networkID, counter, key)
+		if again != addr {
+			t.Fatalf("stable privacy address not stable: %s and %s", addr, again)
+		}
+
+		next, _, _ := random.StablePrivacyAddr(subnet, iface, networkID, counter+1, key)
+		if next == addr {
+			t.Fatalf("stable privacy address %s not changed by DAD counter", addr)
+		}
+	})
+}
+
+func TestEUI64Addr(t *testing.T) {
+	subnet := netip.MustParsePrefix("fd12:3456:789a:1::/64")
+
+	testCases := map[string]string{
+		"00:11:22:33:44:55":       "fd12:3456:789a:1:211:22ff:fe33:4455",
+		"02:00:5e:10:00:01":       "fd12:3456:789a:1:0:5eff:fe10:1",
+		"00:11:22:33:44:55:66:77": "fd12:3456:789a:1:211:2233:4455:6677",
+	}
+
+	for mac, expected := range testCases {
+		hardwareAddr, _ := net.ParseMAC(mac)
+		addr, err := random.EUI64Addr(subnet, hardwareAddr)
+		if err != nil {
+			t.Fatalf("error generating EUI-64 address of %s: %v", mac, err)
+		}
+
+		if addr != netip.MustParseAddr(expected) {
+			t.Errorf("expected %s for MAC address %s, but got %s", expected, mac, addr)
+		}
+	}
+
+	if _, err := random.EUI64Addr(netip.MustParsePrefix("fd12:3456:789a::/48"), net.HardwareAddr{0, 1, 2, 3, 4, 5}); err == nil {
+		t.Error("expected error for a /48 prefix, but got nil")
+	}
+}
+
+func TestDeriveULAPrefix(t *testing.T) {
+	mac := net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}
+	now := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	prefix1, err := random.DeriveULAPrefix(now, mac)
+	if err != nil {
+		t.Fatalf("error deriving ULA prefix: %v", err)
+	}
+
+	if err := validate.ULA(prefix1); err != nil {
+		t.Fatalf("unexpected error for the derived ULA prefix %s: %v", prefix1, err)
+	}
+
+	prefix2, _ := random.DeriveULAPrefix(now, mac)
+	prefix3, _ := random.DeriveULAPrefix(now.Add(time.Microsecond), mac)
+
+	if prefix1 != prefix2 {
+		t.Errorf("derived ULA prefixes %s and %s differ for the same input", prefix1, prefix2)
+	}
+
+	if prefix1 == prefix3 {
+		t.Errorf("derived ULA prefix %s same for a different time", prefix1)
+	}
+}
+
+func TestStablePrivacyAddrFailsForShortKey(t *testing.T) {
+	if _, _, err := random.StablePrivacyAddr(netip.MustParsePrefix("fd12:3456:789a:1::/64"), "eth0", nil, 0, make([]byte, 15)); err == nil {
+		t.Error("expected error for a 15-byte secret key, but got nil")
+	}
+}
@@ -0,0 +1,250 @@
+package random
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/copartner6412/input/validate"
+)
+
+const (
+	maxURLLengthAllowed      uint = 8000
+	minURLUsernameLength     uint = 4
+	maxURLUsernameLength     uint = 16
+	minURLPasswordLength     uint = 8
+	maxURLPasswordLength     uint = 16
+	maxURLUserinfoLength     uint = maxURLUsernameLength + 1 + 3*maxURLPasswordLength + 1 // 1 for colon, 3 for every percent-encoded character and 1 for @
+	maxURLPortLength         uint = 1 + 5                                                 // 1 for colon
+	maxURLIPv4LiteralLength  uint = 15
+	maxURLIPv6LiteralLength  uint = 2 + 39 // 2 for brackets
+	urlSchemeSeparatorLength uint = 3      // "://"
+)
+
+// URLHost defines the kinds of hosts allowed in URLs.
+type URLHost = validate.URLHost
+
+// List of URL host policies.
+const (
+	URLHostAny    = validate.URLHostAny
+	URLHostDomain = validate.URLHostDomain
+	URLHostPublic = validate.URLHostPublic
+)
+
+var (
+	urlSchemeRunes     = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789+-.")
+	urlUnreservedRunes = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-._~")
+	urlFragmentRunes   = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-._~/?")
+)
+
+// URL generates a cryptographically-secure random absolute URL of the form scheme://[userinfo@]host[:port][/path][?query][#fragment],
+// with a scheme chosen from the specified schemes, or from validate.DefaultURLSchemes if none is specified.
+//
+// The host is a domain name with a valid TLD generated by DomainWithValidTLD or, unless the host policy is URLHostDomain,
+// an IPv4 address or a bracketed IPv6 address, of IPClassGlobal for URLHostPublic. If userinfo is true, the URL has a username
+// and a password generated by Password, percent-encoded as needed, and the minimum length is raised to leave room for them.
+// The port, path, query and fragment are optional and the path, query and fragment fill the URL to its length.
+func URL(randomness io.Reader, minLength, maxLength uint, schemes []string, host URLHost, userinfo bool) (string, error) {
+	if len(schemes) == 0 {
+		schemes = validate.DefaultURLSchemes
+	}
+
+	var longestScheme uint
+	for _, scheme := range schemes {
+		if !isURLScheme(scheme) {
+			return "", fmt.Errorf("invalid URL scheme \"%s\"", scheme)
+		}
+		longestScheme = max(longestScheme, uint(len(scheme)))
+	}
+
+	if host != URLHostAny && host != URLHostDomain && host != URLHostPublic {
+		return "", fmt.Errorf("invalid URL host policy %d", host)
+	}
+
+	minLengthAllowed := longestScheme + urlSchemeSeparatorLength + minDomainWithValidTLDLengthAllowed
+	if userinfo {
+		minLengthAllowed += maxURLUserinfoLength
+	}
+
+	length, err := checkLength(randomness, minLength, maxLength, minLengthAllowed, maxURLLengthAllowed)
+	if err != nil {
+		return "", err
+	}
+
+	random1, err := rand.Int(randomness, big.NewInt(int64(len(schemes))))
+	if err != nil {
+		return "", fmt.Errorf("error generating a random index for URL scheme: %w", err)
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.ToLower(schemes[random1.Int64()]))
+	b.WriteString("://")
+
+	if userinfo {
+		username, err := Password(randomness, minURLUsernameLength, maxURLUsernameLength, true, false, true, false)
+		if err != nil {
+			return "", fmt.Errorf("error generating a random URL username: %w", err)
+		}
+		password, err := Password(randomness, minURLPasswordLength, maxURLPasswordLength, true, true, true, true)
+		if err != nil {
+			return "", fmt.Errorf("error generating a random URL password: %w", err)
+		}
+		b.WriteString(url.UserPassword(username, password).String())
+		b.WriteByte('@')
+	}
+
+	remaining := length - uint(b.Len())
+
+	hostname, err := randomURLHost(randomness, remaining, host)
+	if err != nil {
+		return "", err
+	}
+	b.WriteString(hostname)
+	remaining -= uint(len(hostname))
+
+	if remaining >= maxURLPortLength {
+		random2, err := rand.Int(randomness, big.NewInt(2))
+		if err != nil {
+			return "", fmt.Errorf("error generating a random number for chance of URL port: %w", err)
+		}
+		if random2.Int64() == 1 {
+			port, err := PortBetween(randomness, 1, maxPortAllowed)
+			if err != nil {
+				return "", fmt.Errorf("error generating a random URL port: %w", err)
+			}
+			b.WriteByte(':')
+			b.WriteString(strconv.Itoa(int(port)))
+			remaining -= 1 + uint(len(strconv.Itoa(int(port))))
+		}
+	}
+
+	tail, err := randomURLTail(randomness, remaining)
+	if err != nil {
+		return "", err
+	}
+	b.WriteString(tail)
+
+	return b.String(), nil
+}
+
+// isURLScheme reports whether the scheme is a letter followed by letters, digits, "+", "-" and "." according to RFC 3986, Section 3.1.
+func isURLScheme(scheme string) bool {
+	if scheme == "" || !strings.ContainsRune(string(letterRunes), rune(scheme[0])) {
+		return false
+	}
+
+	return strings.Trim(scheme, string(urlSchemeRunes)) == ""
+}
+
+// randomURLHost generates a domain name or an IP literal allowed by the host policy, which is not longer than maxLength.
+func randomURLHost(randomness io.Reader, maxLength uint, policy URLHost) (string, error) {
+	kinds := 1
+	if policy != URLHostDomain {
+		kinds = 3
+	}
+
+	random1, err := rand.Int(randomness, big.NewInt(int64(kinds)))
+	if err != nil {
+		return "", fmt.Errorf("error generating a random number for kind of URL host: %w", err)
+	}
+
+	switch {
+	case random1.Int64() == 1 && maxLength >= maxURLIPv4LiteralLength:
+		var addr netip.Addr
+		if policy == URLHostPublic {
+			addr, err = IPv4AddrOfClass(randomness, IPClassGlobal)
+		} else {
+			addr, err = IPv4Addr(randomness, netip.Prefix{})
+		}
+		if err != nil {
+			return "", fmt.Errorf("error generating a random IPv4 address as URL host: %w", err)
+		}
+		return addr.String(), nil
+	case random1.Int64() == 2 && maxLength >= maxURLIPv6LiteralLength:
+		var addr netip.Addr
+		if policy == URLHostPublic {
+			addr, err = IPv6AddrOfClass(randomness, IPClassGlobal)
+		} else {
+			addr, err = IPv6Addr(randomness, netip.Prefix{})
+		}
+		if err != nil {
+			return "", fmt.Errorf("error generating a random IPv6 address as URL host: %w", err)
+		}
+		return "[" + addr.String() + "]", nil
+	}
+
+	domain, err := DomainWithValidTLD(randomness, minDomainWithValidTLDLengthAllowed, min(maxLength, maxDomainLengthAllowed))
+	if err != nil {
+		return "", fmt.Errorf("error generating a random domain as URL host: %w", err)
+	}
+
+	return domain, nil
+}
+
+// randomURLTail generates a path, a query and a fragment, each of which may be empty, with the total length.
+func randomURLTail(randomness io.Reader, length uint) (string, error) {
+	random1, err := rand.Int(randomness, big.NewInt(int64(length)+1))
+	if err != nil {
+		return "", fmt.Errorf("error generating a random number for URL path length: %w", err)
+	}
+	pathLength := uint(random1.Int64())
+
+	random2, err := rand.Int(randomness, big.NewInt(int64(length-pathLength)+1))
+	if err != nil {
+		return "", fmt.Errorf("error generating a random number for URL query length: %w", err)
+	}
+	queryLength := uint(random2.Int64())
+	fragmentLength := length - pathLength - queryLength
+
+	// An empty query or fragment is not kept in the canonical form of a URL, so its separator is moved to the path.
+	if queryLength == 1 {
+		pathLength, queryLength = pathLength+1, 0
+	}
+	if fragmentLength == 1 {
+		pathLength, fragmentLength = pathLength+1, 0
+	}
+
+	var b strings.Builder
+
+	for _, part := range []struct {
+		length    uint
+		prefix    byte
+		runes     []rune
+		separator string
+	}{
+		{pathLength, '/', urlUnreservedRunes, "/"},
+		{queryLength, '?', urlUnreservedRunes, "=&"},
+		{fragmentLength, '#', urlFragmentRunes, ""},
+	} {
+		if part.length == 0 {
+			continue
+		}
+
+		b.WriteByte(part.prefix)
+
+		// Separators are neither the first character nor adjacent to each other, to avoid empty segments and parameters.
+		previousSeparator := true
+		for range part.length - 1 {
+			runes := part.runes
+			if !previousSeparator && part.separator != "" {
+				runes = append(runes[:len(runes):len(runes)], []rune(part.separator)...)
+			}
+
+			random3, err := rand.Int(randomness, big.NewInt(int64(len(runes))))
+			if err != nil {
+				return "", fmt.Errorf("error generating a random index for a character of URL: %w", err)
+			}
+			character := runes[random3.Int64()]
+			b.WriteRune(character)
+
+			previousSeparator = strings.ContainsRune(part.separator, character)
+		}
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,68 @@
+package random_test
+
+import (
+	"crypto/rand"
+	"net/url"
+	"testing"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzURL(f *testing.F) {
+	f.Fuzz(func(t *testing.T, minLength, maxLength uint, userinfo bool, hostPolicy uint8) {
+		minLength, maxLength = minLength%(8000+1), maxLength%(8000+1)
+		if maxLength < minLength {
+			minLength, maxLength = maxLength, minLength
+		}
+		host := random.URLHost(hostPolicy % 3)
+		schemes := []string{"https", "ftp", "git+ssh"}
+
+		u, err := random.URL(rand.Reader, minLength, maxLength, schemes, host, userinfo)
+		if err != nil {
+			t.Skip(err)
+		}
+
+		err = validate.URL(u, minLength, maxLength, schemes, host)
+		if err != nil {
+			t.Fatalf("expected no error for valid random URL %q, but got error: %v", u, err)
+		}
+
+		parsed, err := url.Parse(u)
+		if err != nil {
+			t.Fatalf("error parsing random URL %q: %v", u, err)
+		}
+		if (parsed.User != nil) != userinfo {
+			t.Fatalf("expected userinfo %t in random URL %q", userinfo, u)
+		}
+		if parsed.String() != u {
+			t.Fatalf("expected random URL %q to be in canonical form, but got %q", u, parsed.String())
+		}
+	})
+}
+
+func TestURLFailsForInvalidInput(t *testing.T) {
+	testCases := map[string]struct {
+		minLength, maxLength uint
+		schemes              []string
+		host                 random.URLHost
+		userinfo             bool
+	}{
+		"Invalid scheme":         {0, 0, []string{"1http"}, random.URLHostAny, false},
+		"Empty scheme":           {0, 0, []string{""}, random.URLHostAny, false},
+		"Invalid host policy":    {0, 0, nil, random.URLHost(3), false},
+		"Too short":              {1, 10, nil, random.URLHostAny, false},
+		"Too short for userinfo": {20, 40, nil, random.URLHostAny, true},
+		"Too long":               {100, 8001, nil, random.URLHostAny, false},
+		"Inverted length range":  {50, 40, nil, random.URLHostAny, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			u, err := random.URL(rand.Reader, testCase.minLength, testCase.maxLength, testCase.schemes, testCase.host, testCase.userinfo)
+			if err == nil {
+				t.Errorf("expected error, but got URL %q", u)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package random
+
+import (
+	"crypto/ecdh"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+const (
+	wireGuardKeySize int = 32
+
+	minWireGuardEndpointLength uint = 8
+	maxWireGuardEndpointLength uint = 63
+)
+
+// WireGuardKeyPair generates an X25519 key pair for WireGuard and returns the base64-encoded private and public keys, like wg genkey and wg pubkey.
+// The private key is clamped as described in RFC 7748.
+func WireGuardKeyPair(randomness io.Reader) (privateKey, publicKey string, err error) {
+	key := make([]byte, wireGuardKeySize)
+	if _, err := io.ReadFull(randomness, key); err != nil {
+		return "", "", fmt.Errorf("error generating WireGuard private key: %w", err)
+	}
+
+	key[0] &= 248
+	key[31] = key[31]&127 | 64
+
+	x25519PrivateKey, err := ecdh.X25519().NewPrivateKey(key)
+	if err != nil {
+		return "", "", fmt.Errorf("error generating WireGuard key pair: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(key), base64.StdEncoding.EncodeToString(x25519PrivateKey.PublicKey().Bytes()), nil
+}
+
+// WireGuardPresharedKey generates a base64-encoded 32-byte WireGuard preshared key, like wg genpsk.
+func WireGuardPresharedKey(randomness io.Reader) (string, error) {
+	key := make([]byte, wireGuardKeySize)
+	if _, err := io.ReadFull(randomness, key); err != nil {
+		return "", fmt.Errorf("error generating WireGuard preshared key: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// wireGuardPeer holds the generated settings of a peer of a WireGuard mesh.
+type wireGuardPeer struct {
+	privateKey string
+	publicKey  string
+	address    net.IP
+	listenPort uint16
+	endpoint   string
+}
+
+// WireGuardConfigs generates wg-quick configuration files for a full mesh of the specified number of peers, in which every peer has a [Peer] section for every other peer.
+//
+// Each peer gets:
+//   - A key pair generated by WireGuardKeyPair.
+//   - A unique address in cidr, which can be an IPv4 or IPv6 network. IPv4 network and broadcast addresses are not used.
+//   - A listen port generated by PortPrivate.
+//   - An endpoint host from endpointHosts, which must have one host per peer, or a random domain if endpointHosts is empty.
+//
+// Every pair of peers shares a preshared key generated by WireGuardPresharedKey, and each peer only allows the address of the other peer.
+func WireGuardConfigs(randomness io.Reader, cidr string, peers uint, endpointHosts []string) ([]string, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR: %w", err)
+	}
+
+	if len(endpointHosts) != 0 && uint(len(endpointHosts)) != peers {
+		return nil, fmt.Errorf("%d endpoint hosts for %d peers", len(endpointHosts), peers)
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	isIPv4 := ipNet.IP.To4() != nil
+
+	// IPv4 networks of 31 and 32 bits have no network and broadcast addresses (RFC 3021).
+	hostBits := bits - ones
+	if hostBits < 64 {
+		available := uint64(1) << hostBits
+		if isIPv4 && hostBits > 1 {
+			available -= 2
+		}
+		if uint64(peers) > available {
+			return nil, fmt.Errorf("network %s has %d addresses for %d peers", ipNet, available, peers)
+		}
+	}
+
+	mesh := make([]wireGuardPeer, 0, peers)
+	used := make(map[string]struct{}, peers)
+
+	for i := uint(0); i < peers; i++ {
+		var peer wireGuardPeer
+
+		peer.privateKey, peer.publicKey, err = WireGuardKeyPair(randomness)
+		if err != nil {
+			return nil, err
+		}
+
+		peer.address, err = wireGuardAddress(randomness, ipNet, used)
+		if err != nil {
+			return nil, err
+		}
+
+		peer.listenPort, err = PortPrivate(randomness)
+		if err != nil {
+			return nil, err
+		}
+
+		host := ""
+		if len(endpointHosts) != 0 {
+			host = endpointHosts[i]
+		} else {
+			host, err = DomainWithValidTLD(randomness, minWireGuardEndpointLength, maxWireGuardEndpointLength)
+			if err != nil {
+				return nil, fmt.Errorf("error generating endpoint host: %w", err)
+			}
+		}
+		peer.endpoint = net.JoinHostPort(host, strconv.Itoa(int(peer.listenPort)))
+
+		mesh = append(mesh, peer)
+	}
+
+	presharedKeys := make(map[[2]uint]string)
+
+	for i := uint(0); i < peers; i++ {
+		for j := i + 1; j < peers; j++ {
+			presharedKeys[[2]uint{i, j}], err = WireGuardPresharedKey(randomness)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	hostPrefix := 32
+	if !isIPv4 {
+		hostPrefix = 128
+	}
+
+	configs := make([]string, 0, peers)
+
+	for i, peer := range mesh {
+		var config strings.Builder
+
+		fmt.Fprintf(&config, "[Interface]\nPrivateKey = %s\nAddress = %s/%d\nListenPort = %d\n", peer.privateKey, peer.address, ones, peer.listenPort)
+
+		for j, other := range mesh {
+			if i == j {
+				continue
+			}
+			pair := [2]uint{uint(min(i, j)), uint(max(i, j))}
+			fmt.Fprintf(&config, "\n[Peer]\nPublicKey = %s\nPresharedKey = %s\nAllowedIPs = %s/%d\nEndpoint = %s\n", other.publicKey, presharedKeys[pair], other.address, hostPrefix, other.endpoint)
+		}
+
+		configs = append(configs, config.String())
+	}
+
+	return configs, nil
+}
+
+// wireGuardAddress generates a random address in ipNet which is not in used, and adds it to used.
+func wireGuardAddress(randomness io.Reader, ipNet *net.IPNet, used map[string]struct{}) (net.IP, error) {
+	ones, bits := ipNet.Mask.Size()
+	isIPv4 := ipNet.IP.To4() != nil
+
+	networkAddr, _ := netip.AddrFromSlice(ipNet.IP)
+	prefix := netip.PrefixFrom(networkAddr.Unmap(), ones)
+
+	for {
+		var addr netip.Addr
+		var err error
+
+		if isIPv4 {
+			addr, err = IPv4Addr(randomness, prefix)
+		} else {
+			addr, err = IPv6Addr(randomness, prefix)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error generating peer address: %w", err)
+		}
+		ip := net.IP(addr.AsSlice())
+
+		if isIPv4 && bits-ones > 1 {
+			ip4 := ip.To4()
+			network, broadcast := true, true
+			for k := range ip4 {
+				network = network && ip4[k]&^ipNet.Mask[k] == 0
+				broadcast = broadcast && ip4[k]|ipNet.Mask[k] == 0xff
+			}
+			if network || broadcast {
+				continue
+			}
+		}
+
+		if _, ok := used[ip.String()]; ok {
+			continue
+		}
+		used[ip.String()] = struct{}{}
+
+		return ip, nil
+	}
+}
@@ -0,0 +1,85 @@
+package random_test
+
+import (
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/copartner6412/input/random"
+	"github.com/copartner6412/input/validate"
+)
+
+func FuzzWireGuardConfigs(f *testing.F) {
+	f.Fuzz(func(t *testing.T, n uint8, ipv6 bool) {
+		peers := uint(n%8) + 1
+
+		cidr := "10.0.0.0/28"
+		if ipv6 {
+			cidr = "fd00:1:2:3::/64"
+		}
+
+		configs, err := random.WireGuardConfigs(rand.Reader, cidr, peers, nil)
+		if err != nil {
+			t.Fatalf("error generating WireGuard configurations for %d peers in %s: %v", peers, cidr, err)
+		}
+
+		if uint(len(configs)) != peers {
+			t.Fatalf("expected %d configurations, but got %d", peers, len(configs))
+		}
+
+		for i, config := range configs {
+			if err := validate.WireGuardConfig([]byte(config)); err != nil {
+				t.Fatalf("invalid WireGuard configuration %d:\n%s\n%v", i, config, err)
+			}
+
+			if sections := strings.Count(config, "[Peer]"); uint(sections) != peers-1 {
+				t.Fatalf("expected %d [Peer] sections, but got %d", peers-1, sections)
+			}
+		}
+	})
+}
+
+func TestWireGuardConfigsFailsForSmallNetwork(t *testing.T) {
+	t.Parallel()
+
+	if _, err := random.WireGuardConfigs(rand.Reader, "10.0.0.0/30", 3, nil); err == nil {
+		t.Fatal("expected error for 3 peers in a network with 2 host addresses, but got nil")
+	}
+}
+
+func TestWireGuardConfigsSuccessfulForDefaultRoute(t *testing.T) {
+	t.Parallel()
+
+	for _, cidr := range []string{"0.0.0.0/0", "::/0"} {
+		configs, err := random.WireGuardConfigs(rand.Reader, cidr, 3, nil)
+		if err != nil {
+			t.Fatalf("expected no error for 3 peers in %s, but got error: %v", cidr, err)
+		}
+
+		for i, config := range configs {
+			if err := validate.WireGuardConfig([]byte(config)); err != nil {
+				t.Fatalf("invalid WireGuard configuration %d for %s:\n%s\n%v", i, cidr, config, err)
+			}
+		}
+	}
+}
+
+func FuzzWireGuardKeyPair(f *testing.F) {
+	f.Fuzz(func(t *testing.T, _ uint8) {
+		privateKey, publicKey, err := random.WireGuardKeyPair(rand.Reader)
+		if err != nil {
+			t.Fatalf("error generating WireGuard key pair: %v", err)
+		}
+
+		presharedKey, err := random.WireGuardPresharedKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("error generating WireGuard preshared key: %v", err)
+		}
+
+		for _, key := range []string{privateKey, publicKey, presharedKey} {
+			if err := validate.WireGuardKey(key); err != nil {
+				t.Fatalf("invalid WireGuard key %s: %v", key, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Algorithm defines the supported key generation algorithms.
+// The signature algorithms (ED25519, ECDSA and RSA) are followed by the key agreement algorithms (X25519, ECDH and ML-KEM).
+// It implements encoding.TextMarshaler and encoding.TextUnmarshaler, so it can be used in JSON and YAML configurations with the names accepted by ParseAlgorithm.
+type Algorithm int
+
+// List of supported algorithms for key generation.
+const (
+	AlgorithmUntyped Algorithm = iota
+	AlgorithmED25519
+	AlgorithmECDSAP521
+	AlgorithmECDSAP384
+	AlgorithmECDSAP256
+	AlgorithmECDSAP224
+	AlgorithmRSA4096
+	AlgorithmRSA2048
+	AlgorithmRSA1024
+	AlgorithmX25519
+	AlgorithmECDHP256
+	AlgorithmECDHP384
+	AlgorithmMLKEM768
+	AlgorithmMLKEM1024
+)
+
+var algorithmString = map[Algorithm]string{
+	AlgorithmUntyped:   "untyped",
+	AlgorithmED25519:   "ED25519",
+	AlgorithmECDSAP521: "ECDSA P521",
+	AlgorithmECDSAP384: "ECDSA P384",
+	AlgorithmECDSAP256: "ECDSA P256",
+	AlgorithmECDSAP224: "ECDSA P224",
+	AlgorithmRSA4096:   "RSA 4096",
+	AlgorithmRSA2048:   "RSA 2048",
+	AlgorithmRSA1024:   "RSA 1024",
+	AlgorithmX25519:    "X25519",
+	AlgorithmECDHP256:  "ECDH P256",
+	AlgorithmECDHP384:  "ECDH P384",
+	AlgorithmMLKEM768:  "ML-KEM 768",
+	AlgorithmMLKEM1024: "ML-KEM 1024",
+}
+
+// algorithmName holds the canonical text representation of each algorithm.
+var algorithmName = map[Algorithm]string{
+	AlgorithmUntyped:   "untyped",
+	AlgorithmED25519:   "ed25519",
+	AlgorithmECDSAP521: "ecdsa-p521",
+	AlgorithmECDSAP384: "ecdsa-p384",
+	AlgorithmECDSAP256: "ecdsa-p256",
+	AlgorithmECDSAP224: "ecdsa-p224",
+	AlgorithmRSA4096:   "rsa-4096",
+	AlgorithmRSA2048:   "rsa-2048",
+	AlgorithmRSA1024:   "rsa-1024",
+	AlgorithmX25519:    "x25519",
+	AlgorithmECDHP256:  "ecdh-p256",
+	AlgorithmECDHP384:  "ecdh-p384",
+	AlgorithmMLKEM768:  "ml-kem-768",
+	AlgorithmMLKEM1024: "ml-kem-1024",
+}
+
+// algorithmOpenSSH holds the OpenSSH public key type of each signature algorithm. OpenSSH has no support for the P-224 curve.
+var algorithmOpenSSH = map[Algorithm]string{
+	AlgorithmUntyped:   "ssh-ed25519",
+	AlgorithmED25519:   "ssh-ed25519",
+	AlgorithmECDSAP521: "ecdsa-sha2-nistp521",
+	AlgorithmECDSAP384: "ecdsa-sha2-nistp384",
+	AlgorithmECDSAP256: "ecdsa-sha2-nistp256",
+	AlgorithmRSA4096:   "ssh-rsa",
+	AlgorithmRSA2048:   "ssh-rsa",
+	AlgorithmRSA1024:   "ssh-rsa",
+}
+
+// sshfpAlgorithm holds the SSHFP algorithm number (RFC 4255, RFC 6594, RFC 7479) of each OpenSSH public key type.
+var sshfpAlgorithm = map[string]int{
+	"ssh-rsa":             1,
+	"ecdsa-sha2-nistp256": 3,
+	"ecdsa-sha2-nistp384": 3,
+	"ecdsa-sha2-nistp521": 3,
+	"ssh-ed25519":         4,
+}
+
+// algorithmJOSE holds the JWS signature algorithm (RFC 7518) of each signature algorithm. JOSE has no support for the P-224 curve.
+var algorithmJOSE = map[Algorithm]string{
+	AlgorithmUntyped:   "EdDSA",
+	AlgorithmED25519:   "EdDSA",
+	AlgorithmECDSAP521: "ES512",
+	AlgorithmECDSAP384: "ES384",
+	AlgorithmECDSAP256: "ES256",
+	AlgorithmRSA4096:   "RS256",
+	AlgorithmRSA2048:   "RS256",
+	AlgorithmRSA1024:   "RS256",
+}
+
+func (a Algorithm) String() string {
+	return algorithmString[a]
+}
+
+// ParseAlgorithm parses the name of an algorithm, such as "ed25519", "ecdsa-p256" or "rsa-4096".
+// The name is case-insensitive and spaces or underscores can be used instead of hyphens, so the output of Algorithm.String is accepted too.
+// An empty name is parsed as AlgorithmUntyped.
+func ParseAlgorithm(name string) (Algorithm, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	normalized = strings.NewReplacer(" ", "-", "_", "-").Replace(normalized)
+
+	if normalized == "" {
+		return AlgorithmUntyped, nil
+	}
+
+	for algorithm, algorithmName := range algorithmName {
+		if normalized == algorithmName {
+			return algorithm, nil
+		}
+	}
+
+	return AlgorithmUntyped, fmt.Errorf("unknown algorithm \"%s\"", name)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (a Algorithm) MarshalText() ([]byte, error) {
+	name, ok := algorithmName[a]
+	if !ok {
+		return nil, fmt.Errorf("unsupported algorithm %d", int(a))
+	}
+
+	return []byte(name), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (a *Algorithm) UnmarshalText(text []byte) error {
+	algorithm, err := ParseAlgorithm(string(text))
+	if err != nil {
+		return err
+	}
+
+	*a = algorithm
+	return nil
+}
+
+// OpenSSH returns the OpenSSH public key type of the algorithm, such as "ssh-ed25519" or "ecdsa-sha2-nistp256".
+func (a Algorithm) OpenSSH() (string, error) {
+	keyType, ok := algorithmOpenSSH[a]
+	if !ok {
+		return "", fmt.Errorf("algorithm %s not supported by OpenSSH", a)
+	}
+
+	return keyType, nil
+}
+
+// SSHFP returns the algorithm number of DNS SSHFP records (RFC 4255, RFC 6594, RFC 7479) for keys of the algorithm, such as 4 for ED25519.
+func (a Algorithm) SSHFP() (int, error) {
+	number, ok := sshfpAlgorithm[algorithmOpenSSH[a]]
+	if !ok {
+		return 0, fmt.Errorf("algorithm %s not supported by SSHFP", a)
+	}
+
+	return number, nil
+}
+
+// JOSE returns the JWS signature algorithm (RFC 7518) for keys of the algorithm, such as "EdDSA" or "ES256".
+// RSA keys map to "RS256", which every JOSE implementation supports.
+func (a Algorithm) JOSE() (string, error) {
+	alg, ok := algorithmJOSE[a]
+	if !ok {
+		return "", fmt.Errorf("algorithm %s not supported by JOSE", a)
+	}
+
+	return alg, nil
+}
@@ -0,0 +1,155 @@
+package validate_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+)
+
+func TestParseAlgorithmSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]validate.Algorithm{
+		"":           validate.AlgorithmUntyped,
+		"untyped":    validate.AlgorithmUntyped,
+		"ed25519":    validate.AlgorithmED25519,
+		"ED25519":    validate.AlgorithmED25519,
+		"ecdsa-p521": validate.AlgorithmECDSAP521,
+		"ecdsa_p384": validate.AlgorithmECDSAP384,
+		"ecdsa-p256": validate.AlgorithmECDSAP256,
+		"ECDSA P224": validate.AlgorithmECDSAP224,
+		"rsa-4096":   validate.AlgorithmRSA4096,
+		" RSA-2048 ": validate.AlgorithmRSA2048,
+		"RSA 1024":   validate.AlgorithmRSA1024,
+	}
+
+	for name, expected := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			algorithm, err := validate.ParseAlgorithm(name)
+			if err != nil {
+				t.Fatalf("expected no error for algorithm name %q, but got error: %v", name, err)
+			}
+			if algorithm != expected {
+				t.Errorf("expected algorithm %s for name %q, but got %s", expected, name, algorithm)
+			}
+		})
+	}
+}
+
+func TestParseAlgorithmFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := []string{"ed448", "ecdsa-p192", "rsa", "rsa-3072", "ecdsa--p256", "p256"}
+
+	for _, name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := validate.ParseAlgorithm(name); err == nil {
+				t.Errorf("expected error for algorithm name %q, but got nil", name)
+			}
+		})
+	}
+}
+
+func TestAlgorithmTextMarshalingRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		Algorithm validate.Algorithm `json:"algorithm"`
+	}
+
+	for _, algorithm := range []validate.Algorithm{
+		validate.AlgorithmUntyped,
+		validate.AlgorithmED25519,
+		validate.AlgorithmECDSAP521,
+		validate.AlgorithmECDSAP384,
+		validate.AlgorithmECDSAP256,
+		validate.AlgorithmECDSAP224,
+		validate.AlgorithmRSA4096,
+		validate.AlgorithmRSA2048,
+		validate.AlgorithmRSA1024,
+		validate.AlgorithmX25519,
+		validate.AlgorithmECDHP256,
+		validate.AlgorithmECDHP384,
+		validate.AlgorithmMLKEM768,
+		validate.AlgorithmMLKEM1024,
+	} {
+		data, err := json.Marshal(config{Algorithm: algorithm})
+		if err != nil {
+			t.Fatalf("error marshaling algorithm %s: %v", algorithm, err)
+		}
+
+		var decoded config
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("error unmarshaling %s: %v", data, err)
+		}
+
+		if decoded.Algorithm != algorithm {
+			t.Errorf("expected algorithm %s after round trip of %s, but got %s", algorithm, data, decoded.Algorithm)
+		}
+	}
+
+	if _, err := json.Marshal(config{Algorithm: validate.Algorithm(-1)}); err == nil {
+		t.Error("expected error marshaling unsupported algorithm, but got nil")
+	}
+
+	var decoded config
+	if err := json.Unmarshal([]byte(`{"algorithm":"dsa"}`), &decoded); err == nil {
+		t.Error("expected error unmarshaling unknown algorithm, but got nil")
+	}
+}
+
+func TestAlgorithmOpenSSHAndJOSE(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[validate.Algorithm]struct {
+		openSSH string
+		jose    string
+		sshfp   int
+	}{
+		validate.AlgorithmED25519:   {"ssh-ed25519", "EdDSA", 4},
+		validate.AlgorithmECDSAP521: {"ecdsa-sha2-nistp521", "ES512", 3},
+		validate.AlgorithmECDSAP384: {"ecdsa-sha2-nistp384", "ES384", 3},
+		validate.AlgorithmECDSAP256: {"ecdsa-sha2-nistp256", "ES256", 3},
+		validate.AlgorithmRSA2048:   {"ssh-rsa", "RS256", 1},
+	}
+
+	for algorithm, tc := range testCases {
+		openSSH, err := algorithm.OpenSSH()
+		if err != nil || openSSH != tc.openSSH {
+			t.Errorf("expected OpenSSH key type %s for %s, but got %q (error: %v)", tc.openSSH, algorithm, openSSH, err)
+		}
+
+		jose, err := algorithm.JOSE()
+		if err != nil || jose != tc.jose {
+			t.Errorf("expected JOSE algorithm %s for %s, but got %q (error: %v)", tc.jose, algorithm, jose, err)
+		}
+
+		sshfp, err := algorithm.SSHFP()
+		if err != nil || sshfp != tc.sshfp {
+			t.Errorf("expected SSHFP algorithm %d for %s, but got %d (error: %v)", tc.sshfp, algorithm, sshfp, err)
+		}
+	}
+
+	if _, err := validate.AlgorithmECDSAP224.OpenSSH(); err == nil {
+		t.Error("expected error for OpenSSH key type of ECDSA P224, but got nil")
+	}
+
+	if _, err := validate.AlgorithmECDSAP224.JOSE(); err == nil {
+		t.Error("expected error for JOSE algorithm of ECDSA P224, but got nil")
+	}
+
+	if _, err := validate.AlgorithmX25519.OpenSSH(); err == nil {
+		t.Error("expected error for OpenSSH key type of X25519, but got nil")
+	}
+
+	if _, err := validate.AlgorithmMLKEM768.JOSE(); err == nil {
+		t.Error("expected error for JOSE algorithm of ML-KEM 768, but got nil")
+	}
+
+	if _, err := validate.AlgorithmECDHP256.SSHFP(); err == nil {
+		t.Error("expected error for SSHFP algorithm of ECDH P256, but got nil")
+	}
+}
@@ -0,0 +1,119 @@
+package validate
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CertificateChain validates a certificate chain ordered from the leaf to the self-signed root at the specified time.
+// It checks that every certificate is signed by the next one, that every issuer is a CA, and that the chain verifies
+// with the root as the only trusted certificate. If at is zero, the current time is used.
+func CertificateChain(chain []*x509.Certificate, at time.Time) error {
+	if len(chain) == 0 {
+		return errors.New("empty certificate chain")
+	}
+
+	for i, certificate := range chain {
+		if certificate == nil {
+			return fmt.Errorf("nil certificate at position %d of chain", i)
+		}
+	}
+
+	root := chain[len(chain)-1]
+
+	if err := root.CheckSignatureFrom(root); err != nil {
+		return fmt.Errorf("root certificate \"%s\" is not self-signed: %w", root.Subject, err)
+	}
+
+	var errs []error
+
+	for i := 0; i < len(chain)-1; i++ {
+		certificate, issuer := chain[i], chain[i+1]
+
+		if !issuer.IsCA {
+			errs = append(errs, fmt.Errorf("issuer certificate \"%s\" is not a CA", issuer.Subject))
+			continue
+		}
+
+		if err := certificate.CheckSignatureFrom(issuer); err != nil {
+			errs = append(errs, fmt.Errorf("certificate \"%s\" is not signed by \"%s\": %w", certificate.Subject, issuer.Subject, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+
+	intermediates := x509.NewCertPool()
+	if len(chain) > 2 {
+		for _, intermediate := range chain[1 : len(chain)-1] {
+			intermediates.AddCert(intermediate)
+		}
+	}
+
+	_, err := chain[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   at,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("error verifying certificate chain: %w", err)
+	}
+
+	return nil
+}
+
+// CertificateSANs validates the subject alternative names of a certificate.
+// DNS names are checked with Domain, where a leading wildcard label ("*.") is allowed, IP addresses with IP and email addresses with Email.
+// A certificate that is not a CA must have at least one subject alternative name.
+func CertificateSANs(certificate *x509.Certificate) error {
+	if certificate == nil {
+		return errors.New("nil certificate")
+	}
+
+	if !certificate.IsCA && len(certificate.DNSNames)+len(certificate.IPAddresses)+len(certificate.EmailAddresses)+len(certificate.URIs) == 0 {
+		return errors.New("no subject alternative names in certificate")
+	}
+
+	var errs []error
+
+	for _, dnsName := range certificate.DNSNames {
+		if err := Domain(strings.TrimPrefix(dnsName, "*."), 0, 0); err != nil {
+			errs = append(errs, fmt.Errorf("invalid DNS name \"%s\": %w", dnsName, err))
+		}
+	}
+
+	for _, ip := range certificate.IPAddresses {
+		if len(ip) != 4 && len(ip) != 16 {
+			errs = append(errs, fmt.Errorf("invalid IP address length of %d bytes", len(ip)))
+			continue
+		}
+
+		if err := IP(ip.String(), ""); err != nil {
+			errs = append(errs, fmt.Errorf("invalid IP address: %w", err))
+		}
+	}
+
+	for _, email := range certificate.EmailAddresses {
+		if err := Email(email, 0, 0, false, false); err != nil {
+			errs = append(errs, fmt.Errorf("invalid email address \"%s\": %w", email, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return nil
+}
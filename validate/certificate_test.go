@@ -0,0 +1,171 @@
+package validate_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/copartner6412/input/validate"
+)
+
+func createCertificate(t *testing.T, template, parent *x509.Certificate, parentKey, key *ecdsa.PrivateKey) *x509.Certificate {
+	t.Helper()
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("error creating certificate: %v", err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("error parsing certificate: %v", err)
+	}
+
+	return certificate
+}
+
+func testChain(t *testing.T, now time.Time) (root, intermediate, leaf, otherRoot, notCA *x509.Certificate) {
+	t.Helper()
+
+	keys := make([]*ecdsa.PrivateKey, 5)
+	for i := range keys {
+		keys[i], _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	}
+
+	caTemplate := func(serial int64, name string) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber:          big.NewInt(serial),
+			Subject:               pkix.Name{CommonName: name},
+			NotBefore:             now.Add(-time.Hour),
+			NotAfter:              now.Add(24 * time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}
+	}
+
+	leafTemplate := &x509.Certificate{
+		SerialNumber:   big.NewInt(3),
+		Subject:        pkix.Name{CommonName: "example.com"},
+		NotBefore:      now.Add(-time.Hour),
+		NotAfter:       now.Add(time.Hour),
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:       []string{"example.com", "*.example.com"},
+		IPAddresses:    []net.IP{net.ParseIP("192.0.2.1"), net.ParseIP("2001:db8::1")},
+		EmailAddresses: []string{"admin@example.com"},
+	}
+
+	notCATemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(5),
+		Subject:      pkix.Name{CommonName: "not a CA"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+		DNSNames:     []string{"example.org"},
+	}
+
+	root = createCertificate(t, caTemplate(1, "Root CA"), nil, nil, keys[0])
+	intermediate = createCertificate(t, caTemplate(2, "Intermediate CA"), root, keys[0], keys[1])
+	leaf = createCertificate(t, leafTemplate, intermediate, keys[1], keys[2])
+	otherRoot = createCertificate(t, caTemplate(4, "Other Root CA"), nil, nil, keys[3])
+	notCA = createCertificate(t, notCATemplate, root, keys[0], keys[4])
+
+	return root, intermediate, leaf, otherRoot, notCA
+}
+
+func TestCertificateChainSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	root, intermediate, leaf, _, _ := testChain(t, now)
+
+	testCases := map[string][]*x509.Certificate{
+		"Root only":                   {root},
+		"Intermediate and root":       {intermediate, root},
+		"Leaf, intermediate and root": {leaf, intermediate, root},
+	}
+
+	for name, chain := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.CertificateChain(chain, now); err != nil {
+				t.Errorf("expected no error for valid chain, but got error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCertificateChainFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	root, intermediate, leaf, otherRoot, notCA := testChain(t, now)
+
+	testCases := map[string]struct {
+		chain []*x509.Certificate
+		at    time.Time
+	}{
+		"Empty chain":          {nil, now},
+		"Nil certificate":      {[]*x509.Certificate{leaf, nil, root}, now},
+		"Missing intermediate": {[]*x509.Certificate{leaf, root}, now},
+		"Wrong root":           {[]*x509.Certificate{leaf, intermediate, otherRoot}, now},
+		"Reversed order":       {[]*x509.Certificate{root, intermediate, leaf}, now},
+		"Issuer not a CA":      {[]*x509.Certificate{leaf, notCA, root}, now},
+		"Root not self-signed": {[]*x509.Certificate{leaf, intermediate}, now},
+		"Expired leaf":         {[]*x509.Certificate{leaf, intermediate, root}, now.Add(2 * time.Hour)},
+		"Not yet valid":        {[]*x509.Certificate{leaf, intermediate, root}, now.Add(-2 * time.Hour)},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.CertificateChain(tc.chain, tc.at); err == nil {
+				t.Errorf("expected error for invalid chain %q, but got nil", name)
+			}
+		})
+	}
+}
+
+func TestCertificateSANsSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	root, _, leaf, _, _ := testChain(t, time.Now())
+
+	for name, certificate := range map[string]*x509.Certificate{"Leaf": leaf, "CA without SANs": root} {
+		if err := validate.CertificateSANs(certificate); err != nil {
+			t.Errorf("expected no error for %s, but got error: %v", name, err)
+		}
+	}
+}
+
+func TestCertificateSANsFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]*x509.Certificate{
+		"Nil certificate":       nil,
+		"No SANs":               {},
+		"Invalid DNS name":      {DNSNames: []string{"-example.com"}},
+		"Double wildcard":       {DNSNames: []string{"*.*.example.com"}},
+		"Invalid IP length":     {IPAddresses: []net.IP{{192, 0, 2}}},
+		"Invalid email address": {EmailAddresses: []string{"admin@@example.com"}},
+	}
+
+	for name, certificate := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.CertificateSANs(certificate); err == nil {
+				t.Errorf("expected error for %q, but got nil", name)
+			}
+		})
+	}
+}
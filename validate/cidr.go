@@ -0,0 +1,107 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+)
+
+// IPFamily defines the address families of IP addresses and networks.
+type IPFamily int
+
+// List of address families.
+const (
+	IPFamilyAny IPFamily = iota
+	IPFamilyIPv4
+	IPFamilyIPv6
+)
+
+var ipFamilyString = map[IPFamily]string{
+	IPFamilyAny:  "any",
+	IPFamilyIPv4: "IPv4",
+	IPFamilyIPv6: "IPv6",
+}
+
+func (f IPFamily) String() string {
+	return ipFamilyString[f]
+}
+
+// CIDR validates if the provided string is an IP network in CIDR notation.
+//
+// The CIDR must:
+//   - Be of the specified address family. IPv4-mapped IPv6 networks such as "::ffff:10.0.0.0/104" are IPv6 networks.
+//   - Have a prefix length between minPrefix and maxPrefix. If both are 0, every prefix length of the family is accepted.
+//   - Be in canonical form, i.e. have all host bits zero, so "10.0.0.1/8" is rejected in favour of "10.0.0.0/8".
+func CIDR(cidr string, family IPFamily, minPrefix, maxPrefix uint) error {
+	prefix, err := parseCanonicalCIDR(cidr)
+	if err != nil {
+		return err
+	}
+
+	switch family {
+	case IPFamilyAny:
+	case IPFamilyIPv4:
+		if !prefix.Addr().Is4() {
+			return fmt.Errorf("CIDR %s is not an IPv4 network", cidr)
+		}
+	case IPFamilyIPv6:
+		if !prefix.Addr().Is6() {
+			return fmt.Errorf("CIDR %s is not an IPv6 network", cidr)
+		}
+	default:
+		return errors.New("unsupported address family")
+	}
+
+	bitLen := uint(prefix.Addr().BitLen())
+
+	if minPrefix == 0 && maxPrefix == 0 {
+		maxPrefix = bitLen
+	} else {
+		if maxPrefix < minPrefix {
+			return errors.New("maximum prefix length can not be less than minimum prefix length")
+		}
+
+		if maxPrefix > bitLen {
+			return fmt.Errorf("maximum prefix length must not exceed %d for %s", bitLen, cidr)
+		}
+	}
+
+	if bits := uint(prefix.Bits()); bits < minPrefix || bits > maxPrefix {
+		return fmt.Errorf("prefix length of %d outside of range [%d, %d]", bits, minPrefix, maxPrefix)
+	}
+
+	return nil
+}
+
+// CIDRWithin validates if the provided string is an IP network in canonical CIDR notation which is contained in the parent prefix,
+// i.e. is of the same address family, has a prefix length at least as long as the parent and has the same network bits.
+func CIDRWithin(cidr string, parent netip.Prefix) error {
+	prefix, err := parseCanonicalCIDR(cidr)
+	if err != nil {
+		return err
+	}
+
+	if !parent.IsValid() {
+		return errors.New("invalid parent prefix")
+	}
+
+	if prefix.Bits() < parent.Bits() || !parent.Contains(prefix.Addr()) {
+		return fmt.Errorf("CIDR %s is not within %s", cidr, parent.Masked())
+	}
+
+	return nil
+}
+
+// parseCanonicalCIDR parses a CIDR and returns an error if it has host bits set.
+func parseCanonicalCIDR(cidr string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid CIDR notation: %w", err)
+	}
+
+	if masked := prefix.Masked(); masked != prefix {
+		return netip.Prefix{}, fmt.Errorf("CIDR %s has host bits set, expected %s", cidr, masked)
+	}
+
+	return prefix, nil
+}
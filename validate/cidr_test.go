@@ -0,0 +1,124 @@
+package validate_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+)
+
+func TestCIDRSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		cidr      string
+		family    validate.IPFamily
+		minPrefix uint
+		maxPrefix uint
+	}{
+		"IPv4 of any family":     {"10.0.0.0/8", validate.IPFamilyAny, 0, 0},
+		"IPv6 of any family":     {"2001:db8::/32", validate.IPFamilyAny, 0, 0},
+		"IPv4":                   {"192.168.1.0/24", validate.IPFamilyIPv4, 0, 0},
+		"IPv6":                   {"fd00::/8", validate.IPFamilyIPv6, 0, 0},
+		"IPv4-mapped IPv6":       {"::ffff:10.0.0.0/104", validate.IPFamilyIPv6, 0, 0},
+		"Default route":          {"0.0.0.0/0", validate.IPFamilyIPv4, 0, 0},
+		"Host route":             {"2001:db8::1/128", validate.IPFamilyIPv6, 0, 0},
+		"Prefix length in range": {"172.16.0.0/16", validate.IPFamilyIPv4, 16, 24},
+		"Minimum prefix length":  {"2001:db8::/48", validate.IPFamilyIPv6, 48, 64},
+		"Maximum prefix length":  {"2001:db8::/64", validate.IPFamilyIPv6, 48, 64},
+		"Exact prefix length":    {"10.1.2.0/24", validate.IPFamilyIPv4, 24, 24},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.CIDR(tc.cidr, tc.family, tc.minPrefix, tc.maxPrefix); err != nil {
+				t.Errorf("expected no error for valid CIDR %s, but got error: %v", tc.cidr, err)
+			}
+		})
+	}
+}
+
+func TestCIDRFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		cidr      string
+		family    validate.IPFamily
+		minPrefix uint
+		maxPrefix uint
+	}{
+		"Empty":                            {"", validate.IPFamilyAny, 0, 0},
+		"Address without length":           {"10.0.0.0", validate.IPFamilyAny, 0, 0},
+		"Prefix length too long":           {"10.0.0.0/33", validate.IPFamilyAny, 0, 0},
+		"Host bits set":                    {"10.0.0.1/8", validate.IPFamilyAny, 0, 0},
+		"IPv6 host bits set":               {"2001:db8::1/32", validate.IPFamilyAny, 0, 0},
+		"IPv6 for IPv4":                    {"2001:db8::/32", validate.IPFamilyIPv4, 0, 0},
+		"IPv4 for IPv6":                    {"10.0.0.0/8", validate.IPFamilyIPv6, 0, 0},
+		"IPv4-mapped IPv6 for IPv4":        {"::ffff:10.0.0.0/104", validate.IPFamilyIPv4, 0, 0},
+		"Unsupported family":               {"10.0.0.0/8", validate.IPFamily(3), 0, 0},
+		"Prefix length too short":          {"10.0.0.0/8", validate.IPFamilyIPv4, 16, 24},
+		"Prefix length too long for range": {"10.0.0.0/28", validate.IPFamilyIPv4, 16, 24},
+		"Inverted range":                   {"10.0.0.0/16", validate.IPFamilyIPv4, 24, 16},
+		"Maximum beyond family":            {"10.0.0.0/16", validate.IPFamilyIPv4, 16, 64},
+		"Zone":                             {"fe80::%eth0/64", validate.IPFamilyIPv6, 0, 0},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.CIDR(tc.cidr, tc.family, tc.minPrefix, tc.maxPrefix); err == nil {
+				t.Errorf("expected error for invalid CIDR %q, but got nil", tc.cidr)
+			}
+		})
+	}
+}
+
+func TestCIDRWithinSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		cidr   string
+		parent string
+	}{
+		"Subnet":          {"10.1.0.0/16", "10.0.0.0/8"},
+		"Same network":    {"10.0.0.0/8", "10.0.0.0/8"},
+		"Unmasked parent": {"10.1.2.0/24", "10.1.200.7/16"},
+		"IPv6 subnet":     {"2001:db8:1::/48", "2001:db8::/32"},
+		"Host route":      {"192.168.1.1/32", "192.168.0.0/16"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.CIDRWithin(tc.cidr, netip.MustParsePrefix(tc.parent)); err != nil {
+				t.Errorf("expected no error for %s within %s, but got error: %v", tc.cidr, tc.parent, err)
+			}
+		})
+	}
+}
+
+func TestCIDRWithinFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		cidr   string
+		parent netip.Prefix
+	}{
+		"Outside parent":     {"11.0.0.0/16", netip.MustParsePrefix("10.0.0.0/8")},
+		"Supernet of parent": {"10.0.0.0/7", netip.MustParsePrefix("10.0.0.0/8")},
+		"Different family":   {"::/0", netip.MustParsePrefix("0.0.0.0/0")},
+		"Host bits set":      {"10.1.0.1/16", netip.MustParsePrefix("10.0.0.0/8")},
+		"Invalid CIDR":       {"10.1.0.0", netip.MustParsePrefix("10.0.0.0/8")},
+		"Zero parent":        {"10.1.0.0/16", netip.Prefix{}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.CIDRWithin(tc.cidr, tc.parent); err == nil {
+				t.Errorf("expected error for %s within %s, but got nil", tc.cidr, tc.parent)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ const (
 //  2. The total length of the domain is within the allowed limit.
 //  3. Each subdomain (label) is valid.
 //  4. The label "www" is only used as the first label.
+//  5. Names in the special-use ".onion" top-level domain (RFC 7686) are valid Tor v3 onion addresses according to OnionAddress.
 //
 // https://man7.org/linux/man-pages/man7/hostname.7.html
 func Domain(domain string, minLength, maxLength uint) error {
@@ -28,6 +29,11 @@ func Domain(domain string, minLength, maxLength uint) error {
 		return err
 	}
 
+	// Onion addresses are not DNS names, so they are only valid if they encode an onion service key.
+	if i := strings.LastIndexByte(domain, '.'); i >= 0 && strings.EqualFold(domain[i+1:], onionTLD) {
+		return OnionAddress(domain)
+	}
+
 	// Split the domain into subdomains.
 	subdomains := strings.Split(domain, ".")
 
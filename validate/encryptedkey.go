@@ -0,0 +1,323 @@
+package validate
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"math/bits"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/ssh"
+)
+
+// Minimum key derivation function parameters accepted for passphrase-encrypted private keys.
+const (
+	minBcryptRounds        uint32 = 16     // ssh-keygen default
+	minPBKDF2Iterations    int    = 600000 // OWASP recommendation for PBKDF2-HMAC-SHA256
+	minScryptCostParameter int    = 1 << 14
+	minScryptBlockSize     int    = 8
+	minScryptWork          int    = 5 << 14 // minimum N * p among the OWASP recommended scrypt configurations (N=2^14, r=8, p=5)
+)
+
+// Maximum key derivation function parameters accepted for passphrase-encrypted private keys, so a crafted key can't exhaust memory or CPU.
+const (
+	maxPBKDF2Iterations      int = 10000000
+	maxScryptMemory          int = 1 << 30 // bytes of memory scrypt uses, 128 * N * r, which is 1 GiB for N=2^20 and r=8
+	maxScryptWork            int = 1 << 22 // N * p, such as N=2^20 and p=4
+	maxScryptParallelization int = 16
+)
+
+const (
+	pemTypeEncryptedPrivateKey string = "ENCRYPTED PRIVATE KEY"
+	openSSHKeyMagic            string = "openssh-key-v1\x00"
+	pbes2KeyLength             int    = 32 // AES-256
+)
+
+var (
+	oidPBES2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidScrypt         = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11591, 4, 11}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+	oidAES256CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+type encryptedPrivateKeyInfo struct {
+	EncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedData       []byte
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+type scryptParams struct {
+	Salt                     []byte
+	CostParameter            int
+	BlockSize                int
+	ParallelizationParameter int
+	KeyLength                int `asn1:"optional"`
+}
+
+// EncryptedPrivateKey checks that a PEM-encoded passphrase-encrypted private key can be decrypted with password,
+// that its key derivation function parameters are within the accepted limits, and that the decrypted key is a valid private key of the specified algorithm.
+//
+// Supported formats and key derivation function parameter limits:
+//   - OpenSSH private key ("OPENSSH PRIVATE KEY") with the bcrypt KDF: at least 16 rounds.
+//   - PKCS #8 PBES2 ("ENCRYPTED PRIVATE KEY") with AES-256-CBC and PBKDF2-HMAC-SHA256/SHA512: 600,000 to 10,000,000 iterations.
+//   - PKCS #8 PBES2 ("ENCRYPTED PRIVATE KEY") with AES-256-CBC and scrypt: N at least 2^14, r at least 8, p from 1 to 16, N * p from 5 * 2^14 to 2^22, and 128 * N * r at most 1 GiB of memory.
+func EncryptedPrivateKey(algorithm Algorithm, encryptedPrivateKey []byte, password string) error {
+	if password == "" {
+		return errors.New("empty password")
+	}
+
+	block, _ := pem.Decode(encryptedPrivateKey)
+	if block == nil {
+		return errors.New("no PEM block found")
+	}
+
+	var privateKey crypto.PrivateKey
+	var err error
+
+	switch block.Type {
+	case pemTypeOpenSSHPrivateKey:
+		privateKey, err = decryptOpenSSH(encryptedPrivateKey, block.Bytes, password)
+	case pemTypeEncryptedPrivateKey:
+		privateKey, err = decryptPKCS8(block.Bytes, password)
+	default:
+		return fmt.Errorf("unexpected PEM block type \"%s\", expected \"%s\" or \"%s\"", block.Type, pemTypeOpenSSHPrivateKey, pemTypeEncryptedPrivateKey)
+	}
+	if err != nil {
+		return err
+	}
+
+	signer, ok := privateKey.(interface{ Public() crypto.PublicKey })
+	if !ok {
+		return fmt.Errorf("unsupported private key type %T", privateKey)
+	}
+
+	publicKey, privateKey := ecdhKeysFromPKIX(algorithm, signer.Public(), privateKey)
+
+	return KeyPair(algorithm, publicKey, privateKey)
+}
+
+// decryptOpenSSH checks the KDF parameters of an OpenSSH private key and decrypts it.
+func decryptOpenSSH(pemBytes, der []byte, password string) (crypto.PrivateKey, error) {
+	if !bytes.HasPrefix(der, []byte(openSSHKeyMagic)) {
+		return nil, errors.New("invalid OpenSSH private key: missing magic header")
+	}
+
+	var header struct {
+		CipherName string
+		KdfName    string
+		KdfOpts    string
+		Rest       []byte `ssh:"rest"`
+	}
+	if err := ssh.Unmarshal(der[len(openSSHKeyMagic):], &header); err != nil {
+		return nil, fmt.Errorf("invalid OpenSSH private key: %w", err)
+	}
+
+	if header.CipherName == "none" || header.KdfName == "none" {
+		return nil, errors.New("OpenSSH private key is not encrypted")
+	}
+
+	if header.KdfName != "bcrypt" {
+		return nil, fmt.Errorf("unsupported OpenSSH key derivation function \"%s\", expected \"bcrypt\"", header.KdfName)
+	}
+
+	if !strings.HasPrefix(header.CipherName, "aes256-") {
+		return nil, fmt.Errorf("unsupported OpenSSH cipher \"%s\", expected AES-256", header.CipherName)
+	}
+
+	var kdfOpts struct {
+		Salt   string
+		Rounds uint32
+	}
+	if err := ssh.Unmarshal([]byte(header.KdfOpts), &kdfOpts); err != nil {
+		return nil, fmt.Errorf("invalid bcrypt KDF options: %w", err)
+	}
+
+	if kdfOpts.Rounds < minBcryptRounds {
+		return nil, fmt.Errorf("bcrypt KDF rounds %d is less than minimum of %d", kdfOpts.Rounds, minBcryptRounds)
+	}
+
+	privateKey, err := ssh.ParseRawPrivateKeyWithPassphrase(pemBytes, []byte(password))
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting OpenSSH private key: %w", err)
+	}
+
+	// The ssh package returns ED25519 private keys as pointers, while KeyPair expects them as values.
+	if ed25519PrivateKey, ok := privateKey.(*ed25519.PrivateKey); ok {
+		return *ed25519PrivateKey, nil
+	}
+
+	return privateKey, nil
+}
+
+// decryptPKCS8 checks the PBES2 parameters of a PKCS #8 encrypted private key and decrypts it.
+func decryptPKCS8(der []byte, password string) (crypto.PrivateKey, error) {
+	var info encryptedPrivateKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil || len(rest) > 0 {
+		return nil, errors.New("invalid PKCS #8 encrypted private key structure")
+	}
+
+	if !info.EncryptionAlgorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("unsupported encryption algorithm %s, expected PBES2", info.EncryptionAlgorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.EncryptionAlgorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, errors.New("invalid PBES2 parameters")
+	}
+
+	if !params.EncryptionScheme.Algorithm.Equal(oidAES256CBC) {
+		return nil, fmt.Errorf("unsupported encryption scheme %s, expected AES-256-CBC", params.EncryptionScheme.Algorithm)
+	}
+
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil || len(iv) != aes.BlockSize {
+		return nil, errors.New("invalid AES-256-CBC initialization vector")
+	}
+
+	key, err := pbes2Key(params.KeyDerivationFunc, password)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext := info.EncryptedData
+	if len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 {
+		return nil, errors.New("encrypted data length is not a multiple of the AES block size")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating AES cipher: %w", err)
+	}
+
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+
+	// Remove the PKCS #5 padding. Invalid padding almost always means an incorrect password.
+	padding := int(plaintext[len(plaintext)-1])
+	if padding == 0 || padding > aes.BlockSize || !bytes.Equal(plaintext[len(plaintext)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return nil, errors.New("error decrypting PKCS #8 private key: incorrect password")
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(plaintext[:len(plaintext)-padding])
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting PKCS #8 private key: incorrect password or invalid key: %w", err)
+	}
+
+	return privateKey, nil
+}
+
+// pbes2Key checks the parameters of a PBES2 key derivation function and derives the AES-256 key from password.
+func pbes2Key(kdf pkix.AlgorithmIdentifier, password string) ([]byte, error) {
+	switch {
+	case kdf.Algorithm.Equal(oidPBKDF2):
+		var params pbkdf2Params
+		if _, err := asn1.Unmarshal(kdf.Parameters.FullBytes, &params); err != nil {
+			return nil, errors.New("invalid PBKDF2 parameters")
+		}
+
+		var prf func() hash.Hash
+		switch {
+		case params.PRF.Algorithm.Equal(oidHMACWithSHA256):
+			prf = sha256.New
+		case params.PRF.Algorithm.Equal(oidHMACWithSHA512):
+			prf = sha512.New
+		default:
+			return nil, fmt.Errorf("unsupported PBKDF2 pseudorandom function %s, expected HMAC-SHA256 or HMAC-SHA512", params.PRF.Algorithm)
+		}
+
+		if params.IterationCount < minPBKDF2Iterations {
+			return nil, fmt.Errorf("PBKDF2 iteration count %d is less than minimum of %d", params.IterationCount, minPBKDF2Iterations)
+		}
+
+		if params.IterationCount > maxPBKDF2Iterations {
+			return nil, fmt.Errorf("PBKDF2 iteration count %d exceeds maximum of %d", params.IterationCount, maxPBKDF2Iterations)
+		}
+
+		if params.KeyLength != 0 && params.KeyLength != pbes2KeyLength {
+			return nil, fmt.Errorf("PBKDF2 key length %d doesn't match AES-256 key length of %d", params.KeyLength, pbes2KeyLength)
+		}
+
+		return pbkdf2.Key([]byte(password), params.Salt, params.IterationCount, pbes2KeyLength, prf), nil
+
+	case kdf.Algorithm.Equal(oidScrypt):
+		var params scryptParams
+		if _, err := asn1.Unmarshal(kdf.Parameters.FullBytes, &params); err != nil {
+			return nil, errors.New("invalid scrypt parameters")
+		}
+
+		var errs []error
+
+		if params.CostParameter < minScryptCostParameter {
+			errs = append(errs, fmt.Errorf("scrypt cost parameter %d is less than minimum of %d", params.CostParameter, minScryptCostParameter))
+		}
+
+		if params.BlockSize < minScryptBlockSize {
+			errs = append(errs, fmt.Errorf("scrypt block size %d is less than minimum of %d", params.BlockSize, minScryptBlockSize))
+		}
+
+		if params.ParallelizationParameter < 1 || params.ParallelizationParameter > maxScryptParallelization {
+			errs = append(errs, fmt.Errorf("scrypt parallelization parameter %d is outside of range [1, %d]", params.ParallelizationParameter, maxScryptParallelization))
+		}
+
+		if params.CostParameter > 0 && params.BlockSize > 0 {
+			overflow, memory := bits.Mul64(uint64(params.CostParameter), uint64(params.BlockSize))
+			if overflow != 0 || memory > uint64(maxScryptMemory/128) { // scrypt uses 128 * r bytes for each of its N blocks
+				errs = append(errs, fmt.Errorf("scrypt cost parameter %d and block size %d need more than the maximum of %d bytes of memory", params.CostParameter, params.BlockSize, maxScryptMemory))
+			}
+		}
+
+		if params.CostParameter > 0 && params.ParallelizationParameter > 0 {
+			overflow, work := bits.Mul64(uint64(params.CostParameter), uint64(params.ParallelizationParameter))
+			if overflow == 0 && work < uint64(minScryptWork) {
+				errs = append(errs, fmt.Errorf("scrypt cost parameter %d times parallelization parameter %d is less than minimum of %d", params.CostParameter, params.ParallelizationParameter, minScryptWork))
+			}
+			if overflow != 0 || work > uint64(maxScryptWork) {
+				errs = append(errs, fmt.Errorf("scrypt cost parameter %d times parallelization parameter %d exceeds maximum of %d", params.CostParameter, params.ParallelizationParameter, maxScryptWork))
+			}
+		}
+
+		if params.KeyLength != 0 && params.KeyLength != pbes2KeyLength {
+			errs = append(errs, fmt.Errorf("scrypt key length %d doesn't match AES-256 key length of %d", params.KeyLength, pbes2KeyLength))
+		}
+
+		if len(errs) > 0 {
+			return nil, errors.Join(errs...)
+		}
+
+		key, err := scrypt.Key([]byte(password), params.Salt, params.CostParameter, params.BlockSize, params.ParallelizationParameter, pbes2KeyLength)
+		if err != nil {
+			return nil, fmt.Errorf("error deriving key with scrypt: %w", err)
+		}
+
+		return key, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key derivation function %s, expected PBKDF2 or scrypt", kdf.Algorithm)
+	}
+}
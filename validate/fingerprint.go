@@ -0,0 +1,239 @@
+package validate
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/md5"
+	"crypto/mlkem"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// FingerprintFormat defines the supported public key fingerprint formats.
+type FingerprintFormat int
+
+// List of supported fingerprint formats.
+const (
+	// OpenSSH SHA-256 fingerprint of the SSH wire encoding of the key, e.g. "SHA256:" followed by unpadded base64, as printed by ssh-keygen -l.
+	FingerprintFormatSHA256 FingerprintFormat = iota
+	// Legacy OpenSSH MD5 fingerprint, e.g. "MD5:" followed by colon-separated hexadecimal bytes, as printed by ssh-keygen -l -E md5.
+	FingerprintFormatMD5
+	// Base64 SHA-256 hash of the DER-encoded SubjectPublicKeyInfo of the key, as used by HPKP pin-sha256 directives (RFC 7469).
+	FingerprintFormatSPKI
+	// RDATA of a DNS SSHFP record with a SHA-256 fingerprint (RFC 4255, RFC 6594, RFC 7479), e.g. "4 2 " followed by hexadecimal digest.
+	FingerprintFormatSSHFP
+)
+
+var fingerprintFormatString = map[FingerprintFormat]string{
+	FingerprintFormatSHA256: "OpenSSH SHA256",
+	FingerprintFormatMD5:    "OpenSSH MD5",
+	FingerprintFormatSPKI:   "SPKI SHA-256",
+	FingerprintFormatSSHFP:  "SSHFP",
+}
+
+func (f FingerprintFormat) String() string {
+	return fingerprintFormatString[f]
+}
+
+const (
+	fingerprintPrefixSHA256 string = "SHA256:"
+	fingerprintPrefixMD5    string = "MD5:"
+
+	sshfpFingerprintTypeSHA256 int = 2
+)
+
+// Object identifiers of ML-KEM public keys assigned by NIST, which crypto/x509 can not marshal.
+var (
+	oidMLKEM768  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 2}
+	oidMLKEM1024 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 3}
+)
+
+// Fingerprint validates if fingerprint is a well-formed fingerprint of the specified format and if it matches publicKey.
+// OpenSSH formats (SHA256, MD5 and SSHFP) are only defined for keys supported by OpenSSH, i.e. ED25519, ECDSA (except P-224) and RSA keys,
+// while SPKI pins are defined for every algorithm.
+//
+// Hexadecimal digits are compared case-insensitively.
+func Fingerprint(fingerprint string, format FingerprintFormat, publicKey crypto.PublicKey) error {
+	var digest []byte
+	var sshfpAlgorithmNumber int
+	var err error
+
+	switch format {
+	case FingerprintFormatSHA256:
+		digest, err = parseFingerprintSHA256(fingerprint)
+	case FingerprintFormatMD5:
+		digest, err = parseFingerprintMD5(fingerprint)
+	case FingerprintFormatSPKI:
+		digest, err = parseFingerprintSPKI(fingerprint)
+	case FingerprintFormatSSHFP:
+		sshfpAlgorithmNumber, digest, err = parseFingerprintSSHFP(fingerprint)
+	default:
+		return errors.New("unsupported fingerprint format")
+	}
+	if err != nil {
+		return fmt.Errorf("malformed %s fingerprint: %w", format, err)
+	}
+
+	if publicKey == nil {
+		return errors.New("nil public key")
+	}
+
+	var expected []byte
+
+	switch format {
+	case FingerprintFormatSHA256, FingerprintFormatMD5, FingerprintFormatSSHFP:
+		sshPublicKey, err := ssh.NewPublicKey(publicKey)
+		if err != nil {
+			return fmt.Errorf("public key not supported by OpenSSH: %w", err)
+		}
+
+		if format == FingerprintFormatMD5 {
+			sum := md5.Sum(sshPublicKey.Marshal())
+			expected = sum[:]
+		} else {
+			sum := sha256.Sum256(sshPublicKey.Marshal())
+			expected = sum[:]
+		}
+
+		if format == FingerprintFormatSSHFP && sshfpAlgorithm[sshPublicKey.Type()] != sshfpAlgorithmNumber {
+			return fmt.Errorf("SSHFP algorithm %d doesn't match public key of type %s", sshfpAlgorithmNumber, sshPublicKey.Type())
+		}
+	case FingerprintFormatSPKI:
+		spki, err := MarshalPKIXPublicKey(publicKey)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(spki)
+		expected = sum[:]
+	}
+
+	if !bytes.Equal(digest, expected) {
+		return fmt.Errorf("%s fingerprint doesn't match public key", format)
+	}
+
+	return nil
+}
+
+// MarshalPKIXPublicKey encodes a public key to the DER-encoded PKIX SubjectPublicKeyInfo form.
+// Unlike x509.MarshalPKIXPublicKey, it also supports ML-KEM encapsulation keys.
+func MarshalPKIXPublicKey(publicKey crypto.PublicKey) ([]byte, error) {
+	var oid asn1.ObjectIdentifier
+	var key []byte
+
+	switch k := publicKey.(type) {
+	case *mlkem.EncapsulationKey768:
+		oid, key = oidMLKEM768, k.Bytes()
+	case *mlkem.EncapsulationKey1024:
+		oid, key = oidMLKEM1024, k.Bytes()
+	default:
+		spki, err := x509.MarshalPKIXPublicKey(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling public key to SubjectPublicKeyInfo: %w", err)
+		}
+		return spki, nil
+	}
+
+	spki, err := asn1.Marshal(struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: oid},
+		PublicKey: asn1.BitString{Bytes: key, BitLength: 8 * len(key)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling ML-KEM public key to SubjectPublicKeyInfo: %w", err)
+	}
+
+	return spki, nil
+}
+
+func parseFingerprintSHA256(fingerprint string) ([]byte, error) {
+	encoded, ok := strings.CutPrefix(fingerprint, fingerprintPrefixSHA256)
+	if !ok {
+		return nil, fmt.Errorf("missing \"%s\" prefix", fingerprintPrefixSHA256)
+	}
+
+	digest, err := base64.RawStdEncoding.Strict().DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid unpadded base64: %w", err)
+	}
+
+	return digest, checkDigestLength(digest, sha256.Size)
+}
+
+func parseFingerprintMD5(fingerprint string) ([]byte, error) {
+	encoded, ok := strings.CutPrefix(fingerprint, fingerprintPrefixMD5)
+	if !ok {
+		return nil, fmt.Errorf("missing \"%s\" prefix", fingerprintPrefixMD5)
+	}
+
+	parts := strings.Split(encoded, ":")
+	if len(parts) != md5.Size {
+		return nil, fmt.Errorf("%d colon-separated bytes, expected %d", len(parts), md5.Size)
+	}
+
+	digest := make([]byte, 0, md5.Size)
+
+	for i, part := range parts {
+		if len(part) != 2 {
+			return nil, fmt.Errorf("byte %d \"%s\" not two hexadecimal digits", i, part)
+		}
+		b, err := hex.DecodeString(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hexadecimal byte %d: %w", i, err)
+		}
+		digest = append(digest, b[0])
+	}
+
+	return digest, nil
+}
+
+func parseFingerprintSPKI(fingerprint string) ([]byte, error) {
+	digest, err := base64.StdEncoding.Strict().DecodeString(fingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64: %w", err)
+	}
+
+	return digest, checkDigestLength(digest, sha256.Size)
+}
+
+func parseFingerprintSSHFP(fingerprint string) (int, []byte, error) {
+	fields := strings.Fields(fingerprint)
+	if len(fields) != 3 {
+		return 0, nil, fmt.Errorf("%d fields, expected algorithm, fingerprint type and fingerprint", len(fields))
+	}
+
+	algorithm, err := strconv.Atoi(fields[0])
+	if err != nil || algorithm < 1 || algorithm > 255 {
+		return 0, nil, fmt.Errorf("invalid algorithm \"%s\"", fields[0])
+	}
+
+	fingerprintType, err := strconv.Atoi(fields[1])
+	if err != nil || fingerprintType != sshfpFingerprintTypeSHA256 {
+		return 0, nil, fmt.Errorf("fingerprint type \"%s\", expected %d (SHA-256)", fields[1], sshfpFingerprintTypeSHA256)
+	}
+
+	digest, err := hex.DecodeString(fields[2])
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid hexadecimal fingerprint: %w", err)
+	}
+
+	return algorithm, digest, checkDigestLength(digest, sha256.Size)
+}
+
+func checkDigestLength(digest []byte, size int) error {
+	if len(digest) != size {
+		return fmt.Errorf("digest of %d bytes, expected %d", len(digest), size)
+	}
+	return nil
+}
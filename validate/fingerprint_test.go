@@ -0,0 +1,162 @@
+package validate_test
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/mlkem"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestFingerprintSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	publicKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)).Public().(ed25519.PublicKey)
+	sshPublicKey, _ := ssh.NewPublicKey(publicKey)
+	spki, _ := x509.MarshalPKIXPublicKey(publicKey)
+	spkiSum := sha256.Sum256(spki)
+	sshSum := sha256.Sum256(sshPublicKey.Marshal())
+
+	x25519PrivateKey, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	x25519SPKI, _ := x509.MarshalPKIXPublicKey(x25519PrivateKey.PublicKey())
+	x25519Sum := sha256.Sum256(x25519SPKI)
+
+	decapsulationKey, _ := mlkem.GenerateKey768()
+	mlkemKey := decapsulationKey.EncapsulationKey().Bytes()
+	mlkemSPKI, _ := asn1.Marshal(struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 2}},
+		PublicKey: asn1.BitString{Bytes: mlkemKey, BitLength: 8 * len(mlkemKey)},
+	})
+	mlkemSum := sha256.Sum256(mlkemSPKI)
+
+	testCases := map[string]struct {
+		fingerprint string
+		format      validate.FingerprintFormat
+		publicKey   any
+	}{
+		"OpenSSH SHA256":       {ssh.FingerprintSHA256(sshPublicKey), validate.FingerprintFormatSHA256, publicKey},
+		"OpenSSH MD5":          {"MD5:" + ssh.FingerprintLegacyMD5(sshPublicKey), validate.FingerprintFormatMD5, publicKey},
+		"SPKI pin":             {base64.StdEncoding.EncodeToString(spkiSum[:]), validate.FingerprintFormatSPKI, publicKey},
+		"SPKI pin of X25519":   {base64.StdEncoding.EncodeToString(x25519Sum[:]), validate.FingerprintFormatSPKI, x25519PrivateKey.PublicKey()},
+		"SPKI pin of ML-KEM":   {base64.StdEncoding.EncodeToString(mlkemSum[:]), validate.FingerprintFormatSPKI, decapsulationKey.EncapsulationKey()},
+		"SSHFP":                {"4 2 " + hex.EncodeToString(sshSum[:]), validate.FingerprintFormatSSHFP, publicKey},
+		"SSHFP upper-case hex": {"4 2 " + strings.ToUpper(hex.EncodeToString(sshSum[:])), validate.FingerprintFormatSSHFP, publicKey},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.Fingerprint(tc.fingerprint, tc.format, tc.publicKey); err != nil {
+				t.Errorf("expected no error for valid fingerprint \"%s\", but got error: %v", tc.fingerprint, err)
+			}
+		})
+	}
+}
+
+func TestFingerprintFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	publicKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)).Public().(ed25519.PublicKey)
+	otherPublicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	sshPublicKey, _ := ssh.NewPublicKey(publicKey)
+	sha256Fingerprint := ssh.FingerprintSHA256(sshPublicKey)
+	sshSum := sha256.Sum256(sshPublicKey.Marshal())
+	x25519PrivateKey, _ := ecdh.X25519().GenerateKey(rand.Reader)
+
+	testCases := map[string]struct {
+		fingerprint string
+		format      validate.FingerprintFormat
+		publicKey   any
+	}{
+		"Unsupported format":           {sha256Fingerprint, validate.FingerprintFormat(-1), publicKey},
+		"Nil public key":               {sha256Fingerprint, validate.FingerprintFormatSHA256, nil},
+		"Different key":                {sha256Fingerprint, validate.FingerprintFormatSHA256, otherPublicKey},
+		"Missing SHA256 prefix":        {sha256Fingerprint[len("SHA256:"):], validate.FingerprintFormatSHA256, publicKey},
+		"Padded base64":                {sha256Fingerprint + "=", validate.FingerprintFormatSHA256, publicKey},
+		"Truncated SHA256":             {sha256Fingerprint[:20], validate.FingerprintFormatSHA256, publicKey},
+		"Missing MD5 prefix":           {ssh.FingerprintLegacyMD5(sshPublicKey), validate.FingerprintFormatMD5, publicKey},
+		"MD5 without colons":           {"MD5:00112233445566778899aabbccddeeff", validate.FingerprintFormatMD5, publicKey},
+		"Invalid MD5 hex":              {"MD5:zz:11:22:33:44:55:66:77:88:99:aa:bb:cc:dd:ee:ff", validate.FingerprintFormatMD5, publicKey},
+		"SPKI pin of wrong length":     {"AAAA", validate.FingerprintFormatSPKI, publicKey},
+		"SSHFP wrong algorithm":        {"3 2 " + hex.EncodeToString(sshSum[:]), validate.FingerprintFormatSSHFP, publicKey},
+		"SSHFP SHA-1 type":             {"4 1 " + hex.EncodeToString(sshSum[:20]), validate.FingerprintFormatSSHFP, publicKey},
+		"SSHFP missing field":          {"4 " + hex.EncodeToString(sshSum[:]), validate.FingerprintFormatSSHFP, publicKey},
+		"OpenSSH format of X25519 key": {sha256Fingerprint, validate.FingerprintFormatSHA256, x25519PrivateKey.PublicKey()},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.Fingerprint(tc.fingerprint, tc.format, tc.publicKey); err == nil {
+				t.Errorf("expected error for invalid fingerprint %q, but got nil", name)
+			}
+		})
+	}
+}
+
+func TestMarshalPKIXPublicKey(t *testing.T) {
+	t.Parallel()
+
+	publicKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)).Public()
+	decapsulationKey768, _ := mlkem.GenerateKey768()
+	decapsulationKey1024, _ := mlkem.GenerateKey1024()
+
+	x509SPKI, _ := x509.MarshalPKIXPublicKey(publicKey)
+
+	testCases := map[string]struct {
+		publicKey any
+		oid       asn1.ObjectIdentifier
+		key       []byte
+	}{
+		"ED25519":     {publicKey, asn1.ObjectIdentifier{1, 3, 101, 112}, publicKey.(ed25519.PublicKey)},
+		"ML-KEM-768":  {decapsulationKey768.EncapsulationKey(), asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 2}, decapsulationKey768.EncapsulationKey().Bytes()},
+		"ML-KEM-1024": {decapsulationKey1024.EncapsulationKey(), asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 3}, decapsulationKey1024.EncapsulationKey().Bytes()},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			spki, err := validate.MarshalPKIXPublicKey(tc.publicKey)
+			if err != nil {
+				t.Fatalf("expected no error for valid public key, but got error: %v", err)
+			}
+
+			var info struct {
+				Algorithm pkix.AlgorithmIdentifier
+				PublicKey asn1.BitString
+			}
+			if rest, err := asn1.Unmarshal(spki, &info); err != nil || len(rest) != 0 {
+				t.Fatalf("invalid SubjectPublicKeyInfo: %v", err)
+			}
+			if !info.Algorithm.Algorithm.Equal(tc.oid) {
+				t.Errorf("expected algorithm %s, but got %s", tc.oid, info.Algorithm.Algorithm)
+			}
+			if !bytes.Equal(info.PublicKey.Bytes, tc.key) {
+				t.Errorf("public key of SubjectPublicKeyInfo doesn't match")
+			}
+		})
+	}
+
+	spki, _ := validate.MarshalPKIXPublicKey(publicKey)
+	if !bytes.Equal(spki, x509SPKI) {
+		t.Errorf("expected the encoding of x509.MarshalPKIXPublicKey for keys it supports")
+	}
+
+	if _, err := validate.MarshalPKIXPublicKey("not a key"); err == nil {
+		t.Errorf("expected error for unsupported public key type, but got no error")
+	}
+}
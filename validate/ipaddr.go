@@ -0,0 +1,31 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+)
+
+// IPAddr validates if the provided address is a valid IP address within the specified prefix, like IP for netip types.
+// The zero prefix accepts every address. Addresses with an IPv6 zone are never within a prefix.
+//
+// IPAddr doesn't allocate for valid input, so it can be used on hot paths.
+func IPAddr(addr netip.Addr, prefix netip.Prefix) error {
+	if !addr.IsValid() {
+		return errors.New("invalid IP address")
+	}
+
+	if prefix == (netip.Prefix{}) {
+		return nil
+	}
+
+	if !prefix.IsValid() {
+		return errors.New("invalid prefix")
+	}
+
+	if !prefix.Contains(addr) {
+		return fmt.Errorf("IP address %s is not within the specified prefix %s", addr, prefix)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package validate_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+)
+
+func TestIPAddrSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		addr   netip.Addr
+		prefix netip.Prefix
+	}{
+		"IPv4 without prefix":      {netip.MustParseAddr("192.168.1.1"), netip.Prefix{}},
+		"IPv6 without prefix":      {netip.MustParseAddr("2001:db8:85a3::8a2e:370:7334"), netip.Prefix{}},
+		"IPv6 with zone":           {netip.MustParseAddr("fe80::1%eth0"), netip.Prefix{}},
+		"IPv4 within prefix":       {netip.MustParseAddr("192.168.1.10"), netip.MustParsePrefix("192.168.1.0/24")},
+		"IPv6 within prefix":       {netip.MustParseAddr("2001:db8::1"), netip.MustParsePrefix("2001:db8::/32")},
+		"Network address":          {netip.MustParseAddr("10.0.0.0"), netip.MustParsePrefix("10.0.0.0/8")},
+		"Broadcast address":        {netip.MustParseAddr("10.255.255.255"), netip.MustParsePrefix("10.0.0.0/8")},
+		"Exact IPv4 match":         {netip.MustParseAddr("10.0.0.1"), netip.MustParsePrefix("10.0.0.1/32")},
+		"Exact IPv6 match":         {netip.MustParseAddr("2001:db8::1"), netip.MustParsePrefix("2001:db8::1/128")},
+		"Unmasked prefix":          {netip.MustParseAddr("172.16.5.4"), netip.MustParsePrefix("172.16.200.1/12")},
+		"Whole IPv4 address space": {netip.MustParseAddr("8.8.8.8"), netip.MustParsePrefix("0.0.0.0/0")},
+		"IPv4-mapped IPv6 in IPv6": {netip.MustParseAddr("::ffff:10.0.0.1"), netip.MustParsePrefix("::ffff:0:0/96")},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.IPAddr(tc.addr, tc.prefix); err != nil {
+				t.Errorf("expected no error for valid input %s in %s, but got error: %v", tc.addr, tc.prefix, err)
+			}
+		})
+	}
+}
+
+func TestIPAddrFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		addr   netip.Addr
+		prefix netip.Prefix
+	}{
+		"Zero address":               {netip.Addr{}, netip.Prefix{}},
+		"Outside IPv4 prefix":        {netip.MustParseAddr("192.168.2.1"), netip.MustParsePrefix("192.168.1.0/24")},
+		"Outside IPv6 prefix":        {netip.MustParseAddr("2001:db9::1"), netip.MustParsePrefix("2001:db8::/32")},
+		"IPv4 in IPv6 prefix":        {netip.MustParseAddr("10.0.0.1"), netip.MustParsePrefix("::/0")},
+		"IPv6 in IPv4 prefix":        {netip.MustParseAddr("::1"), netip.MustParsePrefix("0.0.0.0/0")},
+		"IPv4-mapped in IPv4":        {netip.MustParseAddr("::ffff:10.0.0.1"), netip.MustParsePrefix("10.0.0.0/8")},
+		"Zone within prefix":         {netip.MustParseAddr("fe80::1%eth0"), netip.MustParsePrefix("fe80::/10")},
+		"Prefix length out of range": {netip.MustParseAddr("10.0.0.1"), netip.PrefixFrom(netip.MustParseAddr("10.0.0.0"), 33)},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.IPAddr(tc.addr, tc.prefix); err == nil {
+				t.Errorf("expected error for invalid input %s in %s, but got nil", tc.addr, tc.prefix)
+			}
+		})
+	}
+}
+
+func TestIPAddrDoesNotAllocate(t *testing.T) {
+	addr := netip.MustParseAddr("2001:db8::1")
+	prefix := netip.MustParsePrefix("2001:db8::/32")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := validate.IPAddr(addr, prefix); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if allocs != 0 {
+		t.Errorf("expected no allocations, but got %v", allocs)
+	}
+}
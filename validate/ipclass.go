@@ -0,0 +1,119 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+)
+
+// IPClass defines the classes of IP addresses derived from the IANA special-purpose address registries.
+type IPClass int
+
+// List of IP address classes.
+const (
+	// Globally routable unicast addresses, which are outside every special-purpose block or in a block marked globally reachable.
+	IPClassGlobal IPClass = iota
+	// Private-use addresses of RFC 1918 and unique local IPv6 addresses of RFC 4193.
+	IPClassPrivate
+	// Loopback addresses: 127.0.0.0/8 and ::1.
+	IPClassLoopback
+	// Link-local addresses: 169.254.0.0/16 and fe80::/10.
+	IPClassLinkLocal
+	// Documentation addresses of RFC 5737, RFC 3849 and RFC 9637.
+	IPClassDocumentation
+	// Shared address space for carrier-grade NAT of RFC 6598: 100.64.0.0/10.
+	IPClassCGNAT
+	// Multicast addresses: 224.0.0.0/4 and ff00::/8.
+	IPClassMulticast
+	// Every other special-purpose address, such as unspecified, benchmarking, broadcast and reserved addresses,
+	// and IPv6 addresses outside the global unicast space 2000::/3 which are not in a special-purpose block.
+	IPClassReserved
+)
+
+var ipClassString = map[IPClass]string{
+	IPClassGlobal:        "global",
+	IPClassPrivate:       "private",
+	IPClassLoopback:      "loopback",
+	IPClassLinkLocal:     "link-local",
+	IPClassDocumentation: "documentation",
+	IPClassCGNAT:         "CGNAT",
+	IPClassMulticast:     "multicast",
+	IPClassReserved:      "reserved",
+}
+
+func (c IPClass) String() string {
+	return ipClassString[c]
+}
+
+// IPSpecialPurpose is an entry of an IANA special-purpose address registry.
+type IPSpecialPurpose struct {
+	Prefix             netip.Prefix
+	Name               string
+	RFC                string
+	Class              IPClass
+	Source             bool // Valid as a source address.
+	Destination        bool // Valid as a destination address.
+	Forwardable        bool // Forwardable by routers.
+	Global             bool // Globally reachable.
+	ReservedByProtocol bool // Reserved by protocol.
+}
+
+var ipv6GlobalUnicast = netip.MustParsePrefix("2000::/3")
+
+// SpecialPurposeIP returns the most specific entry of IPv4SpecialPurposeRegistry or IPv6SpecialPurposeRegistry containing the address,
+// or false if the address is in no special-purpose block. IPv4-mapped IPv6 addresses are looked up in the IPv6 registry.
+func SpecialPurposeIP(addr netip.Addr) (IPSpecialPurpose, bool) {
+	registry := IPv6SpecialPurposeRegistry
+	if addr.Is4() {
+		registry = IPv4SpecialPurposeRegistry
+	}
+
+	var match IPSpecialPurpose
+	found := false
+
+	for _, entry := range registry {
+		if entry.Prefix.Contains(addr.WithZone("")) && (!found || entry.Prefix.Bits() > match.Prefix.Bits()) {
+			match, found = entry, true
+		}
+	}
+
+	return match, found
+}
+
+// ClassifyIP returns the class of the address according to the IANA special-purpose address registries.
+// It returns IPClassReserved for an invalid address.
+func ClassifyIP(addr netip.Addr) IPClass {
+	if !addr.IsValid() {
+		return IPClassReserved
+	}
+
+	if entry, ok := SpecialPurposeIP(addr); ok {
+		return entry.Class
+	}
+
+	if addr.Is6() && !ipv6GlobalUnicast.Contains(addr.WithZone("")) {
+		return IPClassReserved
+	}
+
+	return IPClassGlobal
+}
+
+// IPAddrClass validates if the provided address is a valid IP address of one of the specified classes, as returned by ClassifyIP.
+func IPAddrClass(addr netip.Addr, classes ...IPClass) error {
+	if !addr.IsValid() {
+		return errors.New("invalid IP address")
+	}
+
+	if len(classes) == 0 {
+		return errors.New("no IP address class specified")
+	}
+
+	class := ClassifyIP(addr)
+	for _, c := range classes {
+		if c == class {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("IP address %s is a %s address, expected %v", addr, class, classes)
+}
@@ -0,0 +1,108 @@
+package validate_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+)
+
+func TestClassifyIP(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]validate.IPClass{
+		"8.8.8.8":          validate.IPClassGlobal,
+		"1.1.1.1":          validate.IPClassGlobal,
+		"10.1.2.3":         validate.IPClassPrivate,
+		"172.31.255.255":   validate.IPClassPrivate,
+		"172.32.0.1":       validate.IPClassGlobal,
+		"192.168.0.1":      validate.IPClassPrivate,
+		"100.64.0.1":       validate.IPClassCGNAT,
+		"100.128.0.1":      validate.IPClassGlobal,
+		"127.0.0.1":        validate.IPClassLoopback,
+		"169.254.1.1":      validate.IPClassLinkLocal,
+		"192.0.2.1":        validate.IPClassDocumentation,
+		"198.51.100.7":     validate.IPClassDocumentation,
+		"203.0.113.255":    validate.IPClassDocumentation,
+		"224.0.0.251":      validate.IPClassMulticast,
+		"239.255.255.250":  validate.IPClassMulticast,
+		"0.0.0.0":          validate.IPClassReserved,
+		"240.0.0.1":        validate.IPClassReserved,
+		"255.255.255.255":  validate.IPClassReserved,
+		"198.18.0.1":       validate.IPClassReserved,
+		"192.0.0.8":        validate.IPClassReserved,
+		"192.0.0.9":        validate.IPClassGlobal,
+		"2606:4700::1111":  validate.IPClassGlobal,
+		"2001:db8::1":      validate.IPClassDocumentation,
+		"3fff::1":          validate.IPClassDocumentation,
+		"fd00::1":          validate.IPClassPrivate,
+		"fe80::1":          validate.IPClassLinkLocal,
+		"fe80::1%eth0":     validate.IPClassLinkLocal,
+		"::1":              validate.IPClassLoopback,
+		"::":               validate.IPClassReserved,
+		"::ffff:8.8.8.8":   validate.IPClassReserved,
+		"ff02::1":          validate.IPClassMulticast,
+		"2001::1":          validate.IPClassReserved,
+		"2001:1::1":        validate.IPClassGlobal,
+		"64:ff9b::808:808": validate.IPClassGlobal,
+		"4000::1":          validate.IPClassReserved,
+	}
+
+	for addr, expected := range testCases {
+		t.Run(addr, func(t *testing.T) {
+			t.Parallel()
+			if class := validate.ClassifyIP(netip.MustParseAddr(addr)); class != expected {
+				t.Errorf("expected %s to be a %s address, but got %s", addr, expected, class)
+			}
+		})
+	}
+}
+
+func TestIPAddrClassSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		addr    string
+		classes []validate.IPClass
+	}{
+		"Global":                 {"8.8.8.8", []validate.IPClass{validate.IPClassGlobal}},
+		"Private":                {"192.168.1.1", []validate.IPClass{validate.IPClassPrivate}},
+		"One of several classes": {"100.64.0.1", []validate.IPClass{validate.IPClassPrivate, validate.IPClassCGNAT}},
+		"IPv6 documentation":     {"2001:db8::1", []validate.IPClass{validate.IPClassDocumentation}},
+		"IPv6 unique local":      {"fd12:3456:789a::1", []validate.IPClass{validate.IPClassPrivate}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.IPAddrClass(netip.MustParseAddr(tc.addr), tc.classes...); err != nil {
+				t.Errorf("expected no error for valid input %s, but got error: %v", tc.addr, err)
+			}
+		})
+	}
+}
+
+func TestIPAddrClassFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		addr    netip.Addr
+		classes []validate.IPClass
+	}{
+		"Zero address":          {netip.Addr{}, []validate.IPClass{validate.IPClassReserved}},
+		"No class":              {netip.MustParseAddr("8.8.8.8"), nil},
+		"Private not global":    {netip.MustParseAddr("10.0.0.1"), []validate.IPClass{validate.IPClassGlobal}},
+		"Loopback not private":  {netip.MustParseAddr("127.0.0.1"), []validate.IPClass{validate.IPClassPrivate}},
+		"Multicast not global":  {netip.MustParseAddr("ff02::1"), []validate.IPClass{validate.IPClassGlobal}},
+		"Global not documented": {netip.MustParseAddr("1.1.1.1"), []validate.IPClass{validate.IPClassDocumentation}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.IPAddrClass(tc.addr, tc.classes...); err == nil {
+				t.Errorf("expected error for invalid input %s, but got nil", tc.addr)
+			}
+		})
+	}
+}
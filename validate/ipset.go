@@ -0,0 +1,251 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+)
+
+// IPSet is an immutable set of IP addresses of both families, built from prefixes.
+// The zero value is an empty set.
+type IPSet struct {
+	ranges []ipRange // Sorted, non-overlapping and non-adjacent.
+}
+
+// ipRange is an inclusive range of addresses of the same family.
+type ipRange struct {
+	from, to netip.Addr
+}
+
+// NewIPSet returns the set of the addresses in the specified prefixes. Prefixes may overlap and needn't be masked.
+func NewIPSet(prefixes ...netip.Prefix) (IPSet, error) {
+	ranges := make([]ipRange, 0, len(prefixes))
+
+	for _, prefix := range prefixes {
+		if !prefix.IsValid() {
+			return IPSet{}, fmt.Errorf("invalid prefix %s", prefix)
+		}
+		ranges = append(ranges, prefixRange(prefix))
+	}
+
+	return IPSet{ranges: mergeRanges(ranges)}, nil
+}
+
+// Union returns the set of the addresses in s or other.
+func (s IPSet) Union(other IPSet) IPSet {
+	return IPSet{ranges: mergeRanges(append(slices.Clone(s.ranges), other.ranges...))}
+}
+
+// Difference returns the set of the addresses in s which are not in other.
+func (s IPSet) Difference(other IPSet) IPSet {
+	var ranges []ipRange
+
+	j := 0
+	for _, r := range s.ranges {
+		from := r.from
+
+		for ; j < len(other.ranges) && other.ranges[j].to.Less(from); j++ {
+		}
+
+		for k := j; k < len(other.ranges) && !r.to.Less(other.ranges[k].from); k++ {
+			hole := other.ranges[k]
+			if from.Less(hole.from) {
+				ranges = append(ranges, ipRange{from, hole.from.Prev()})
+			}
+			// The successor of the last address of a family is invalid, which ends the range.
+			from = hole.to.Next()
+			if !from.IsValid() || r.to.Less(from) {
+				break
+			}
+		}
+
+		if from.IsValid() && !r.to.Less(from) {
+			ranges = append(ranges, ipRange{from, r.to})
+		}
+	}
+
+	return IPSet{ranges: ranges}
+}
+
+// Contains reports whether the address is in the set.
+func (s IPSet) Contains(addr netip.Addr) bool {
+	addr = addr.WithZone("")
+	i := s.search(addr)
+
+	return i > 0 && !s.ranges[i-1].to.Less(addr)
+}
+
+// ContainsPrefix reports whether every address of the prefix is in the set.
+func (s IPSet) ContainsPrefix(prefix netip.Prefix) bool {
+	if !prefix.IsValid() {
+		return false
+	}
+
+	r := prefixRange(prefix)
+	i := s.search(r.from)
+
+	return i > 0 && !s.ranges[i-1].to.Less(r.to)
+}
+
+// Overlaps reports whether any address of the prefix is in the set.
+func (s IPSet) Overlaps(prefix netip.Prefix) bool {
+	if !prefix.IsValid() {
+		return false
+	}
+
+	r := prefixRange(prefix)
+	i := s.search(r.to)
+
+	return i > 0 && !s.ranges[i-1].to.Less(r.from)
+}
+
+// search returns the number of ranges starting at or before the address.
+func (s IPSet) search(addr netip.Addr) int {
+	i, found := slices.BinarySearchFunc(s.ranges, addr, func(r ipRange, addr netip.Addr) int {
+		return r.from.Compare(addr)
+	})
+	if found {
+		i++
+	}
+
+	return i
+}
+
+// IsEmpty reports whether the set has no addresses.
+func (s IPSet) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+// Prefixes returns the shortest list of masked prefixes covering exactly the addresses of the set, in ascending order.
+func (s IPSet) Prefixes() []netip.Prefix {
+	var prefixes []netip.Prefix
+
+	for _, r := range s.ranges {
+		from := r.from
+		for from.IsValid() && !r.to.Less(from) {
+			// The largest block aligned at from which ends at or before the end of the range.
+			var prefix netip.Prefix
+			for bits := 0; bits <= from.BitLen(); bits++ {
+				prefix = netip.PrefixFrom(from, bits)
+				if prefix.Masked().Addr() == from && !r.to.Less(lastAddr(prefix)) {
+					break
+				}
+			}
+			prefixes = append(prefixes, prefix)
+			from = lastAddr(prefix).Next()
+		}
+	}
+
+	return prefixes
+}
+
+// String returns the prefixes of the set separated by commas.
+func (s IPSet) String() string {
+	var b []byte
+	for i, prefix := range s.Prefixes() {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = prefix.AppendTo(b)
+	}
+
+	return string(b)
+}
+
+// Subnets validates if the provided CIDRs are non-overlapping networks in canonical form within the supernet,
+// none of which overlaps an excluded prefix.
+func Subnets(cidrs []string, supernet netip.Prefix, excluded ...netip.Prefix) error {
+	if !supernet.IsValid() {
+		return errors.New("invalid supernet")
+	}
+
+	excludedSet, err := NewIPSet(excluded...)
+	if err != nil {
+		return fmt.Errorf("invalid excluded prefix: %w", err)
+	}
+
+	var used IPSet
+	var errs []error
+
+	for _, cidr := range cidrs {
+		if err := CIDRWithin(cidr, supernet); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		prefix := netip.MustParsePrefix(cidr)
+
+		if excludedSet.Overlaps(prefix) {
+			errs = append(errs, fmt.Errorf("CIDR %s overlaps an excluded prefix", cidr))
+			continue
+		}
+
+		if used.Overlaps(prefix) {
+			errs = append(errs, fmt.Errorf("CIDR %s overlaps another CIDR", cidr))
+			continue
+		}
+
+		set, _ := NewIPSet(prefix)
+		used = used.Union(set)
+	}
+
+	return errors.Join(errs...)
+}
+
+// prefixRange returns the range of the addresses of a valid prefix.
+func prefixRange(prefix netip.Prefix) ipRange {
+	return ipRange{from: prefix.Masked().Addr(), to: lastAddr(prefix)}
+}
+
+// lastAddr returns the last address of a valid prefix.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Masked().Addr()
+	ip := addr.As16()
+
+	ones := prefix.Bits()
+	if addr.Is4() {
+		ones += 96
+	}
+
+	for i := range ip {
+		switch {
+		case ones >= (i+1)*8:
+		case ones <= i*8:
+			ip[i] = 0xff
+		default:
+			ip[i] |= 0xff >> (ones - i*8)
+		}
+	}
+
+	if addr.Is4() {
+		return netip.AddrFrom4([4]byte(ip[12:]))
+	}
+
+	return netip.AddrFrom16(ip)
+}
+
+// mergeRanges sorts the ranges and merges the overlapping and adjacent ones.
+func mergeRanges(ranges []ipRange) []ipRange {
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	slices.SortFunc(ranges, func(a, b ipRange) int {
+		return a.from.Compare(b.from)
+	})
+
+	merged := ranges[:1]
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if !last.to.Less(r.from) || last.to.Next() == r.from {
+			if last.to.Less(r.to) {
+				last.to = r.to
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+
+	return merged
+}
@@ -0,0 +1,184 @@
+package validate_test
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+)
+
+func mustIPSet(t *testing.T, cidrs string) validate.IPSet {
+	t.Helper()
+
+	var prefixes []netip.Prefix
+	if cidrs != "" {
+		for _, cidr := range strings.Split(cidrs, ",") {
+			prefixes = append(prefixes, netip.MustParsePrefix(cidr))
+		}
+	}
+
+	set, err := validate.NewIPSet(prefixes...)
+	if err != nil {
+		t.Fatalf("error creating IP set of %s: %v", cidrs, err)
+	}
+
+	return set
+}
+
+func TestIPSetUnion(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		a, b     string
+		expected string
+	}{
+		"Empty sets":       {"", "", ""},
+		"Disjoint":         {"10.0.0.0/24", "10.0.2.0/24", "10.0.0.0/24,10.0.2.0/24"},
+		"Adjacent":         {"10.0.0.0/24", "10.0.1.0/24", "10.0.0.0/23"},
+		"Nested":           {"10.0.0.0/8", "10.1.0.0/16", "10.0.0.0/8"},
+		"Unmasked":         {"10.0.0.7/24", "", "10.0.0.0/24"},
+		"Both families":    {"0.0.0.0/0", "::/0", "0.0.0.0/0,::/0"},
+		"Unaligned result": {"10.0.1.0/24", "10.0.2.0/23", "10.0.1.0/24,10.0.2.0/23"},
+		"Last IPv4 and ::": {"255.255.255.255/32", "::/128", "255.255.255.255/32,::/128"},
+		"IPv6 adjacent":    {"2001:db8::/33", "2001:db8:8000::/33", "2001:db8::/32"},
+		"Three into one":   {"10.0.0.0/25,10.0.1.0/24", "10.0.0.128/25", "10.0.0.0/23"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if union := mustIPSet(t, tc.a).Union(mustIPSet(t, tc.b)).String(); union != tc.expected {
+				t.Errorf("expected union %s, but got %s", tc.expected, union)
+			}
+		})
+	}
+}
+
+func TestIPSetDifference(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		a, b     string
+		expected string
+	}{
+		"Empty":             {"", "10.0.0.0/8", ""},
+		"Nothing removed":   {"10.0.0.0/8", "", "10.0.0.0/8"},
+		"Disjoint":          {"10.0.0.0/24", "10.0.1.0/24", "10.0.0.0/24"},
+		"Everything":        {"10.0.0.0/24", "10.0.0.0/8", ""},
+		"First half":        {"10.0.0.0/23", "10.0.0.0/24", "10.0.1.0/24"},
+		"Hole":              {"10.0.0.0/30", "10.0.0.1/32", "10.0.0.0/32,10.0.0.2/31"},
+		"Two holes":         {"10.0.0.0/29", "10.0.0.1/32,10.0.0.6/32", "10.0.0.0/32,10.0.0.2/31,10.0.0.4/31,10.0.0.7/32"},
+		"Other family":      {"10.0.0.0/8", "::/0", "10.0.0.0/8"},
+		"End of IPv4 space": {"255.255.255.0/24", "255.255.255.128/25", "255.255.255.0/25"},
+		"End of IPv6 space": {"ffff::/16", "ffff:ffff::/32", "ffff::/17,ffff:8000::/18,ffff:c000::/19,ffff:e000::/20,ffff:f000::/21,ffff:f800::/22,ffff:fc00::/23,ffff:fe00::/24,ffff:ff00::/25,ffff:ff80::/26,ffff:ffc0::/27,ffff:ffe0::/28,ffff:fff0::/29,ffff:fff8::/30,ffff:fffc::/31,ffff:fffe::/32"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if difference := mustIPSet(t, tc.a).Difference(mustIPSet(t, tc.b)).String(); difference != tc.expected {
+				t.Errorf("expected difference %s, but got %s", tc.expected, difference)
+			}
+		})
+	}
+}
+
+func TestIPSetContains(t *testing.T) {
+	t.Parallel()
+
+	set := mustIPSet(t, "10.0.0.0/8,192.168.0.0/24,192.168.2.0/24,2001:db8::/32")
+
+	for addr, expected := range map[string]bool{
+		"10.0.0.0":        true,
+		"10.255.255.255":  true,
+		"11.0.0.0":        false,
+		"192.168.1.1":     false,
+		"192.168.2.255":   true,
+		"2001:db8::1":     true,
+		"2001:db9::":      false,
+		"::ffff:10.0.0.1": false,
+	} {
+		if contains := set.Contains(netip.MustParseAddr(addr)); contains != expected {
+			t.Errorf("expected Contains(%s) to be %t", addr, expected)
+		}
+	}
+
+	for prefix, expected := range map[string]bool{
+		"10.1.0.0/16":      true,
+		"10.0.0.0/8":       true,
+		"10.0.0.0/7":       false,
+		"192.168.0.0/22":   false,
+		"192.168.2.128/25": true,
+		"2001:db8::/48":    true,
+	} {
+		if contains := set.ContainsPrefix(netip.MustParsePrefix(prefix)); contains != expected {
+			t.Errorf("expected ContainsPrefix(%s) to be %t", prefix, expected)
+		}
+	}
+
+	for prefix, expected := range map[string]bool{
+		"0.0.0.0/0":      true,
+		"192.168.1.0/24": false,
+		"192.168.0.0/22": true,
+		"11.0.0.0/8":     false,
+		"::/0":           true,
+		"fd00::/8":       false,
+	} {
+		if overlaps := set.Overlaps(netip.MustParsePrefix(prefix)); overlaps != expected {
+			t.Errorf("expected Overlaps(%s) to be %t", prefix, expected)
+		}
+	}
+}
+
+func TestSubnetsSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		cidrs    []string
+		supernet string
+		excluded []netip.Prefix
+	}{
+		"No subnets":       {nil, "10.0.0.0/8", nil},
+		"Disjoint subnets": {[]string{"10.0.0.0/24", "10.0.1.0/24", "10.1.0.0/16"}, "10.0.0.0/8", nil},
+		"Around excluded":  {[]string{"10.0.0.0/24", "10.0.2.0/24"}, "10.0.0.0/16", []netip.Prefix{netip.MustParsePrefix("10.0.1.0/24")}},
+		"Whole supernet":   {[]string{"192.168.0.0/16"}, "192.168.0.0/16", nil},
+		"IPv6 subnets":     {[]string{"fd00:1::/64", "fd00:1:0:1::/64"}, "fd00:1::/48", nil},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.Subnets(tc.cidrs, netip.MustParsePrefix(tc.supernet), tc.excluded...); err != nil {
+				t.Errorf("expected no error for valid subnets %v, but got error: %v", tc.cidrs, err)
+			}
+		})
+	}
+}
+
+func TestSubnetsFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		cidrs    []string
+		supernet netip.Prefix
+		excluded []netip.Prefix
+	}{
+		"Zero supernet":        {[]string{"10.0.0.0/24"}, netip.Prefix{}, nil},
+		"Outside supernet":     {[]string{"11.0.0.0/24"}, netip.MustParsePrefix("10.0.0.0/8"), nil},
+		"Overlapping subnets":  {[]string{"10.0.0.0/16", "10.0.1.0/24"}, netip.MustParsePrefix("10.0.0.0/8"), nil},
+		"Duplicate subnets":    {[]string{"10.0.0.0/24", "10.0.0.0/24"}, netip.MustParsePrefix("10.0.0.0/8"), nil},
+		"Overlapping excluded": {[]string{"10.0.0.0/16"}, netip.MustParsePrefix("10.0.0.0/8"), []netip.Prefix{netip.MustParsePrefix("10.0.5.0/24")}},
+		"Host bits set":        {[]string{"10.0.0.1/24"}, netip.MustParsePrefix("10.0.0.0/8"), nil},
+		"Invalid excluded":     {[]string{"10.0.0.0/24"}, netip.MustParsePrefix("10.0.0.0/8"), []netip.Prefix{{}}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.Subnets(tc.cidrs, tc.supernet, tc.excluded...); err == nil {
+				t.Errorf("expected error for invalid subnets %v, but got nil", tc.cidrs)
+			}
+		})
+	}
+}
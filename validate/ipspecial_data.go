@@ -0,0 +1,66 @@
+package validate
+
+import "net/netip"
+
+// IPv4SpecialPurposeRegistry is a copy of the IANA IPv4 Special-Purpose Address Registry from
+// https://www.iana.org/assignments/iana-ipv4-special-registry, with the multicast block 224.0.0.0/4 added
+// from https://www.iana.org/assignments/multicast-addresses.
+var IPv4SpecialPurposeRegistry = []IPSpecialPurpose{
+	{Prefix: netip.MustParsePrefix("0.0.0.0/8"), Name: "This network", RFC: "RFC 791, Section 3.2", Class: IPClassReserved, Source: true, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: true},
+	{Prefix: netip.MustParsePrefix("0.0.0.0/32"), Name: "This host on this network", RFC: "RFC 1122, Section 3.2.1.3", Class: IPClassReserved, Source: true, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: true},
+	{Prefix: netip.MustParsePrefix("10.0.0.0/8"), Name: "Private-Use", RFC: "RFC 1918", Class: IPClassPrivate, Source: true, Destination: true, Forwardable: true, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("100.64.0.0/10"), Name: "Shared Address Space", RFC: "RFC 6598", Class: IPClassCGNAT, Source: true, Destination: true, Forwardable: true, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("127.0.0.0/8"), Name: "Loopback", RFC: "RFC 1122, Section 3.2.1.3", Class: IPClassLoopback, Source: false, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: true},
+	{Prefix: netip.MustParsePrefix("169.254.0.0/16"), Name: "Link Local", RFC: "RFC 3927", Class: IPClassLinkLocal, Source: true, Destination: true, Forwardable: false, Global: false, ReservedByProtocol: true},
+	{Prefix: netip.MustParsePrefix("172.16.0.0/12"), Name: "Private-Use", RFC: "RFC 1918", Class: IPClassPrivate, Source: true, Destination: true, Forwardable: true, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("192.0.0.0/24"), Name: "IETF Protocol Assignments", RFC: "RFC 6890, Section 2.1", Class: IPClassReserved, Source: false, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("192.0.0.0/29"), Name: "IPv4 Service Continuity Prefix", RFC: "RFC 7335", Class: IPClassReserved, Source: true, Destination: true, Forwardable: true, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("192.0.0.8/32"), Name: "IPv4 dummy address", RFC: "RFC 7600", Class: IPClassReserved, Source: true, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("192.0.0.9/32"), Name: "Port Control Protocol Anycast", RFC: "RFC 7723", Class: IPClassGlobal, Source: true, Destination: true, Forwardable: true, Global: true, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("192.0.0.10/32"), Name: "Traversal Using Relays around NAT Anycast", RFC: "RFC 8155", Class: IPClassGlobal, Source: true, Destination: true, Forwardable: true, Global: true, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("192.0.0.170/32"), Name: "NAT64/DNS64 Discovery", RFC: "RFC 8880, RFC 7050, Section 2.2", Class: IPClassReserved, Source: false, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: true},
+	{Prefix: netip.MustParsePrefix("192.0.0.171/32"), Name: "NAT64/DNS64 Discovery", RFC: "RFC 8880, RFC 7050, Section 2.2", Class: IPClassReserved, Source: false, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: true},
+	{Prefix: netip.MustParsePrefix("192.0.2.0/24"), Name: "Documentation (TEST-NET-1)", RFC: "RFC 5737", Class: IPClassDocumentation, Source: false, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("192.31.196.0/24"), Name: "AS112-v4", RFC: "RFC 7535", Class: IPClassGlobal, Source: true, Destination: true, Forwardable: true, Global: true, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("192.52.193.0/24"), Name: "AMT", RFC: "RFC 7450", Class: IPClassGlobal, Source: true, Destination: true, Forwardable: true, Global: true, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("192.88.99.0/24"), Name: "Deprecated (6to4 Relay Anycast)", RFC: "RFC 7526", Class: IPClassReserved, Source: false, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("192.168.0.0/16"), Name: "Private-Use", RFC: "RFC 1918", Class: IPClassPrivate, Source: true, Destination: true, Forwardable: true, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("192.175.48.0/24"), Name: "Direct Delegation AS112 Service", RFC: "RFC 7534", Class: IPClassGlobal, Source: true, Destination: true, Forwardable: true, Global: true, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("198.18.0.0/15"), Name: "Benchmarking", RFC: "RFC 2544", Class: IPClassReserved, Source: true, Destination: true, Forwardable: true, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("198.51.100.0/24"), Name: "Documentation (TEST-NET-2)", RFC: "RFC 5737", Class: IPClassDocumentation, Source: false, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("203.0.113.0/24"), Name: "Documentation (TEST-NET-3)", RFC: "RFC 5737", Class: IPClassDocumentation, Source: false, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("224.0.0.0/4"), Name: "Multicast", RFC: "RFC 5771", Class: IPClassMulticast, Source: false, Destination: true, Forwardable: true, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("240.0.0.0/4"), Name: "Reserved", RFC: "RFC 1112, Section 4", Class: IPClassReserved, Source: false, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: true},
+	{Prefix: netip.MustParsePrefix("255.255.255.255/32"), Name: "Limited Broadcast", RFC: "RFC 8190, RFC 919, Section 7", Class: IPClassReserved, Source: false, Destination: true, Forwardable: false, Global: false, ReservedByProtocol: true},
+}
+
+// IPv6SpecialPurposeRegistry is a copy of the IANA IPv6 Special-Purpose Address Registry from
+// https://www.iana.org/assignments/iana-ipv6-special-registry, with the multicast block ff00::/8 added
+// from https://www.iana.org/assignments/ipv6-address-space. Blocks whose "Globally Reachable" is N/A are not global.
+var IPv6SpecialPurposeRegistry = []IPSpecialPurpose{
+	{Prefix: netip.MustParsePrefix("::1/128"), Name: "Loopback Address", RFC: "RFC 4291", Class: IPClassLoopback, Source: false, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: true},
+	{Prefix: netip.MustParsePrefix("::/128"), Name: "Unspecified Address", RFC: "RFC 4291", Class: IPClassReserved, Source: true, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: true},
+	{Prefix: netip.MustParsePrefix("::ffff:0:0/96"), Name: "IPv4-mapped Address", RFC: "RFC 4291", Class: IPClassReserved, Source: false, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: true},
+	{Prefix: netip.MustParsePrefix("64:ff9b::/96"), Name: "IPv4-IPv6 Translation", RFC: "RFC 6052", Class: IPClassGlobal, Source: true, Destination: true, Forwardable: true, Global: true, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("64:ff9b:1::/48"), Name: "IPv4-IPv6 Translation", RFC: "RFC 8215", Class: IPClassReserved, Source: true, Destination: true, Forwardable: true, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("100::/64"), Name: "Discard-Only Address Block", RFC: "RFC 6666", Class: IPClassReserved, Source: true, Destination: true, Forwardable: true, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("2001::/23"), Name: "IETF Protocol Assignments", RFC: "RFC 2928", Class: IPClassReserved, Source: false, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("2001::/32"), Name: "TEREDO", RFC: "RFC 4380, RFC 8190", Class: IPClassReserved, Source: true, Destination: true, Forwardable: true, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("2001:1::1/128"), Name: "Port Control Protocol Anycast", RFC: "RFC 7723", Class: IPClassGlobal, Source: true, Destination: true, Forwardable: true, Global: true, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("2001:1::2/128"), Name: "Traversal Using Relays around NAT Anycast", RFC: "RFC 8155", Class: IPClassGlobal, Source: true, Destination: true, Forwardable: true, Global: true, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("2001:1::3/128"), Name: "DNS-SD Service Registration Protocol Anycast", RFC: "RFC 9665", Class: IPClassGlobal, Source: true, Destination: true, Forwardable: true, Global: true, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("2001:2::/48"), Name: "Benchmarking", RFC: "RFC 5180", Class: IPClassReserved, Source: true, Destination: true, Forwardable: true, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("2001:3::/32"), Name: "AMT", RFC: "RFC 7450", Class: IPClassGlobal, Source: true, Destination: true, Forwardable: true, Global: true, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("2001:4:112::/48"), Name: "AS112-v6", RFC: "RFC 7535", Class: IPClassGlobal, Source: true, Destination: true, Forwardable: true, Global: true, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("2001:10::/28"), Name: "Deprecated (previously ORCHID)", RFC: "RFC 4843", Class: IPClassReserved, Source: false, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("2001:20::/28"), Name: "ORCHIDv2", RFC: "RFC 7343", Class: IPClassGlobal, Source: true, Destination: true, Forwardable: true, Global: true, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("2001:30::/28"), Name: "Drone Remote ID Protocol Entity Tags (DETs) Prefix", RFC: "RFC 9374", Class: IPClassGlobal, Source: true, Destination: true, Forwardable: true, Global: true, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("2001:db8::/32"), Name: "Documentation", RFC: "RFC 3849", Class: IPClassDocumentation, Source: false, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("2002::/16"), Name: "6to4", RFC: "RFC 3056", Class: IPClassReserved, Source: true, Destination: true, Forwardable: true, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("2620:4f:8000::/48"), Name: "Direct Delegation AS112 Service", RFC: "RFC 7534", Class: IPClassGlobal, Source: true, Destination: true, Forwardable: true, Global: true, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("3fff::/20"), Name: "Documentation", RFC: "RFC 9637", Class: IPClassDocumentation, Source: false, Destination: false, Forwardable: false, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("5f00::/16"), Name: "Segment Routing (SRv6) SIDs", RFC: "RFC 9602", Class: IPClassReserved, Source: true, Destination: true, Forwardable: true, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("fc00::/7"), Name: "Unique-Local", RFC: "RFC 4193, RFC 8190", Class: IPClassPrivate, Source: true, Destination: true, Forwardable: true, Global: false, ReservedByProtocol: false},
+	{Prefix: netip.MustParsePrefix("fe80::/10"), Name: "Link-Local Unicast", RFC: "RFC 4291", Class: IPClassLinkLocal, Source: true, Destination: true, Forwardable: false, Global: false, ReservedByProtocol: true},
+	{Prefix: netip.MustParsePrefix("ff00::/8"), Name: "Multicast", RFC: "RFC 4291", Class: IPClassMulticast, Source: false, Destination: true, Forwardable: true, Global: false, ReservedByProtocol: false},
+}
@@ -0,0 +1,276 @@
+package validate
+
+import (
+	"crypto/ecdh"
+	"crypto/mlkem"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// jwkRequiredMembers holds the required public members of each supported JWK key type.
+var jwkRequiredMembers = map[string][]string{
+	"EC":  {"crv", "x", "y"},
+	"RSA": {"n", "e"},
+	"OKP": {"crv", "x"},
+	"AKP": {"alg", "pub"},
+}
+
+// jwkPrivateMembers holds the private members of each supported JWK key type. The first member is the one required in private keys.
+var jwkPrivateMembers = map[string][]string{
+	"EC":  {"d"},
+	"RSA": {"d", "p", "q", "dp", "dq", "qi", "oth"},
+	"OKP": {"d"},
+	"AKP": {"priv"},
+}
+
+// jwkCurveLength holds the coordinate or key length in bytes of each supported curve per key type.
+var jwkCurveLength = map[string]map[string]int{
+	"EC":  {"P-256": 32, "P-384": 48, "P-521": 66},
+	"OKP": {"Ed25519": 32, "X25519": 32},
+}
+
+// jwkECDHCurve holds the curves of "EC" keys used to check that points are on their curve.
+var jwkECDHCurve = map[string]ecdh.Curve{
+	"P-256": ecdh.P256(),
+	"P-384": ecdh.P384(),
+	"P-521": ecdh.P521(),
+}
+
+// jwkAKPLength holds the public and private key lengths of each supported "AKP" algorithm.
+var jwkAKPLength = map[string][2]int{
+	"ML-KEM-768":  {mlkem.EncapsulationKeySize768, mlkem.SeedSize},
+	"ML-KEM-1024": {mlkem.EncapsulationKeySize1024, mlkem.SeedSize},
+}
+
+// jwkAlgCurve holds the key type and curve each JWS or JWE algorithm requires. An empty curve accepts every curve of the key type.
+var jwkAlgCurve = map[string][2]string{
+	"EdDSA":       {"OKP", "Ed25519"},
+	"ES256":       {"EC", "P-256"},
+	"ES384":       {"EC", "P-384"},
+	"ES512":       {"EC", "P-521"},
+	"RS256":       {"RSA", ""},
+	"RS384":       {"RSA", ""},
+	"RS512":       {"RSA", ""},
+	"PS256":       {"RSA", ""},
+	"PS384":       {"RSA", ""},
+	"PS512":       {"RSA", ""},
+	"ECDH-ES":     {"", ""},
+	"ML-KEM-768":  {"AKP", ""},
+	"ML-KEM-1024": {"AKP", ""},
+}
+
+// JWK validates the structure of a JSON Web Key.
+//
+// The JWK must:
+//   - Be a JSON object with a supported key type ("kty"): "EC", "RSA", "OKP" or "AKP".
+//   - Have the required members of its key type as unpadded base64url strings, with coordinates and keys of the length of the curve or algorithm.
+//   - Have a point on its curve for "EC" keys.
+//   - Have an "alg", if present, consistent with its key type and curve ("crv"), and a "use", if present, of "sig" or "enc".
+//   - Have its private members if private is true, and no private members otherwise. Private RSA keys must have all CRT members (p, q, dp, dq and qi).
+//
+// Multi-prime RSA keys ("oth") are not supported.
+func JWK(data []byte, private bool) error {
+	var members map[string]any
+	if err := json.Unmarshal(data, &members); err != nil {
+		return fmt.Errorf("invalid JWK JSON object: %w", err)
+	}
+
+	return jwkMembers(members, private)
+}
+
+// JWKS validates a JSON Web Key Set, i.e. a JSON object with a "keys" array of JWKs which are all validated with JWK.
+// Key IDs ("kid") must be unique within the set.
+func JWKS(data []byte, private bool) error {
+	var set struct {
+		Keys *[]map[string]any `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("invalid JWKS JSON object: %w", err)
+	}
+
+	if set.Keys == nil {
+		return errors.New("missing JWKS member \"keys\"")
+	}
+
+	var errs []error
+	kids := make(map[string]int)
+
+	for i, members := range *set.Keys {
+		if err := jwkMembers(members, private); err != nil {
+			errs = append(errs, fmt.Errorf("invalid key %d: %w", i, err))
+		}
+
+		if kid, ok := members["kid"].(string); ok {
+			if j, ok := kids[kid]; ok {
+				errs = append(errs, fmt.Errorf("key %d has the same key ID \"%s\" as key %d", i, kid, j))
+			}
+			kids[kid] = i
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func jwkMembers(members map[string]any, private bool) error {
+	kty, err := jwkString(members, "kty")
+	if err != nil {
+		return err
+	}
+
+	required, ok := jwkRequiredMembers[kty]
+	if !ok {
+		return fmt.Errorf("unsupported key type \"%s\"", kty)
+	}
+
+	var errs []error
+
+	for _, name := range []string{"kid", "use", "alg", "crv"} {
+		if _, ok := members[name]; ok {
+			if _, err := jwkString(members, name); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	decoded := make(map[string][]byte)
+
+	for _, name := range required {
+		if name == "alg" || name == "crv" {
+			if _, err := jwkString(members, name); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		b, err := jwkBase64URL(members, name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		decoded[name] = b
+	}
+
+	privateMembers := jwkPrivateMembers[kty]
+
+	if _, ok := members["oth"]; ok {
+		errs = append(errs, errors.New("multi-prime RSA keys not supported"))
+	}
+
+	if private {
+		names := privateMembers[:1]
+		if kty == "RSA" {
+			names = privateMembers[:len(privateMembers)-1]
+		}
+		for _, name := range names {
+			b, err := jwkBase64URL(members, name)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			decoded[name] = b
+		}
+	} else {
+		for _, name := range privateMembers {
+			if _, ok := members[name]; ok {
+				errs = append(errs, fmt.Errorf("private member \"%s\" in public key", name))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	crv, _ := members["crv"].(string)
+	alg, _ := members["alg"].(string)
+
+	if use, ok := members["use"].(string); ok && use != "sig" && use != "enc" {
+		errs = append(errs, fmt.Errorf("unsupported use \"%s\"", use))
+	}
+
+	if alg != "" {
+		algCurve, ok := jwkAlgCurve[alg]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("unsupported algorithm \"%s\"", alg))
+		case algCurve[0] != "" && algCurve[0] != kty:
+			errs = append(errs, fmt.Errorf("algorithm \"%s\" not consistent with key type \"%s\"", alg, kty))
+		case algCurve[1] != "" && algCurve[1] != crv:
+			errs = append(errs, fmt.Errorf("algorithm \"%s\" not consistent with curve \"%s\"", alg, crv))
+		case alg == "ECDH-ES" && kty != "EC" && crv != "X25519":
+			errs = append(errs, fmt.Errorf("algorithm \"%s\" not consistent with key type \"%s\" and curve \"%s\"", alg, kty, crv))
+		}
+	}
+
+	switch kty {
+	case "EC", "OKP":
+		length, ok := jwkCurveLength[kty][crv]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unsupported curve \"%s\" for key type \"%s\"", crv, kty))
+			break
+		}
+		for _, name := range []string{"x", "y", "d"} {
+			if b, ok := decoded[name]; ok && len(b) != length {
+				errs = append(errs, fmt.Errorf("member \"%s\" of %d bytes, expected %d for curve %s", name, len(b), length, crv))
+			}
+		}
+		if len(errs) == 0 && kty == "EC" {
+			point := append(append([]byte{4}, decoded["x"]...), decoded["y"]...)
+			if _, err := jwkECDHCurve[crv].NewPublicKey(point); err != nil {
+				errs = append(errs, fmt.Errorf("point not on curve %s", crv))
+			}
+		}
+	case "RSA":
+		if len(decoded["n"]) == 0 || decoded["n"][0] == 0 {
+			errs = append(errs, errors.New("modulus not in minimal big-endian form"))
+		}
+		if len(decoded["e"]) == 0 || len(decoded["e"]) > 4 || decoded["e"][0] == 0 {
+			errs = append(errs, errors.New("public exponent not in minimal big-endian form or too large"))
+		}
+	case "AKP":
+		lengths, ok := jwkAKPLength[alg]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unsupported algorithm \"%s\" for key type \"AKP\"", alg))
+			break
+		}
+		if len(decoded["pub"]) != lengths[0] {
+			errs = append(errs, fmt.Errorf("member \"pub\" of %d bytes, expected %d for %s", len(decoded["pub"]), lengths[0], alg))
+		}
+		if b, ok := decoded["priv"]; ok && len(b) != lengths[1] {
+			errs = append(errs, fmt.Errorf("member \"priv\" of %d bytes, expected %d for %s", len(b), lengths[1], alg))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// jwkString returns the member of the specified name if it is a non-empty string.
+func jwkString(members map[string]any, name string) (string, error) {
+	value, ok := members[name]
+	if !ok {
+		return "", fmt.Errorf("missing member \"%s\"", name)
+	}
+
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return "", fmt.Errorf("member \"%s\" not a non-empty string", name)
+	}
+
+	return s, nil
+}
+
+// jwkBase64URL decodes the member of the specified name as unpadded base64url.
+func jwkBase64URL(members map[string]any, name string) ([]byte, error) {
+	s, err := jwkString(members, name)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := base64.RawURLEncoding.Strict().DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("member \"%s\" not unpadded base64url: %w", name, err)
+	}
+
+	return b, nil
+}
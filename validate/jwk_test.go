@@ -0,0 +1,133 @@
+package validate_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+)
+
+// Examples of RFC 8037, appendix A.1 and A.2, and RFC 7517, appendix A.1 and A.2.
+const (
+	jwkEd25519X  = "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"
+	jwkEd25519D  = "nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A"
+	jwkP256X     = "MKBCTNIcKUSDii11ySs3526iDZ8AiTo7Tu6KPAqv7D4"
+	jwkP256Y     = "4Etl6SRW2YiLUrN5vfvVHuhp7x8PxltmWWlbbM4IFyM"
+	jwkP256D     = "870MB6gfuTJ4HtUnUvYMyJpr5eUZNP4Bk43bVdj3eAE"
+	jwkRSAN      = "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"
+	jwkX25519X   = "hSDwCYkwp1R0i33ctD73Wg2_Og0mOBr066SpjqqbTmo"
+	jwkPublicEC  = `{"kty":"EC","crv":"P-256","x":"` + jwkP256X + `","y":"` + jwkP256Y + `","use":"enc","kid":"1"}`
+	jwkPublicRSA = `{"kty":"RSA","n":"` + jwkRSAN + `","e":"AQAB","alg":"RS256","kid":"2011-04-29"}`
+)
+
+func TestJWKSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		jwk     string
+		private bool
+	}{
+		"Public Ed25519":  {`{"kty":"OKP","crv":"Ed25519","x":"` + jwkEd25519X + `","alg":"EdDSA"}`, false},
+		"Private Ed25519": {`{"kty":"OKP","crv":"Ed25519","x":"` + jwkEd25519X + `","d":"` + jwkEd25519D + `"}`, true},
+		"Public X25519":   {`{"kty":"OKP","crv":"X25519","x":"` + jwkX25519X + `","alg":"ECDH-ES","use":"enc"}`, false},
+		"Public P-256":    {jwkPublicEC, false},
+		"Private P-256":   {`{"kty":"EC","crv":"P-256","x":"` + jwkP256X + `","y":"` + jwkP256Y + `","d":"` + jwkP256D + `","alg":"ES256"}`, true},
+		"Public RSA":      {jwkPublicRSA, false},
+		"Public ML-KEM":   {`{"kty":"AKP","alg":"ML-KEM-768","pub":"` + jwkZeros(1184) + `"}`, false},
+		"Private ML-KEM":  {`{"kty":"AKP","alg":"ML-KEM-1024","pub":"` + jwkZeros(1568) + `","priv":"` + jwkZeros(64) + `"}`, true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.JWK([]byte(tc.jwk), tc.private); err != nil {
+				t.Errorf("expected no error for valid JWK %s, but got error: %v", tc.jwk, err)
+			}
+		})
+	}
+}
+
+func TestJWKFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		jwk     string
+		private bool
+	}{
+		"Not JSON":                  {`not JSON`, false},
+		"Missing key type":          {`{"crv":"Ed25519","x":"` + jwkEd25519X + `"}`, false},
+		"Unsupported key type":      {`{"kty":"oct","k":"AAAA"}`, false},
+		"Missing x":                 {`{"kty":"OKP","crv":"Ed25519"}`, false},
+		"Missing y":                 {`{"kty":"EC","crv":"P-256","x":"` + jwkP256X + `"}`, false},
+		"Missing modulus":           {`{"kty":"RSA","e":"AQAB"}`, false},
+		"Padded base64url":          {`{"kty":"OKP","crv":"Ed25519","x":"` + jwkEd25519X + `="}`, false},
+		"Standard base64":           {`{"kty":"RSA","n":"ab+/","e":"AQAB"}`, false},
+		"Unsupported curve":         {`{"kty":"OKP","crv":"Ed448","x":"` + jwkEd25519X + `"}`, false},
+		"Coordinate length":         {`{"kty":"EC","crv":"P-384","x":"` + jwkP256X + `","y":"` + jwkP256Y + `"}`, false},
+		"Point not on curve":        {`{"kty":"EC","crv":"P-256","x":"` + jwkP256Y + `","y":"` + jwkP256X + `"}`, false},
+		"Algorithm of other curve":  {`{"kty":"EC","crv":"P-256","x":"` + jwkP256X + `","y":"` + jwkP256Y + `","alg":"ES384"}`, false},
+		"Algorithm of other type":   {`{"kty":"OKP","crv":"Ed25519","x":"` + jwkEd25519X + `","alg":"RS256"}`, false},
+		"EdDSA with X25519":         {`{"kty":"OKP","crv":"X25519","x":"` + jwkX25519X + `","alg":"EdDSA"}`, false},
+		"Unsupported use":           {`{"kty":"OKP","crv":"Ed25519","x":"` + jwkEd25519X + `","use":"wrap"}`, false},
+		"Private member in public":  {`{"kty":"OKP","crv":"Ed25519","x":"` + jwkEd25519X + `","d":"` + jwkEd25519D + `"}`, false},
+		"Missing private member":    {`{"kty":"OKP","crv":"Ed25519","x":"` + jwkEd25519X + `"}`, true},
+		"RSA without CRT members":   {`{"kty":"RSA","n":"` + jwkRSAN + `","e":"AQAB","d":"AQAB"}`, true},
+		"Multi-prime RSA":           {`{"kty":"RSA","n":"` + jwkRSAN + `","e":"AQAB","oth":[]}`, false},
+		"Non-string member":         {`{"kty":"OKP","crv":"Ed25519","x":42}`, false},
+		"Unsupported AKP algorithm": {`{"kty":"AKP","alg":"ML-DSA-44","pub":"` + jwkZeros(1312) + `"}`, false},
+		"ML-KEM public key length":  {`{"kty":"AKP","alg":"ML-KEM-768","pub":"` + jwkZeros(1568) + `"}`, false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.JWK([]byte(tc.jwk), tc.private); err == nil {
+				t.Errorf("expected error for invalid JWK %q, but got nil", name)
+			}
+		})
+	}
+}
+
+func TestJWKSSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"Empty set":     `{"keys":[]}`,
+		"Two keys":      `{"keys":[` + jwkPublicEC + `,` + jwkPublicRSA + `]}`,
+		"Extra members": `{"keys":[` + jwkPublicEC + `],"cache":"max-age=3600"}`,
+	}
+
+	for name, jwks := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.JWKS([]byte(jwks), false); err != nil {
+				t.Errorf("expected no error for valid JWKS, but got error: %v", err)
+			}
+		})
+	}
+}
+
+func TestJWKSFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"Not JSON":          `[]`,
+		"Missing keys":      `{}`,
+		"Invalid key":       `{"keys":[{"kty":"EC"}]}`,
+		"Duplicate key IDs": `{"keys":[` + jwkPublicEC + `,` + jwkPublicEC + `]}`,
+	}
+
+	for name, jwks := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.JWKS([]byte(jwks), false); err == nil {
+				t.Errorf("expected error for invalid JWKS %q, but got nil", name)
+			}
+		})
+	}
+}
+
+// jwkZeros returns the base64url encoding of n zero bytes.
+func jwkZeros(n int) string {
+	return base64.RawURLEncoding.EncodeToString(make([]byte, n))
+}
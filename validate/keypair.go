@@ -1,46 +1,93 @@
 package validate
 
 import (
+	"bytes"
 	"crypto"
+	"crypto/ecdh"
 	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/mlkem"
+	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 )
 
-// Algorithm defines the supported key generation algorithms.
-type Algorithm int
-
-// List of supported algorithms for key generation.
-const (
-	AlgorithmUntyped Algorithm = iota
-	AlgorithmED25519
-	AlgorithmECDSAP521
-	AlgorithmECDSAP384
-	AlgorithmECDSAP256
-	AlgorithmECDSAP224
-	AlgorithmRSA4096
-	AlgorithmRSA2048
-	AlgorithmRSA1024
-)
+var algorithmCurve = map[Algorithm]elliptic.Curve{
+	AlgorithmECDSAP521: elliptic.P521(),
+	AlgorithmECDSAP384: elliptic.P384(),
+	AlgorithmECDSAP256: elliptic.P256(),
+	AlgorithmECDSAP224: elliptic.P224(),
+}
+
+var algorithmECDHCurve = map[Algorithm]ecdh.Curve{
+	AlgorithmX25519:   ecdh.X25519(),
+	AlgorithmECDHP256: ecdh.P256(),
+	AlgorithmECDHP384: ecdh.P384(),
+}
+
+// algorithmCurveBits holds the size of the NIST curves used by ECDSA and ECDH algorithms.
+var algorithmCurveBits = map[Algorithm]int{
+	AlgorithmECDSAP521: 521,
+	AlgorithmECDSAP384: 384,
+	AlgorithmECDSAP256: 256,
+	AlgorithmECDSAP224: 224,
+	AlgorithmECDHP384:  384,
+	AlgorithmECDHP256:  256,
+}
 
-var algorithmString = map[Algorithm]string{
-	AlgorithmUntyped:   "untyped",
-	AlgorithmED25519:   "ED25519",
-	AlgorithmECDSAP521: "ECDSA P521",
-	AlgorithmECDSAP384: "ECDSA P384",
-	AlgorithmECDSAP256: "ECDSA P256",
-	AlgorithmECDSAP224: "ECDSA P224",
-	AlgorithmRSA4096:   "RSA 4096",
-	AlgorithmRSA2048:   "RSA 2048",
-	AlgorithmRSA1024:   "RSA 1024",
+var algorithmMLKEMSize = map[Algorithm]int{
+	AlgorithmMLKEM1024: 1024,
+	AlgorithmMLKEM768:  768,
 }
 
-func (a Algorithm) String() string {
-	return algorithmString[a]
+var algorithmRSABits = map[Algorithm]int{
+	AlgorithmRSA4096: 4096,
+	AlgorithmRSA2048: 2048,
+	AlgorithmRSA1024: 1024,
 }
 
+// keyPairTestMessage is signed with the private key and verified with the public key to make sure the key pair actually works.
+var keyPairTestMessage = []byte("github.com/copartner6412/input/validate KeyPair")
+
+// KeyPolicy defines the minimum key strength accepted by KeyPairFor.
+// Curve sizes apply to both ECDSA and ECDH on NIST curves, and RejectCurve25519 applies to both ED25519 and X25519.
+// The zero value accepts every supported algorithm, like KeyPolicyLegacy.
+type KeyPolicy struct {
+	MinRSABits       int  // Minimum RSA modulus size in bits.
+	MinCurveBits     int  // Minimum NIST curve size in bits.
+	MinMLKEMSize     int  // Minimum ML-KEM parameter set, 768 or 1024.
+	RejectCurve25519 bool // Reject ED25519 and X25519 keys.
+}
+
+var (
+	// Key policy accepting every supported algorithm:
+	//  - MinRSABits: 1024
+	//  - MinCurveBits: 224
+	//  - MinMLKEMSize: 768
+	//  - RejectCurve25519: false
+	KeyPolicyLegacy = KeyPolicy{MinRSABits: 1024, MinCurveBits: 224, MinMLKEMSize: 768, RejectCurve25519: false}
+	// Key policy rejecting RSA 1024 and ECDSA P224:
+	//  - MinRSABits: 2048
+	//  - MinCurveBits: 256
+	//  - MinMLKEMSize: 768
+	//  - RejectCurve25519: false
+	KeyPolicyModern = KeyPolicy{MinRSABits: 2048, MinCurveBits: 256, MinMLKEMSize: 768, RejectCurve25519: false}
+	// Key policy following the CNSA suites, accepting only RSA 4096, ECDSA and ECDH on P384 or P521, and ML-KEM 1024:
+	//  - MinRSABits: 3072
+	//  - MinCurveBits: 384
+	//  - MinMLKEMSize: 1024
+	//  - RejectCurve25519: true
+	KeyPolicyStrict = KeyPolicy{MinRSABits: 3072, MinCurveBits: 384, MinMLKEMSize: 1024, RejectCurve25519: true}
+)
+
+// KeyPair validates that the public and private key belong to each other and to the specified algorithm.
+// It checks the key types, the curve of ECDSA and ECDH keys and the modulus size of RSA keys, and validates RSA private keys.
+// For signature algorithms it makes sure a signature created with the private key is verified by the public key,
+// and for key agreement algorithms it makes sure the public key derives from the private key and both sides agree on a shared secret.
+// AlgorithmUntyped is treated as AlgorithmED25519.
 func KeyPair(algorithm Algorithm, publicKey crypto.PublicKey, privateKey crypto.PrivateKey) error {
 	var nilErrs []error
 	if publicKey == nil {
@@ -57,55 +104,326 @@ func KeyPair(algorithm Algorithm, publicKey crypto.PublicKey, privateKey crypto.
 
 	switch algorithm {
 	case AlgorithmUntyped, AlgorithmED25519:
-		privateKey, ok := privateKey.(ed25519.PrivateKey)
-		if !ok {
-			return fmt.Errorf("different algorithm type for private key, expected ED25519 but it's %v", algorithm.String())
-		}
+		return ed25519KeyPair(publicKey, privateKey)
+	case AlgorithmECDSAP521, AlgorithmECDSAP384, AlgorithmECDSAP256, AlgorithmECDSAP224:
+		return ecdsaKeyPair(algorithm, publicKey, privateKey)
+	case AlgorithmRSA4096, AlgorithmRSA2048, AlgorithmRSA1024:
+		return rsaKeyPair(algorithm, publicKey, privateKey)
+	case AlgorithmX25519, AlgorithmECDHP256, AlgorithmECDHP384:
+		return ecdhKeyPair(algorithm, publicKey, privateKey)
+	case AlgorithmMLKEM768:
+		return mlkem768KeyPair(publicKey, privateKey)
+	case AlgorithmMLKEM1024:
+		return mlkem1024KeyPair(publicKey, privateKey)
+	default:
+		return fmt.Errorf("unsupported algorithm type")
+	}
+}
 
-		publicKey, ok := publicKey.(ed25519.PublicKey)
-		if !ok {
-			return fmt.Errorf("different algorithm type for public key, expected ED25519 but it's %v", algorithm.String())
-		}
+// KeyPairFor validates a key pair with KeyPair after making sure the algorithm is accepted by the specified key policy.
+func KeyPairFor(algorithm Algorithm, publicKey crypto.PublicKey, privateKey crypto.PrivateKey, policy KeyPolicy) error {
+	if err := policy.allows(algorithm); err != nil {
+		return err
+	}
+
+	return KeyPair(algorithm, publicKey, privateKey)
+}
 
-		match := privateKey.Public().(ed25519.PublicKey).Equal(publicKey)
-		if !match {
-			return fmt.Errorf("private and public key don't match with each other")
+// DetectAlgorithm returns the algorithm matching the type, curve or modulus size of a public key.
+func DetectAlgorithm(publicKey crypto.PublicKey) (Algorithm, error) {
+	switch publicKey := publicKey.(type) {
+	case ed25519.PublicKey:
+		if len(publicKey) != ed25519.PublicKeySize {
+			return AlgorithmUntyped, fmt.Errorf("invalid ED25519 public key length %d, expected %d", len(publicKey), ed25519.PublicKeySize)
 		}
-	case AlgorithmECDSAP521, AlgorithmECDSAP384, AlgorithmECDSAP256, AlgorithmECDSAP224:
-		privateKey, ok := privateKey.(*ecdsa.PrivateKey)
-		if !ok {
-			return fmt.Errorf("different algorithm type for private key, expected ED25519 but it's %v", algorithm.String())
+		return AlgorithmED25519, nil
+	case *ecdsa.PublicKey:
+		if publicKey == nil || publicKey.Curve == nil {
+			return AlgorithmUntyped, errors.New("nil ECDSA public key")
 		}
-
-		publicKey, ok := publicKey.(*ecdsa.PublicKey)
-		if !ok {
-			return fmt.Errorf("different algorithm type for public key, expected ED25519 but it's %v", algorithm.String())
+		for algorithm, curve := range algorithmCurve {
+			if publicKey.Curve == curve {
+				return algorithm, nil
+			}
 		}
-
-		match := privateKey.PublicKey.Equal(publicKey)
-		if !match {
-			return fmt.Errorf("private and public key don't match with each other")
+		return AlgorithmUntyped, fmt.Errorf("unsupported ECDSA curve %s", publicKey.Curve.Params().Name)
+	case *rsa.PublicKey:
+		if publicKey == nil || publicKey.N == nil {
+			return AlgorithmUntyped, errors.New("nil RSA public key")
 		}
-
-	case AlgorithmRSA4096, AlgorithmRSA2048, AlgorithmRSA1024:
-		privateKey, ok := privateKey.(*rsa.PrivateKey)
-		if !ok {
-			return fmt.Errorf("different algorithm type for private key, expected ED25519 but it's %v", algorithm.String())
+		for algorithm, bits := range algorithmRSABits {
+			if publicKey.N.BitLen() == bits {
+				return algorithm, nil
+			}
 		}
-
-		publicKey, ok = publicKey.(*rsa.PublicKey)
-		if !ok {
-			return fmt.Errorf("different algorithm type for public key, expected ED25519 but it's %v", algorithm.String())
+		return AlgorithmUntyped, fmt.Errorf("unsupported RSA modulus size of %d bits", publicKey.N.BitLen())
+	case *ecdh.PublicKey:
+		if publicKey == nil {
+			return AlgorithmUntyped, errors.New("nil ECDH public key")
 		}
-
-		match := privateKey.PublicKey.Equal(publicKey)
-		if !match {
-			return fmt.Errorf("private and public key don't match with each other")
+		for algorithm, curve := range algorithmECDHCurve {
+			if publicKey.Curve() == curve {
+				return algorithm, nil
+			}
+		}
+		return AlgorithmUntyped, fmt.Errorf("unsupported ECDH curve %s", publicKey.Curve())
+	case *mlkem.EncapsulationKey768:
+		if publicKey == nil {
+			return AlgorithmUntyped, errors.New("nil ML-KEM 768 encapsulation key")
+		}
+		return AlgorithmMLKEM768, nil
+	case *mlkem.EncapsulationKey1024:
+		if publicKey == nil {
+			return AlgorithmUntyped, errors.New("nil ML-KEM 1024 encapsulation key")
 		}
+		return AlgorithmMLKEM1024, nil
+	default:
+		return AlgorithmUntyped, fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+}
 
+// allows returns an error if the algorithm is weaker than the key policy accepts.
+func (policy KeyPolicy) allows(algorithm Algorithm) error {
+	switch algorithm {
+	case AlgorithmUntyped, AlgorithmED25519, AlgorithmX25519:
+		if policy.RejectCurve25519 {
+			return fmt.Errorf("algorithm %s not allowed by key policy", algorithm)
+		}
+	case AlgorithmECDSAP521, AlgorithmECDSAP384, AlgorithmECDSAP256, AlgorithmECDSAP224, AlgorithmECDHP384, AlgorithmECDHP256:
+		if algorithmCurveBits[algorithm] < policy.MinCurveBits {
+			return fmt.Errorf("algorithm %s not allowed by key policy, minimum curve size is %d bits", algorithm, policy.MinCurveBits)
+		}
+	case AlgorithmRSA4096, AlgorithmRSA2048, AlgorithmRSA1024:
+		if algorithmRSABits[algorithm] < policy.MinRSABits {
+			return fmt.Errorf("algorithm %s not allowed by key policy, minimum RSA modulus size is %d bits", algorithm, policy.MinRSABits)
+		}
+	case AlgorithmMLKEM1024, AlgorithmMLKEM768:
+		if algorithmMLKEMSize[algorithm] < policy.MinMLKEMSize {
+			return fmt.Errorf("algorithm %s not allowed by key policy, minimum ML-KEM parameter set is ML-KEM %d", algorithm, policy.MinMLKEMSize)
+		}
 	default:
 		return fmt.Errorf("unsupported algorithm type")
 	}
 
 	return nil
 }
+
+func ed25519KeyPair(publicKey crypto.PublicKey, privateKey crypto.PrivateKey) error {
+	ed25519PrivateKey, ok := privateKey.(ed25519.PrivateKey)
+	if !ok {
+		return fmt.Errorf("different algorithm type for private key, expected %s but it's %T", AlgorithmED25519, privateKey)
+	}
+
+	ed25519PublicKey, ok := publicKey.(ed25519.PublicKey)
+	if !ok {
+		return fmt.Errorf("different algorithm type for public key, expected %s but it's %T", AlgorithmED25519, publicKey)
+	}
+
+	if len(ed25519PrivateKey) != ed25519.PrivateKeySize {
+		return fmt.Errorf("invalid ED25519 private key length %d, expected %d", len(ed25519PrivateKey), ed25519.PrivateKeySize)
+	}
+
+	if len(ed25519PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid ED25519 public key length %d, expected %d", len(ed25519PublicKey), ed25519.PublicKeySize)
+	}
+
+	if !ed25519PrivateKey.Public().(ed25519.PublicKey).Equal(ed25519PublicKey) {
+		return fmt.Errorf("private and public key don't match with each other")
+	}
+
+	signature := ed25519.Sign(ed25519PrivateKey, keyPairTestMessage)
+	if !ed25519.Verify(ed25519PublicKey, keyPairTestMessage, signature) {
+		return errors.New("signature created with the private key not verified by the public key")
+	}
+
+	return nil
+}
+
+func ecdsaKeyPair(algorithm Algorithm, publicKey crypto.PublicKey, privateKey crypto.PrivateKey) error {
+	ecdsaPrivateKey, ok := privateKey.(*ecdsa.PrivateKey)
+	if !ok || ecdsaPrivateKey == nil {
+		return fmt.Errorf("different algorithm type for private key, expected %s but it's %T", algorithm, privateKey)
+	}
+
+	ecdsaPublicKey, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok || ecdsaPublicKey == nil {
+		return fmt.Errorf("different algorithm type for public key, expected %s but it's %T", algorithm, publicKey)
+	}
+
+	curve := algorithmCurve[algorithm]
+
+	if ecdsaPrivateKey.Curve != curve {
+		return fmt.Errorf("different curve for private key, expected %s", algorithm)
+	}
+
+	if ecdsaPublicKey.Curve != curve {
+		return fmt.Errorf("different curve for public key, expected %s", algorithm)
+	}
+
+	if ecdsaPublicKey.X == nil || ecdsaPublicKey.Y == nil || !curve.IsOnCurve(ecdsaPublicKey.X, ecdsaPublicKey.Y) {
+		return errors.New("public key point not on curve")
+	}
+
+	if ecdsaPrivateKey.D == nil || ecdsaPrivateKey.D.Sign() <= 0 || ecdsaPrivateKey.D.Cmp(curve.Params().N) >= 0 {
+		return errors.New("private key scalar out of range")
+	}
+
+	if !ecdsaPrivateKey.PublicKey.Equal(ecdsaPublicKey) {
+		return fmt.Errorf("private and public key don't match with each other")
+	}
+
+	digest := sha256.Sum256(keyPairTestMessage)
+
+	signature, err := ecdsa.SignASN1(rand.Reader, ecdsaPrivateKey, digest[:])
+	if err != nil {
+		return fmt.Errorf("error signing with private key: %w", err)
+	}
+
+	if !ecdsa.VerifyASN1(ecdsaPublicKey, digest[:], signature) {
+		return errors.New("signature created with the private key not verified by the public key")
+	}
+
+	return nil
+}
+
+func rsaKeyPair(algorithm Algorithm, publicKey crypto.PublicKey, privateKey crypto.PrivateKey) error {
+	rsaPrivateKey, ok := privateKey.(*rsa.PrivateKey)
+	if !ok || rsaPrivateKey == nil {
+		return fmt.Errorf("different algorithm type for private key, expected %s but it's %T", algorithm, privateKey)
+	}
+
+	rsaPublicKey, ok := publicKey.(*rsa.PublicKey)
+	if !ok || rsaPublicKey == nil || rsaPublicKey.N == nil {
+		return fmt.Errorf("different algorithm type for public key, expected %s but it's %T", algorithm, publicKey)
+	}
+
+	if bits := rsaPublicKey.N.BitLen(); bits != algorithmRSABits[algorithm] {
+		return fmt.Errorf("RSA modulus size of %d bits, expected %s", bits, algorithm)
+	}
+
+	if err := rsaPrivateKey.Validate(); err != nil {
+		return fmt.Errorf("invalid RSA private key: %w", err)
+	}
+
+	if !rsaPrivateKey.PublicKey.Equal(rsaPublicKey) {
+		return fmt.Errorf("private and public key don't match with each other")
+	}
+
+	digest := sha256.Sum256(keyPairTestMessage)
+
+	signature, err := rsa.SignPSS(rand.Reader, rsaPrivateKey, crypto.SHA256, digest[:], nil)
+	if err != nil {
+		return fmt.Errorf("error signing with private key: %w", err)
+	}
+
+	if err := rsa.VerifyPSS(rsaPublicKey, crypto.SHA256, digest[:], signature, nil); err != nil {
+		return fmt.Errorf("signature created with the private key not verified by the public key: %w", err)
+	}
+
+	return nil
+}
+
+func ecdhKeyPair(algorithm Algorithm, publicKey crypto.PublicKey, privateKey crypto.PrivateKey) error {
+	ecdhPrivateKey, ok := privateKey.(*ecdh.PrivateKey)
+	if !ok || ecdhPrivateKey == nil {
+		return fmt.Errorf("different algorithm type for private key, expected %s but it's %T", algorithm, privateKey)
+	}
+
+	ecdhPublicKey, ok := publicKey.(*ecdh.PublicKey)
+	if !ok || ecdhPublicKey == nil {
+		return fmt.Errorf("different algorithm type for public key, expected %s but it's %T", algorithm, publicKey)
+	}
+
+	curve := algorithmECDHCurve[algorithm]
+
+	if ecdhPrivateKey.Curve() != curve {
+		return fmt.Errorf("different curve for private key, expected %s", algorithm)
+	}
+
+	if ecdhPublicKey.Curve() != curve {
+		return fmt.Errorf("different curve for public key, expected %s", algorithm)
+	}
+
+	if !ecdhPrivateKey.PublicKey().Equal(ecdhPublicKey) {
+		return fmt.Errorf("private and public key don't match with each other")
+	}
+
+	peerPrivateKey, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		return fmt.Errorf("error generating peer key: %w", err)
+	}
+
+	sharedSecret, err := ecdhPrivateKey.ECDH(peerPrivateKey.PublicKey())
+	if err != nil {
+		return fmt.Errorf("error agreeing on shared secret with private key: %w", err)
+	}
+
+	peerSharedSecret, err := peerPrivateKey.ECDH(ecdhPublicKey)
+	if err != nil {
+		return fmt.Errorf("error agreeing on shared secret with public key: %w", err)
+	}
+
+	if !bytes.Equal(sharedSecret, peerSharedSecret) {
+		return errors.New("shared secrets agreed with the private and public key don't match")
+	}
+
+	return nil
+}
+
+func mlkem768KeyPair(publicKey crypto.PublicKey, privateKey crypto.PrivateKey) error {
+	decapsulationKey, ok := privateKey.(*mlkem.DecapsulationKey768)
+	if !ok || decapsulationKey == nil {
+		return fmt.Errorf("different algorithm type for private key, expected %s but it's %T", AlgorithmMLKEM768, privateKey)
+	}
+
+	encapsulationKey, ok := publicKey.(*mlkem.EncapsulationKey768)
+	if !ok || encapsulationKey == nil {
+		return fmt.Errorf("different algorithm type for public key, expected %s but it's %T", AlgorithmMLKEM768, publicKey)
+	}
+
+	if !bytes.Equal(decapsulationKey.EncapsulationKey().Bytes(), encapsulationKey.Bytes()) {
+		return fmt.Errorf("private and public key don't match with each other")
+	}
+
+	sharedKey, ciphertext := encapsulationKey.Encapsulate()
+
+	decapsulatedKey, err := decapsulationKey.Decapsulate(ciphertext)
+	if err != nil {
+		return fmt.Errorf("error decapsulating with private key: %w", err)
+	}
+
+	if !bytes.Equal(sharedKey, decapsulatedKey) {
+		return errors.New("shared key encapsulated with the public key not recovered by the private key")
+	}
+
+	return nil
+}
+
+func mlkem1024KeyPair(publicKey crypto.PublicKey, privateKey crypto.PrivateKey) error {
+	decapsulationKey, ok := privateKey.(*mlkem.DecapsulationKey1024)
+	if !ok || decapsulationKey == nil {
+		return fmt.Errorf("different algorithm type for private key, expected %s but it's %T", AlgorithmMLKEM1024, privateKey)
+	}
+
+	encapsulationKey, ok := publicKey.(*mlkem.EncapsulationKey1024)
+	if !ok || encapsulationKey == nil {
+		return fmt.Errorf("different algorithm type for public key, expected %s but it's %T", AlgorithmMLKEM1024, publicKey)
+	}
+
+	if !bytes.Equal(decapsulationKey.EncapsulationKey().Bytes(), encapsulationKey.Bytes()) {
+		return fmt.Errorf("private and public key don't match with each other")
+	}
+
+	sharedKey, ciphertext := encapsulationKey.Encapsulate()
+
+	decapsulatedKey, err := decapsulationKey.Decapsulate(ciphertext)
+	if err != nil {
+		return fmt.Errorf("error decapsulating with private key: %w", err)
+	}
+
+	if !bytes.Equal(sharedKey, decapsulatedKey) {
+		return errors.New("shared key encapsulated with the public key not recovered by the private key")
+	}
+
+	return nil
+}
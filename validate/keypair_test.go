@@ -1 +1,222 @@
 package validate_test
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/mlkem"
+	"crypto/rand"
+	"crypto/rsa"
+	"math/big"
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+)
+
+func TestKeyPairSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	ed25519PublicKey, ed25519PrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	p521PrivateKey, _ := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	p384PrivateKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	p256PrivateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	p224PrivateKey, _ := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	rsa2048PrivateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	x25519PrivateKey, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	ecdhP256PrivateKey, _ := ecdh.P256().GenerateKey(rand.Reader)
+	ecdhP384PrivateKey, _ := ecdh.P384().GenerateKey(rand.Reader)
+	mlkem768DecapsulationKey, _ := mlkem.GenerateKey768()
+	mlkem1024DecapsulationKey, _ := mlkem.GenerateKey1024()
+
+	testCases := map[string]struct {
+		algorithm  validate.Algorithm
+		publicKey  crypto.PublicKey
+		privateKey crypto.PrivateKey
+	}{
+		"X25519":      {validate.AlgorithmX25519, x25519PrivateKey.PublicKey(), x25519PrivateKey},
+		"ECDH P256":   {validate.AlgorithmECDHP256, ecdhP256PrivateKey.PublicKey(), ecdhP256PrivateKey},
+		"ECDH P384":   {validate.AlgorithmECDHP384, ecdhP384PrivateKey.PublicKey(), ecdhP384PrivateKey},
+		"ML-KEM 768":  {validate.AlgorithmMLKEM768, mlkem768DecapsulationKey.EncapsulationKey(), mlkem768DecapsulationKey},
+		"ML-KEM 1024": {validate.AlgorithmMLKEM1024, mlkem1024DecapsulationKey.EncapsulationKey(), mlkem1024DecapsulationKey},
+		"Untyped":     {validate.AlgorithmUntyped, ed25519PublicKey, ed25519PrivateKey},
+		"ED25519":     {validate.AlgorithmED25519, ed25519PublicKey, ed25519PrivateKey},
+		"ECDSA P521":  {validate.AlgorithmECDSAP521, &p521PrivateKey.PublicKey, p521PrivateKey},
+		"ECDSA P384":  {validate.AlgorithmECDSAP384, &p384PrivateKey.PublicKey, p384PrivateKey},
+		"ECDSA P256":  {validate.AlgorithmECDSAP256, &p256PrivateKey.PublicKey, p256PrivateKey},
+		"ECDSA P224":  {validate.AlgorithmECDSAP224, &p224PrivateKey.PublicKey, p224PrivateKey},
+		"RSA 2048":    {validate.AlgorithmRSA2048, &rsa2048PrivateKey.PublicKey, rsa2048PrivateKey},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := validate.KeyPair(tc.algorithm, tc.publicKey, tc.privateKey)
+			if err != nil {
+				t.Errorf("expected no error for valid %s key pair, but got error: %v", name, err)
+			}
+		})
+	}
+}
+
+func TestKeyPairFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	ed25519PublicKey, ed25519PrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	otherED25519PublicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	p256PrivateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	p384PrivateKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	rsa2048PrivateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	x25519PrivateKey, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	otherX25519PrivateKey, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	ecdhP256PrivateKey, _ := ecdh.P256().GenerateKey(rand.Reader)
+	mlkem768DecapsulationKey, _ := mlkem.GenerateKey768()
+	otherMLKEM768DecapsulationKey, _ := mlkem.GenerateKey768()
+	mlkem1024DecapsulationKey, _ := mlkem.GenerateKey1024()
+
+	corruptedRSAPrivateKey := *rsa2048PrivateKey
+	corruptedRSAPrivateKey.D = new(big.Int).Add(rsa2048PrivateKey.D, big.NewInt(2))
+
+	testCases := map[string]struct {
+		algorithm  validate.Algorithm
+		publicKey  crypto.PublicKey
+		privateKey crypto.PrivateKey
+	}{
+		"Nil keys":                        {validate.AlgorithmED25519, nil, nil},
+		"Mismatched ED25519 keys":         {validate.AlgorithmED25519, otherED25519PublicKey, ed25519PrivateKey},
+		"Truncated ED25519 private key":   {validate.AlgorithmED25519, ed25519PublicKey, ed25519PrivateKey[:32]},
+		"ECDSA keys for ED25519":          {validate.AlgorithmED25519, &p256PrivateKey.PublicKey, p256PrivateKey},
+		"ED25519 keys for ECDSA":          {validate.AlgorithmECDSAP256, ed25519PublicKey, ed25519PrivateKey},
+		"P256 keys for P521":              {validate.AlgorithmECDSAP521, &p256PrivateKey.PublicKey, p256PrivateKey},
+		"P384 keys for P224":              {validate.AlgorithmECDSAP224, &p384PrivateKey.PublicKey, p384PrivateKey},
+		"Mismatched ECDSA keys":           {validate.AlgorithmECDSAP256, &p384PrivateKey.PublicKey, p256PrivateKey},
+		"RSA 2048 keys for RSA 4096":      {validate.AlgorithmRSA4096, &rsa2048PrivateKey.PublicKey, rsa2048PrivateKey},
+		"RSA 2048 keys for RSA 1024":      {validate.AlgorithmRSA1024, &rsa2048PrivateKey.PublicKey, rsa2048PrivateKey},
+		"Corrupted RSA private exponent":  {validate.AlgorithmRSA2048, &rsa2048PrivateKey.PublicKey, &corruptedRSAPrivateKey},
+		"Unsupported algorithm":           {validate.Algorithm(-1), ed25519PublicKey, ed25519PrivateKey},
+		"Mismatched X25519 keys":          {validate.AlgorithmX25519, otherX25519PrivateKey.PublicKey(), x25519PrivateKey},
+		"ECDH P256 keys for X25519":       {validate.AlgorithmX25519, ecdhP256PrivateKey.PublicKey(), ecdhP256PrivateKey},
+		"X25519 keys for ECDH P384":       {validate.AlgorithmECDHP384, x25519PrivateKey.PublicKey(), x25519PrivateKey},
+		"ECDSA keys for ECDH P256":        {validate.AlgorithmECDHP256, &p256PrivateKey.PublicKey, p256PrivateKey},
+		"Mismatched ML-KEM 768 keys":      {validate.AlgorithmMLKEM768, otherMLKEM768DecapsulationKey.EncapsulationKey(), mlkem768DecapsulationKey},
+		"ML-KEM 1024 keys for ML-KEM 768": {validate.AlgorithmMLKEM768, mlkem1024DecapsulationKey.EncapsulationKey(), mlkem1024DecapsulationKey},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := validate.KeyPair(tc.algorithm, tc.publicKey, tc.privateKey)
+			if err == nil {
+				t.Errorf("expected error for invalid key pair %q, but got nil", name)
+			}
+		})
+	}
+}
+
+func TestKeyPairForFailsForWeakAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	ed25519PublicKey, ed25519PrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	p224PrivateKey, _ := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	p256PrivateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	p384PrivateKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	rsa2048PrivateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	x25519PrivateKey, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	ecdhP384PrivateKey, _ := ecdh.P384().GenerateKey(rand.Reader)
+	mlkem768DecapsulationKey, _ := mlkem.GenerateKey768()
+	mlkem1024DecapsulationKey, _ := mlkem.GenerateKey1024()
+
+	// A policy accepting NIST curves from 256 bits but only RSA 4096, and rejecting Curve25519.
+	customPolicy := validate.KeyPolicy{MinRSABits: 4096, MinCurveBits: 256, MinMLKEMSize: 768, RejectCurve25519: true}
+
+	testCases := map[string]struct {
+		algorithm  validate.Algorithm
+		publicKey  crypto.PublicKey
+		privateKey crypto.PrivateKey
+		policy     validate.KeyPolicy
+		valid      bool
+	}{
+		"P224 under legacy policy":        {validate.AlgorithmECDSAP224, &p224PrivateKey.PublicKey, p224PrivateKey, validate.KeyPolicyLegacy, true},
+		"P224 under modern policy":        {validate.AlgorithmECDSAP224, &p224PrivateKey.PublicKey, p224PrivateKey, validate.KeyPolicyModern, false},
+		"P256 under modern policy":        {validate.AlgorithmECDSAP256, &p256PrivateKey.PublicKey, p256PrivateKey, validate.KeyPolicyModern, true},
+		"P256 under strict policy":        {validate.AlgorithmECDSAP256, &p256PrivateKey.PublicKey, p256PrivateKey, validate.KeyPolicyStrict, false},
+		"P384 under strict policy":        {validate.AlgorithmECDSAP384, &p384PrivateKey.PublicKey, p384PrivateKey, validate.KeyPolicyStrict, true},
+		"ED25519 under modern policy":     {validate.AlgorithmED25519, ed25519PublicKey, ed25519PrivateKey, validate.KeyPolicyModern, true},
+		"ED25519 under strict policy":     {validate.AlgorithmED25519, ed25519PublicKey, ed25519PrivateKey, validate.KeyPolicyStrict, false},
+		"RSA 2048 under modern policy":    {validate.AlgorithmRSA2048, &rsa2048PrivateKey.PublicKey, rsa2048PrivateKey, validate.KeyPolicyModern, true},
+		"RSA 2048 under strict policy":    {validate.AlgorithmRSA2048, &rsa2048PrivateKey.PublicKey, rsa2048PrivateKey, validate.KeyPolicyStrict, false},
+		"RSA 1024 under modern policy":    {validate.AlgorithmRSA1024, &rsa2048PrivateKey.PublicKey, rsa2048PrivateKey, validate.KeyPolicyModern, false},
+		"X25519 under strict policy":      {validate.AlgorithmX25519, x25519PrivateKey.PublicKey(), x25519PrivateKey, validate.KeyPolicyStrict, false},
+		"ECDH P384 under strict policy":   {validate.AlgorithmECDHP384, ecdhP384PrivateKey.PublicKey(), ecdhP384PrivateKey, validate.KeyPolicyStrict, true},
+		"ML-KEM 768 under modern policy":  {validate.AlgorithmMLKEM768, mlkem768DecapsulationKey.EncapsulationKey(), mlkem768DecapsulationKey, validate.KeyPolicyModern, true},
+		"ML-KEM 768 under strict policy":  {validate.AlgorithmMLKEM768, mlkem768DecapsulationKey.EncapsulationKey(), mlkem768DecapsulationKey, validate.KeyPolicyStrict, false},
+		"ML-KEM 1024 under strict policy": {validate.AlgorithmMLKEM1024, mlkem1024DecapsulationKey.EncapsulationKey(), mlkem1024DecapsulationKey, validate.KeyPolicyStrict, true},
+		"ED25519 under zero policy":       {validate.AlgorithmED25519, ed25519PublicKey, ed25519PrivateKey, validate.KeyPolicy{}, true},
+		"X25519 under zero policy":        {validate.AlgorithmX25519, x25519PrivateKey.PublicKey(), x25519PrivateKey, validate.KeyPolicy{}, true},
+		"P224 under zero policy":          {validate.AlgorithmECDSAP224, &p224PrivateKey.PublicKey, p224PrivateKey, validate.KeyPolicy{}, true},
+		"P256 under custom policy":        {validate.AlgorithmECDSAP256, &p256PrivateKey.PublicKey, p256PrivateKey, customPolicy, true},
+		"RSA 2048 under custom policy":    {validate.AlgorithmRSA2048, &rsa2048PrivateKey.PublicKey, rsa2048PrivateKey, customPolicy, false},
+		"ED25519 under custom policy":     {validate.AlgorithmED25519, ed25519PublicKey, ed25519PrivateKey, customPolicy, false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := validate.KeyPairFor(tc.algorithm, tc.publicKey, tc.privateKey, tc.policy)
+			if tc.valid && err != nil {
+				t.Errorf("expected no error for %s, but got error: %v", name, err)
+			}
+			if !tc.valid && err == nil {
+				t.Errorf("expected error for %s, but got nil", name)
+			}
+		})
+	}
+}
+
+func TestDetectAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	ed25519PublicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	p521PrivateKey, _ := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	p224PrivateKey, _ := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	rsa2048PrivateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	rsa3072PrivateKey, _ := rsa.GenerateKey(rand.Reader, 3072)
+	x25519PrivateKey, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	ecdhP384PrivateKey, _ := ecdh.P384().GenerateKey(rand.Reader)
+	mlkem1024DecapsulationKey, _ := mlkem.GenerateKey1024()
+
+	testCases := map[string]struct {
+		publicKey crypto.PublicKey
+		expected  validate.Algorithm
+		valid     bool
+	}{
+		"ED25519":           {ed25519PublicKey, validate.AlgorithmED25519, true},
+		"ECDSA P521":        {&p521PrivateKey.PublicKey, validate.AlgorithmECDSAP521, true},
+		"ECDSA P224":        {&p224PrivateKey.PublicKey, validate.AlgorithmECDSAP224, true},
+		"RSA 2048":          {&rsa2048PrivateKey.PublicKey, validate.AlgorithmRSA2048, true},
+		"X25519":            {x25519PrivateKey.PublicKey(), validate.AlgorithmX25519, true},
+		"ECDH P384":         {ecdhP384PrivateKey.PublicKey(), validate.AlgorithmECDHP384, true},
+		"ML-KEM 1024":       {mlkem1024DecapsulationKey.EncapsulationKey(), validate.AlgorithmMLKEM1024, true},
+		"RSA 3072":          {&rsa3072PrivateKey.PublicKey, validate.AlgorithmUntyped, false},
+		"Truncated ED25519": {ed25519PublicKey[:16], validate.AlgorithmUntyped, false},
+		"Nil":               {nil, validate.AlgorithmUntyped, false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			algorithm, err := validate.DetectAlgorithm(tc.publicKey)
+			if tc.valid && err != nil {
+				t.Fatalf("expected no error for %s public key, but got error: %v", name, err)
+			}
+			if !tc.valid && err == nil {
+				t.Fatalf("expected error for %s public key, but got nil", name)
+			}
+			if algorithm != tc.expected {
+				t.Errorf("expected algorithm %s, but got %s", tc.expected, algorithm)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package validate
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+const (
+	macMulticastBit byte = 0x01 // I/G bit of the first octet.
+	macLocalBit     byte = 0x02 // U/L bit of the first octet.
+
+	eui48Length int = 6
+	eui64Length int = 8
+)
+
+// MACFlags reports the flags of a MAC address encoded in its first octet, and its length.
+type MACFlags struct {
+	Local     bool // Locally administered, i.e. the U/L bit is set. Universally administered addresses have an OUI assigned by the IEEE.
+	Multicast bool // Group address, i.e. the I/G bit is set. The broadcast address FF:FF:FF:FF:FF:FF is a multicast address.
+	EUI64     bool // 64-bit EUI-64 instead of 48-bit EUI-48.
+}
+
+// MAC validates if the provided string is an EUI-48 or EUI-64 MAC address and returns its flags.
+//
+// The address may be written in upper or lower case in one of the notations:
+//   - Colon notation, such as 00:00:5e:00:53:01 or 02:00:5e:10:00:00:00:01.
+//   - Hyphen notation, such as 00-00-5E-00-53-01.
+//   - Dot (Cisco) notation, such as 0000.5e00.5301.
+//
+// 20-octet IP over InfiniBand link-layer addresses are not MAC addresses and are rejected.
+func MAC(mac string) (MACFlags, error) {
+	// net.ParseMAC may also accept bare hexadecimal digits, which are ambiguous with other identifiers.
+	if !strings.ContainsAny(mac, ":-.") {
+		return MACFlags{}, fmt.Errorf("MAC address \"%s\" not in colon, hyphen or dot notation", mac)
+	}
+
+	addr, err := net.ParseMAC(mac)
+	if err != nil {
+		return MACFlags{}, fmt.Errorf("invalid MAC address: %w", err)
+	}
+
+	if len(addr) != eui48Length && len(addr) != eui64Length {
+		return MACFlags{}, fmt.Errorf("link-layer address of %d octets, expected %d or %d", len(addr), eui48Length, eui64Length)
+	}
+
+	return MACFlags{
+		Local:     addr[0]&macLocalBit != 0,
+		Multicast: addr[0]&macMulticastBit != 0,
+		EUI64:     len(addr) == eui64Length,
+	}, nil
+}
@@ -0,0 +1,60 @@
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+)
+
+func TestMACSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]validate.MACFlags{
+		"00:00:5e:00:53:01":       {},
+		"00-00-5E-00-53-01":       {},
+		"0000.5e00.5301":          {},
+		"52:54:00:12:34:56":       {Local: true},
+		"01:00:5e:00:00:fb":       {Multicast: true},
+		"33-33-00-00-00-01":       {Local: true, Multicast: true},
+		"ff:ff:ff:ff:ff:ff":       {Local: true, Multicast: true},
+		"02:00:5e:10:00:00:00:01": {Local: true, EUI64: true},
+		"0000.5e10.0000.0001":     {EUI64: true},
+	}
+
+	for mac, expected := range testCases {
+		t.Run(mac, func(t *testing.T) {
+			t.Parallel()
+			flags, err := validate.MAC(mac)
+			if err != nil {
+				t.Fatalf("expected no error for valid MAC address %s, but got error: %v", mac, err)
+			}
+			if flags != expected {
+				t.Errorf("expected flags %+v for MAC address %s, but got %+v", expected, mac, flags)
+			}
+		})
+	}
+}
+
+func TestMACFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"Empty":              "",
+		"Too short":          "00:00:5e:00:53",
+		"Seven octets":       "00:00:5e:00:53:01:02",
+		"Mixed separators":   "00:00-5e:00:53:01",
+		"Invalid hex":        "00:00:5g:00:53:01",
+		"Without separators": "00005e005301",
+		"Wrong dot grouping": "00.005e.0053.01",
+		"IP over InfiniBand": "00:00:00:00:fe:80:00:00:00:00:00:00:02:00:5e:10:00:00:00:01",
+	}
+
+	for name, mac := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := validate.MAC(mac); err == nil {
+				t.Errorf("expected error for invalid MAC address %q, but got nil", mac)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package validate
+
+import (
+	"bytes"
+	"crypto/sha3"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	onionTLD             string = "onion"
+	onionVersion         byte   = 3
+	onionChecksumPrefix  string = ".onion checksum"
+	onionPublicKeyLength int    = 32
+	onionChecksumLength  int    = 2
+	onionLabelLength     int    = 56 // base32 of the public key, checksum and version byte
+)
+
+// OnionAddress validates if the provided hostname is a Tor v3 onion service address, i.e. a 56-character base32 label followed by ".onion".
+// The label encodes an ED25519 public key, a two-byte checksum and the version byte 0x03, as specified in the Tor rendezvous specification (rend-spec-v3).
+//
+// The address must:
+//   - End with the ".onion" top-level domain, optionally preceded by subdomains valid according to Subdomain.
+//   - Have a label of exactly 56 base32 characters without padding. Upper-case letters are accepted as in DNS names.
+//   - Have the version byte 3.
+//   - Have a checksum equal to the first two bytes of SHA3-256(".onion checksum" || public key || version).
+func OnionAddress(address string) error {
+	labels := strings.Split(address, ".")
+	if len(labels) < 2 || !strings.EqualFold(labels[len(labels)-1], onionTLD) {
+		return fmt.Errorf("address \"%s\" doesn't end with \".%s\"", address, onionTLD)
+	}
+
+	var errs []error
+
+	for _, subdomain := range labels[:len(labels)-2] {
+		if err := Subdomain(subdomain, minSubdomainLengthAllowed, maxSubdomainLengthAllowed); err != nil {
+			errs = append(errs, fmt.Errorf("invalid subdomain: %w", err))
+		}
+	}
+
+	label := labels[len(labels)-2]
+	if len(label) != onionLabelLength {
+		return errors.Join(append(errs, fmt.Errorf("onion label of %d characters, expected %d", len(label), onionLabelLength))...)
+	}
+
+	decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(label))
+	if err != nil {
+		return errors.Join(append(errs, fmt.Errorf("invalid base32 onion label: %w", err))...)
+	}
+
+	publicKey := decoded[:onionPublicKeyLength]
+	checksum := decoded[onionPublicKeyLength : onionPublicKeyLength+onionChecksumLength]
+	version := decoded[onionPublicKeyLength+onionChecksumLength]
+
+	if version != onionVersion {
+		errs = append(errs, fmt.Errorf("onion address version %d, expected %d", version, onionVersion))
+	}
+
+	if !bytes.Equal(checksum, onionChecksum(publicKey, version)) {
+		errs = append(errs, errors.New("invalid onion address checksum"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// onionChecksum computes the checksum of an onion address.
+func onionChecksum(publicKey []byte, version byte) []byte {
+	h := sha3.New256()
+	h.Write([]byte(onionChecksumPrefix))
+	h.Write(publicKey)
+	h.Write([]byte{version})
+	return h.Sum(nil)[:onionChecksumLength]
+}
@@ -0,0 +1,71 @@
+package validate_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+)
+
+const (
+	testOnionAddressTorProject = "2gzyxa5ihm7nsggfxnu52rck2vv4rvmdlkiu3zzui5du4xyclen53wid.onion"
+	testOnionAddressDuckDuckGo = "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion"
+)
+
+func TestOnionAddressSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"Tor Project": testOnionAddressTorProject,
+		"DuckDuckGo":  testOnionAddressDuckDuckGo,
+		"Subdomain":   "www." + testOnionAddressTorProject,
+		"Upper case":  strings.ToUpper(testOnionAddressDuckDuckGo),
+	}
+
+	for name, address := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.OnionAddress(address); err != nil {
+				t.Errorf("expected no error for valid onion address \"%s\", but got error: %v", address, err)
+			}
+			if err := validate.Domain(address, 0, 0); err != nil {
+				t.Errorf("expected no error for valid onion domain \"%s\", but got error: %v", address, err)
+			}
+		})
+	}
+}
+
+func TestOnionAddressFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"Empty":             "",
+		"Other TLD":         strings.TrimSuffix(testOnionAddressTorProject, ".onion") + ".com",
+		"Missing label":     ".onion",
+		"Version 2 address": "expyuzz4wqqyqhjn.onion",
+		"Too long":          "a" + testOnionAddressTorProject,
+		"Invalid base32":    "1" + testOnionAddressTorProject[1:],
+		"Invalid checksum":  "3" + testOnionAddressTorProject[1:],
+		"Invalid version":   testOnionAddressTorProject[:55] + "a.onion",
+		"Invalid subdomain": "-www." + testOnionAddressTorProject,
+	}
+
+	for name, address := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.OnionAddress(address); err == nil {
+				t.Errorf("expected error for invalid onion address %q, but got nil", name)
+			}
+		})
+	}
+}
+
+func TestDomainFailsForInvalidOnionAddress(t *testing.T) {
+	t.Parallel()
+
+	for _, domain := range []string{"example.onion", "expyuzz4wqqyqhjn.onion", "3" + testOnionAddressTorProject[1:]} {
+		if err := validate.Domain(domain, 0, 0); err == nil {
+			t.Errorf("expected error for invalid onion domain \"%s\", but got nil", domain)
+		}
+	}
+}
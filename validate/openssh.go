@@ -0,0 +1,46 @@
+package validate
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const pemTypeOpenSSHPrivateKey string = "OPENSSH PRIVATE KEY"
+
+// KeyPairOpenSSH parses a public key in authorized_keys format and an unencrypted private key in the OpenSSH private key format ("OPENSSH PRIVATE KEY") and checks that they form a valid key pair of the specified algorithm using KeyPair.
+func KeyPairOpenSSH(algorithm Algorithm, authorizedKey, privateKeyOpenSSH []byte) error {
+	var errs []error
+
+	sshPublicKey, _, _, _, err := ssh.ParseAuthorizedKey(authorizedKey)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid authorized key: %w", err))
+	}
+
+	if _, err := pemBlock(privateKeyOpenSSH, pemTypeOpenSSHPrivateKey); err != nil {
+		errs = append(errs, fmt.Errorf("invalid private key: %w", err))
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	cryptoPublicKey, ok := sshPublicKey.(ssh.CryptoPublicKey)
+	if !ok {
+		return fmt.Errorf("unsupported authorized key type %s", sshPublicKey.Type())
+	}
+
+	privateKey, err := ssh.ParseRawPrivateKey(privateKeyOpenSSH)
+	if err != nil {
+		return fmt.Errorf("invalid OpenSSH private key: %w", err)
+	}
+
+	// The ssh package returns ED25519 private keys as pointers, while KeyPair expects them as values.
+	if ed25519PrivateKey, ok := privateKey.(*ed25519.PrivateKey); ok {
+		privateKey = *ed25519PrivateKey
+	}
+
+	return KeyPair(algorithm, cryptoPublicKey.CryptoPublicKey(), privateKey)
+}
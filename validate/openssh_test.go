@@ -0,0 +1,108 @@
+package validate_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+	"golang.org/x/crypto/ssh"
+)
+
+func encodeOpenSSH(t *testing.T, publicKey crypto.PublicKey, privateKey crypto.PrivateKey) (authorizedKey, privateKeyOpenSSH []byte) {
+	t.Helper()
+
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("error converting public key: %v", err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(privateKey, "comment")
+	if err != nil {
+		t.Fatalf("error marshaling private key: %v", err)
+	}
+
+	return ssh.MarshalAuthorizedKey(sshPublicKey), pem.EncodeToMemory(block)
+}
+
+func TestKeyPairOpenSSHSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	ed25519PublicKey, ed25519PrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	ecdsaPrivateKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	rsaPrivateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	testCases := map[string]struct {
+		algorithm  validate.Algorithm
+		publicKey  crypto.PublicKey
+		privateKey crypto.PrivateKey
+	}{
+		"ED25519":    {validate.AlgorithmED25519, ed25519PublicKey, ed25519PrivateKey},
+		"ECDSA P384": {validate.AlgorithmECDSAP384, &ecdsaPrivateKey.PublicKey, ecdsaPrivateKey},
+		"RSA 2048":   {validate.AlgorithmRSA2048, &rsaPrivateKey.PublicKey, rsaPrivateKey},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			authorizedKey, privateKeyOpenSSH := encodeOpenSSH(t, tc.publicKey, tc.privateKey)
+			err := validate.KeyPairOpenSSH(tc.algorithm, authorizedKey, privateKeyOpenSSH)
+			if err != nil {
+				t.Errorf("expected no error for valid %s key pair, but got error: %v", name, err)
+			}
+		})
+	}
+}
+
+func TestKeyPairOpenSSHFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	publicKey1, privateKey1, _ := ed25519.GenerateKey(rand.Reader)
+	publicKey2, privateKey2, _ := ed25519.GenerateKey(rand.Reader)
+	authorizedKey1, privateKeyOpenSSH1 := encodeOpenSSH(t, publicKey1, privateKey1)
+	authorizedKey2, _ := encodeOpenSSH(t, publicKey2, privateKey2)
+	_, privateKeyPEM1 := encodePEM(t, publicKey1, privateKey1)
+
+	testCases := map[string]struct {
+		algorithm         validate.Algorithm
+		authorizedKey     []byte
+		privateKeyOpenSSH []byte
+	}{
+		"empty input": {
+			algorithm: validate.AlgorithmED25519,
+		},
+		"invalid authorized key": {
+			algorithm:         validate.AlgorithmED25519,
+			authorizedKey:     []byte("ssh-ed25519 not-base64 comment"),
+			privateKeyOpenSSH: privateKeyOpenSSH1,
+		},
+		"PKCS #8 private key": {
+			algorithm:         validate.AlgorithmED25519,
+			authorizedKey:     authorizedKey1,
+			privateKeyOpenSSH: privateKeyPEM1,
+		},
+		"mismatched keys": {
+			algorithm:         validate.AlgorithmED25519,
+			authorizedKey:     authorizedKey2,
+			privateKeyOpenSSH: privateKeyOpenSSH1,
+		},
+		"wrong algorithm": {
+			algorithm:         validate.AlgorithmECDSAP256,
+			authorizedKey:     authorizedKey1,
+			privateKeyOpenSSH: privateKeyOpenSSH1,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validate.KeyPairOpenSSH(tc.algorithm, tc.authorizedKey, tc.privateKeyOpenSSH)
+			if err == nil {
+				t.Errorf("expected error for invalid input \"%s\", but got no error", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package validate
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+const (
+	pemTypePrivateKey string = "PRIVATE KEY"
+	pemTypePublicKey  string = "PUBLIC KEY"
+)
+
+// KeyPairPEM parses a PKIX "PUBLIC KEY" PEM block and a PKCS #8 "PRIVATE KEY" PEM block and checks that they form a valid key pair of the specified algorithm using KeyPair.
+func KeyPairPEM(algorithm Algorithm, publicKeyPEM, privateKeyPEM []byte) error {
+	var errs []error
+
+	publicKeyDER, err := pemBlock(publicKeyPEM, pemTypePublicKey)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid public key: %w", err))
+	}
+
+	privateKeyDER, err := pemBlock(privateKeyPEM, pemTypePrivateKey)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid private key: %w", err))
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(publicKeyDER)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid PKIX public key: %w", err))
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(privateKeyDER)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid PKCS #8 private key: %w", err))
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	publicKey, privateKey = ecdhKeysFromPKIX(algorithm, publicKey, privateKey)
+
+	return KeyPair(algorithm, publicKey, privateKey)
+}
+
+// ecdhKeysFromPKIX converts the ECDSA keys which x509 returns for ECDH keys on NIST curves back to ECDH keys if the algorithm is an ECDH one,
+// since PKCS #8 and PKIX share the key type of ECDSA and ECDH keys. Other keys are returned as they are.
+func ecdhKeysFromPKIX(algorithm Algorithm, publicKey crypto.PublicKey, privateKey crypto.PrivateKey) (crypto.PublicKey, crypto.PrivateKey) {
+	if algorithm != AlgorithmECDHP256 && algorithm != AlgorithmECDHP384 {
+		return publicKey, privateKey
+	}
+
+	if ecdsaPublicKey, ok := publicKey.(*ecdsa.PublicKey); ok {
+		if ecdhPublicKey, err := ecdsaPublicKey.ECDH(); err == nil {
+			publicKey = ecdhPublicKey
+		}
+	}
+
+	if ecdsaPrivateKey, ok := privateKey.(*ecdsa.PrivateKey); ok {
+		if ecdhPrivateKey, err := ecdsaPrivateKey.ECDH(); err == nil {
+			privateKey = ecdhPrivateKey
+		}
+	}
+
+	return publicKey, privateKey
+}
+
+// pemBlock returns the bytes of the first PEM block in data and checks it has the expected type.
+func pemBlock(data []byte, blockType string) ([]byte, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if block.Type != blockType {
+		return nil, fmt.Errorf("unexpected PEM block type \"%s\", expected \"%s\"", block.Type, blockType)
+	}
+
+	return block.Bytes, nil
+}
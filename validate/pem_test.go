@@ -0,0 +1,120 @@
+package validate_test
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+)
+
+func encodePEM(t *testing.T, publicKey crypto.PublicKey, privateKey crypto.PrivateKey) (publicKeyPEM, privateKeyPEM []byte) {
+	t.Helper()
+
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("error marshaling public key: %v", err)
+	}
+
+	privateKeyDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("error marshaling private key: %v", err)
+	}
+
+	publicKeyPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDER})
+	privateKeyPEM = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyDER})
+
+	return publicKeyPEM, privateKeyPEM
+}
+
+func TestKeyPairPEMSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	ed25519PublicKey, ed25519PrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	ecdsaPrivateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	rsaPrivateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	x25519PrivateKey, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	ecdhP384PrivateKey, _ := ecdh.P384().GenerateKey(rand.Reader)
+
+	testCases := map[string]struct {
+		algorithm  validate.Algorithm
+		publicKey  crypto.PublicKey
+		privateKey crypto.PrivateKey
+	}{
+		"ED25519":    {validate.AlgorithmED25519, ed25519PublicKey, ed25519PrivateKey},
+		"ECDSA P256": {validate.AlgorithmECDSAP256, &ecdsaPrivateKey.PublicKey, ecdsaPrivateKey},
+		"RSA 2048":   {validate.AlgorithmRSA2048, &rsaPrivateKey.PublicKey, rsaPrivateKey},
+		"X25519":     {validate.AlgorithmX25519, x25519PrivateKey.PublicKey(), x25519PrivateKey},
+		"ECDH P384":  {validate.AlgorithmECDHP384, ecdhP384PrivateKey.PublicKey(), ecdhP384PrivateKey},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			publicKeyPEM, privateKeyPEM := encodePEM(t, tc.publicKey, tc.privateKey)
+			err := validate.KeyPairPEM(tc.algorithm, publicKeyPEM, privateKeyPEM)
+			if err != nil {
+				t.Errorf("expected no error for valid %s key pair, but got error: %v", name, err)
+			}
+		})
+	}
+}
+
+func TestKeyPairPEMFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	publicKey1, privateKey1, _ := ed25519.GenerateKey(rand.Reader)
+	publicKey2, privateKey2, _ := ed25519.GenerateKey(rand.Reader)
+	publicKeyPEM1, privateKeyPEM1 := encodePEM(t, publicKey1, privateKey1)
+	publicKeyPEM2, _ := encodePEM(t, publicKey2, privateKey2)
+
+	testCases := map[string]struct {
+		algorithm     validate.Algorithm
+		publicKeyPEM  []byte
+		privateKeyPEM []byte
+	}{
+		"empty input": {
+			algorithm: validate.AlgorithmED25519,
+		},
+		"not PEM": {
+			algorithm:     validate.AlgorithmED25519,
+			publicKeyPEM:  []byte("not a PEM block"),
+			privateKeyPEM: privateKeyPEM1,
+		},
+		"swapped blocks": {
+			algorithm:     validate.AlgorithmED25519,
+			publicKeyPEM:  privateKeyPEM1,
+			privateKeyPEM: publicKeyPEM1,
+		},
+		"corrupted DER": {
+			algorithm:     validate.AlgorithmED25519,
+			publicKeyPEM:  publicKeyPEM1,
+			privateKeyPEM: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte{0x30, 0x03, 0x02, 0x01}}),
+		},
+		"mismatched keys": {
+			algorithm:     validate.AlgorithmED25519,
+			publicKeyPEM:  publicKeyPEM2,
+			privateKeyPEM: privateKeyPEM1,
+		},
+		"wrong algorithm": {
+			algorithm:     validate.AlgorithmRSA2048,
+			publicKeyPEM:  publicKeyPEM1,
+			privateKeyPEM: privateKeyPEM1,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validate.KeyPairPEM(tc.algorithm, tc.publicKeyPEM, tc.privateKeyPEM)
+			if err == nil {
+				t.Errorf("expected error for invalid input \"%s\", but got no error", name)
+			}
+		})
+	}
+}
@@ -13,11 +13,12 @@ const (
 	maxPortAllowed       uint16 = 65535
 )
 
+// Port checks if the port is in the range [minPort, maxPort]. A minPort and maxPort of 0 allow every port.
 func Port(port, minPort, maxPort uint16) error {
 	if minPort == 0 && maxPort == 0 {
 		maxPort = maxPortAllowed
 	} else {
-		if minPort < maxPort {
+		if maxPort < minPort {
 			return fmt.Errorf("maximum port can not be less than minimum port")
 		}
 	}
@@ -202,3 +202,40 @@ func TestPortPrivateFailsForInvalidInput(t *testing.T) {
 		})
 	}
 }
+
+func TestPortSuccessfulForValidInput(t *testing.T) {
+	testCases := map[string][3]uint16{
+		"Any port":          {0, 0, 0},
+		"Any port maximum":  {65535, 0, 0},
+		"Lower limit":       {8000, 8000, 8100},
+		"Upper limit":       {8100, 8000, 8100},
+		"Single port range": {443, 443, 443},
+		"Full range":        {65535, 0, 65535},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validate.Port(testCase[0], testCase[1], testCase[2])
+			if err != nil {
+				t.Errorf("expected no error for port %d in [%d, %d], but got error: %v", testCase[0], testCase[1], testCase[2], err)
+			}
+		})
+	}
+}
+
+func TestPortFailsForInvalidInput(t *testing.T) {
+	testCases := map[string][3]uint16{
+		"Below range":    {7999, 8000, 8100},
+		"Above range":    {8101, 8000, 8100},
+		"Inverted range": {8050, 8100, 8000},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validate.Port(testCase[0], testCase[1], testCase[2])
+			if err == nil {
+				t.Errorf("expected error for port %d in [%d, %d], but got no error", testCase[0], testCase[1], testCase[2])
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PortRange is an immutable set of ports. The zero value is an empty set.
+type PortRange struct {
+	ranges []portInterval // Sorted, non-overlapping and non-adjacent.
+}
+
+// portInterval is an inclusive interval of ports.
+type portInterval struct {
+	from, to uint16
+}
+
+// NewPortRange returns the set of the ports from minPort to maxPort inclusive.
+func NewPortRange(minPort, maxPort uint16) (PortRange, error) {
+	if maxPort < minPort {
+		return PortRange{}, fmt.Errorf("maximum port %d can not be less than minimum port %d", maxPort, minPort)
+	}
+
+	return PortRange{ranges: []portInterval{{minPort, maxPort}}}, nil
+}
+
+// ParsePortRange parses a comma-separated list of ports and inclusive port ranges, such as "8000-8100,9000".
+// The elements may overlap and be surrounded by spaces.
+func ParsePortRange(s string) (PortRange, error) {
+	if strings.TrimSpace(s) == "" {
+		return PortRange{}, errors.New("empty port range")
+	}
+
+	var intervals []portInterval
+	var errs []error
+
+	for _, element := range strings.Split(s, ",") {
+		element = strings.TrimSpace(element)
+
+		first, last, isRange := strings.Cut(element, "-")
+
+		from, err := parsePort(first)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid element %q: %w", element, err))
+			continue
+		}
+
+		to := from
+		if isRange {
+			to, err = parsePort(last)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid element %q: %w", element, err))
+				continue
+			}
+		}
+
+		if to < from {
+			errs = append(errs, fmt.Errorf("invalid element %q: end of range is less than start", element))
+			continue
+		}
+
+		intervals = append(intervals, portInterval{from, to})
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return PortRange{}, err
+	}
+
+	return PortRange{ranges: mergePortIntervals(intervals)}, nil
+}
+
+// parsePort parses a decimal port number.
+func parsePort(s string) (uint16, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.TrimLeft(s, "0123456789") != "" {
+		return 0, fmt.Errorf("port %q is not a decimal number", s)
+	}
+
+	port, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("port %q exceeds upper limit of %d", s, maxPortAllowed)
+	}
+
+	return uint16(port), nil
+}
+
+// Union returns the set of the ports in r or other.
+func (r PortRange) Union(other PortRange) PortRange {
+	return PortRange{ranges: mergePortIntervals(append(slices.Clone(r.ranges), other.ranges...))}
+}
+
+// Difference returns the set of the ports in r which are not in other.
+func (r PortRange) Difference(other PortRange) PortRange {
+	var ranges []portInterval
+
+	j := 0
+	for _, interval := range r.ranges {
+		from := uint(interval.from)
+
+		for ; j < len(other.ranges) && uint(other.ranges[j].to) < from; j++ {
+		}
+
+		for k := j; k < len(other.ranges) && other.ranges[k].from <= interval.to; k++ {
+			hole := other.ranges[k]
+			if from < uint(hole.from) {
+				ranges = append(ranges, portInterval{uint16(from), hole.from - 1})
+			}
+			from = uint(hole.to) + 1
+			if from > uint(interval.to) {
+				break
+			}
+		}
+
+		if from <= uint(interval.to) {
+			ranges = append(ranges, portInterval{uint16(from), interval.to})
+		}
+	}
+
+	return PortRange{ranges: ranges}
+}
+
+// Contains reports whether the port is in the set.
+func (r PortRange) Contains(port uint16) bool {
+	i, found := slices.BinarySearchFunc(r.ranges, port, func(interval portInterval, port uint16) int {
+		return int(interval.from) - int(port)
+	})
+	if found {
+		return true
+	}
+
+	return i > 0 && port <= r.ranges[i-1].to
+}
+
+// Len returns the number of ports in the set.
+func (r PortRange) Len() int {
+	n := 0
+	for _, interval := range r.ranges {
+		n += int(interval.to-interval.from) + 1
+	}
+
+	return n
+}
+
+// At returns the port at index i of the set in ascending order. It returns false if i is out of [0, Len()).
+func (r PortRange) At(i int) (uint16, bool) {
+	if i < 0 {
+		return 0, false
+	}
+
+	for _, interval := range r.ranges {
+		size := int(interval.to-interval.from) + 1
+		if i < size {
+			return interval.from + uint16(i), true
+		}
+		i -= size
+	}
+
+	return 0, false
+}
+
+// String returns the ports and port ranges of the set separated by commas, in the format accepted by ParsePortRange.
+func (r PortRange) String() string {
+	var b []byte
+	for i, interval := range r.ranges {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = strconv.AppendUint(b, uint64(interval.from), 10)
+		if interval.to != interval.from {
+			b = append(b, '-')
+			b = strconv.AppendUint(b, uint64(interval.to), 10)
+		}
+	}
+
+	return string(b)
+}
+
+// PortInRange validates if the port is in ports and not in excluded.
+func PortInRange(port uint16, ports, excluded PortRange) error {
+	if !ports.Contains(port) {
+		return fmt.Errorf("port %d is not in %q", port, ports)
+	}
+
+	if excluded.Contains(port) {
+		return fmt.Errorf("port %d is excluded by %q", port, excluded)
+	}
+
+	return nil
+}
+
+// mergePortIntervals sorts the intervals and merges the overlapping and adjacent ones.
+func mergePortIntervals(intervals []portInterval) []portInterval {
+	if len(intervals) == 0 {
+		return nil
+	}
+
+	slices.SortFunc(intervals, func(a, b portInterval) int {
+		return int(a.from) - int(b.from)
+	})
+
+	merged := intervals[:1]
+	for _, interval := range intervals[1:] {
+		last := &merged[len(merged)-1]
+		if uint(interval.from) <= uint(last.to)+1 {
+			if last.to < interval.to {
+				last.to = interval.to
+			}
+			continue
+		}
+		merged = append(merged, interval)
+	}
+
+	return merged
+}
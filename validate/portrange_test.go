@@ -0,0 +1,187 @@
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+)
+
+func mustPortRange(t *testing.T, s string) validate.PortRange {
+	t.Helper()
+
+	if s == "" {
+		return validate.PortRange{}
+	}
+
+	ports, err := validate.ParsePortRange(s)
+	if err != nil {
+		t.Fatalf("error parsing port range %q: %v", s, err)
+	}
+
+	return ports
+}
+
+func TestParsePortRangeSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		input    string
+		expected string
+		length   int
+	}{
+		"Single port":          {"443", "443", 1},
+		"Range and port":       {"8000-8100,9000", "8000-8100,9000", 102},
+		"Spaces":               {" 8000 - 8100 , 9000 ", "8000-8100,9000", 102},
+		"Unsorted":             {"9000,8000-8100", "8000-8100,9000", 102},
+		"Overlapping":          {"8000-8100,8050-8200", "8000-8200", 201},
+		"Adjacent":             {"80,81,82-90", "80-90", 11},
+		"Duplicates":           {"22,22,22", "22", 1},
+		"Every port":           {"0-65535", "0-65535", 65536},
+		"Single port as range": {"53-53", "53", 1},
+		"Leading zeros":        {"0080", "80", 1},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ports, err := validate.ParsePortRange(tc.input)
+			if err != nil {
+				t.Fatalf("expected no error for valid port range %q, but got error: %v", tc.input, err)
+			}
+			if s := ports.String(); s != tc.expected {
+				t.Errorf("expected %q, but got %q", tc.expected, s)
+			}
+			if n := ports.Len(); n != tc.length {
+				t.Errorf("expected %d ports, but got %d", tc.length, n)
+			}
+		})
+	}
+}
+
+func TestParsePortRangeFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"Empty":           "",
+		"Blank":           "  ",
+		"Empty element":   "80,,443",
+		"Trailing comma":  "80,",
+		"Open range":      "8000-",
+		"Inverted range":  "8100-8000",
+		"Too large":       "65536",
+		"Negative":        "-1",
+		"Sign":            "+80",
+		"Service name":    "https",
+		"Double dash":     "1-2-3",
+		"Hexadecimal":     "0x50",
+		"Range too large": "60000-70000",
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := validate.ParsePortRange(testCase); err == nil {
+				t.Errorf("expected error for invalid port range %q, but got no error", testCase)
+			}
+		})
+	}
+}
+
+func TestNewPortRange(t *testing.T) {
+	t.Parallel()
+
+	ports, err := validate.NewPortRange(1024, 49151)
+	if err != nil {
+		t.Fatalf("expected no error for valid port range, but got error: %v", err)
+	}
+	if s := ports.String(); s != "1024-49151" {
+		t.Errorf("expected 1024-49151, but got %s", s)
+	}
+
+	if _, err := validate.NewPortRange(2, 1); err == nil {
+		t.Error("expected error for inverted port range, but got no error")
+	}
+}
+
+func TestPortRangeUnionAndDifference(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		a, b       string
+		union      string
+		difference string
+	}{
+		"Empty sets":      {"", "", "", ""},
+		"Disjoint":        {"80", "443", "80,443", "80"},
+		"Adjacent":        {"80-89", "90-99", "80-99", "80-89"},
+		"Hole":            {"8000-8100", "8050", "8000-8100", "8000-8049,8051-8100"},
+		"Covering":        {"8050", "8000-8100", "8000-8100", ""},
+		"Edges":           {"0-65535", "0,65535", "0-65535", "1-65534"},
+		"Several holes":   {"1-10,20-30", "2-3,9-21,30", "1-30", "1,4-8,22-29"},
+		"Difference tail": {"100-200", "150-65535", "100-65535", "100-149"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			a, b := mustPortRange(t, tc.a), mustPortRange(t, tc.b)
+			if union := a.Union(b).String(); union != tc.union {
+				t.Errorf("expected union %q, but got %q", tc.union, union)
+			}
+			if difference := a.Difference(b).String(); difference != tc.difference {
+				t.Errorf("expected difference %q, but got %q", tc.difference, difference)
+			}
+		})
+	}
+}
+
+func TestPortRangeContainsAndAt(t *testing.T) {
+	t.Parallel()
+
+	ports := mustPortRange(t, "22,8000-8002,65535")
+
+	expected := []uint16{22, 8000, 8001, 8002, 65535}
+	for i, want := range expected {
+		if port, ok := ports.At(i); !ok || port != want {
+			t.Errorf("expected port %d at index %d, but got %d, %t", want, i, port, ok)
+		}
+		if !ports.Contains(want) {
+			t.Errorf("expected %s to contain %d", ports, want)
+		}
+	}
+
+	for _, i := range []int{-1, len(expected)} {
+		if _, ok := ports.At(i); ok {
+			t.Errorf("expected no port at index %d", i)
+		}
+	}
+
+	for _, port := range []uint16{0, 21, 23, 7999, 8003, 65534} {
+		if ports.Contains(port) {
+			t.Errorf("expected %s not to contain %d", ports, port)
+		}
+	}
+
+	if (validate.PortRange{}).Contains(0) {
+		t.Error("expected empty port range not to contain 0")
+	}
+}
+
+func TestPortInRange(t *testing.T) {
+	t.Parallel()
+
+	ports := mustPortRange(t, "8000-8100,9000")
+	excluded := mustPortRange(t, "8080")
+
+	for _, port := range []uint16{8000, 8079, 8081, 8100, 9000} {
+		if err := validate.PortInRange(port, ports, excluded); err != nil {
+			t.Errorf("expected no error for port %d, but got error: %v", port, err)
+		}
+	}
+
+	for _, port := range []uint16{0, 7999, 8080, 8101, 8999, 9001} {
+		if err := validate.PortInRange(port, ports, excluded); err == nil {
+			t.Errorf("expected error for port %d, but got no error", port)
+		}
+	}
+}
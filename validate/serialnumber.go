@@ -0,0 +1,47 @@
+package validate
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	minSerialNumberBitSize int = 64 // 64 bits of CSPRNG output required by the CA/Browser Forum Baseline Requirements 7.1
+	maxSerialNumberLength  int = 20 // RFC 5280 4.1.2.2
+)
+
+// SerialNumber validates an X.509 certificate serial number against RFC 5280 and the CA/Browser Forum Baseline Requirements.
+// The serial number must be positive, at least 64 bits long and its DER encoding must not be longer than 20 octets.
+// A positive number whose most significant bit is set needs a leading zero octet in DER (otherwise it starts with 0x80 or above and reads as negative),
+// which counts toward the 20 octets, so 160-bit serial numbers are rejected.
+func SerialNumber(serialNumber *big.Int) error {
+	if serialNumber == nil {
+		return errors.New("nil serial number")
+	}
+
+	if serialNumber.Sign() <= 0 {
+		return fmt.Errorf("serial number %s is not positive", serialNumber)
+	}
+
+	if bitSize := serialNumber.BitLen(); bitSize < minSerialNumberBitSize {
+		return fmt.Errorf("serial number of %d bits is less than minimum size of %d bits", bitSize, minSerialNumberBitSize)
+	}
+
+	der, err := asn1.Marshal(serialNumber)
+	if err != nil {
+		return fmt.Errorf("error encoding serial number: %w", err)
+	}
+
+	var raw asn1.RawValue
+	if _, err := asn1.Unmarshal(der, &raw); err != nil {
+		return fmt.Errorf("error decoding serial number: %w", err)
+	}
+
+	if length := len(raw.Bytes); length > maxSerialNumberLength {
+		return fmt.Errorf("serial number encoding of %d octets exceeds maximum length of %d octets", length, maxSerialNumberLength)
+	}
+
+	return nil
+}
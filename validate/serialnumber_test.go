@@ -0,0 +1,53 @@
+package validate_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+)
+
+func TestSerialNumberSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]*big.Int{
+		"64 bits":                    new(big.Int).Lsh(big.NewInt(1), 63),
+		"65 bits":                    new(big.Int).Lsh(big.NewInt(1), 64),
+		"159 bits":                   new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 159), big.NewInt(1)),
+		"20 octets without high bit": new(big.Int).SetBytes([]byte{0x7f, 0xff, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}),
+	}
+
+	for name, serialNumber := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.SerialNumber(serialNumber); err != nil {
+				t.Errorf("expected no error for valid serial number %s, but got error: %v", serialNumber, err)
+			}
+		})
+	}
+}
+
+func TestSerialNumberFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]*big.Int{
+		"Nil":                             nil,
+		"Zero":                            big.NewInt(0),
+		"Negative":                        new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 100)),
+		"Too short":                       big.NewInt(1),
+		"7 octets":                        new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 55), big.NewInt(1)),
+		"56 bits with leading zero octet": new(big.Int).Lsh(big.NewInt(1), 55),
+		"63 bits":                         new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 63), big.NewInt(1)),
+		"160 bits with high bit":          new(big.Int).Lsh(big.NewInt(1), 159),
+		"21 octets":                       new(big.Int).Lsh(big.NewInt(1), 160),
+	}
+
+	for name, serialNumber := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.SerialNumber(serialNumber); err == nil {
+				t.Errorf("expected error for invalid serial number %q, but got nil", name)
+			}
+		})
+	}
+}
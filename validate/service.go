@@ -0,0 +1,212 @@
+package validate
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Columns of the CSV format of the IANA Service Name and Transport Protocol Port Number Registry read by ParseServiceRegistry.
+const (
+	serviceRegistryColumnName     = "Service Name"
+	serviceRegistryColumnPort     = "Port Number"
+	serviceRegistryColumnProtocol = "Transport Protocol"
+)
+
+// Service is an entry of a service name and port number registry.
+type Service struct {
+	Name     string
+	Port     uint16
+	Protocol string // Transport protocol: "tcp", "udp", "sctp" or "dccp".
+	Aliases  []string
+}
+
+// ServiceByPort returns the first entry of ServiceRegistry for the port and transport protocol, or false if the port is not registered.
+// An empty protocol matches every protocol.
+func ServiceByPort(port uint16, protocol string) (Service, bool) {
+	for _, service := range ServiceRegistry {
+		if service.Port == port && (protocol == "" || strings.EqualFold(service.Protocol, protocol)) {
+			return service, true
+		}
+	}
+
+	return Service{}, false
+}
+
+// ServiceByName returns the first entry of ServiceRegistry with the name or alias and transport protocol, or false if there is none.
+// Names are case-insensitive and an empty protocol matches every protocol.
+func ServiceByName(name, protocol string) (Service, bool) {
+	for _, service := range ServiceRegistry {
+		if protocol != "" && !strings.EqualFold(service.Protocol, protocol) {
+			continue
+		}
+
+		if strings.EqualFold(service.Name, name) {
+			return service, true
+		}
+
+		for _, alias := range service.Aliases {
+			if strings.EqualFold(alias, name) {
+				return service, true
+			}
+		}
+	}
+
+	return Service{}, false
+}
+
+// RegisteredPorts returns the set of the ports of ServiceRegistry for the transport protocol.
+// An empty protocol matches every protocol.
+func RegisteredPorts(protocol string) PortRange {
+	var intervals []portInterval
+	for _, service := range ServiceRegistry {
+		if protocol == "" || strings.EqualFold(service.Protocol, protocol) {
+			intervals = append(intervals, portInterval{service.Port, service.Port})
+		}
+	}
+
+	return PortRange{ranges: mergePortIntervals(intervals)}
+}
+
+// PortNotRegistered validates if the port is not registered to any service of ServiceRegistry for the transport protocol.
+// An empty protocol matches every protocol.
+func PortNotRegistered(port uint16, protocol string) error {
+	service, ok := ServiceByPort(port, protocol)
+	if !ok {
+		return nil
+	}
+
+	return fmt.Errorf("port %d is registered to service %s/%s", port, service.Name, service.Protocol)
+}
+
+// ParseServiceRegistry parses the CSV format of the IANA Service Name and Transport Protocol Port Number Registry,
+// such as service-names-port-numbers.csv from https://www.iana.org/assignments/service-names-port-numbers.
+//
+// Columns are found by their names in the header, and columns other than the service name, port number and transport protocol are ignored.
+// Entries without a service name, port number or transport protocol, such as reserved and unassigned ports, are skipped.
+// An entry for a port range, such as "6000-6063", gives a service for every port of the range.
+func ParseServiceRegistry(r io.Reader) ([]Service, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading header: %w", err)
+	}
+
+	var columns [3]int
+	for i, name := range []string{serviceRegistryColumnName, serviceRegistryColumnPort, serviceRegistryColumnProtocol} {
+		columns[i] = slices.IndexFunc(header, func(column string) bool {
+			return strings.EqualFold(strings.TrimSpace(column), name)
+		})
+		if columns[i] < 0 {
+			return nil, fmt.Errorf("missing \"%s\" column", name)
+		}
+	}
+
+	var services []Service
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading entry: %w", err)
+		}
+
+		var fields [3]string
+		for i, column := range columns {
+			if column < len(record) {
+				fields[i] = strings.TrimSpace(record[column])
+			}
+		}
+		name, ports, protocol := fields[0], fields[1], strings.ToLower(fields[2])
+		if name == "" || ports == "" || protocol == "" {
+			continue
+		}
+
+		from, to, err := parseServicePorts(ports)
+		if err != nil {
+			line, _ := reader.FieldPos(columns[1])
+			return nil, fmt.Errorf("invalid port number \"%s\" of service %s on line %d: %w", ports, name, line, err)
+		}
+
+		for port := uint(from); port <= uint(to); port++ {
+			services = append(services, Service{Name: name, Port: uint16(port), Protocol: protocol})
+		}
+	}
+
+	return services, nil
+}
+
+// parseServicePorts parses a port number or a port range of the IANA registry, such as "443" or "6000-6063".
+func parseServicePorts(ports string) (uint16, uint16, error) {
+	first, last, isRange := strings.Cut(ports, "-")
+
+	from, err := strconv.ParseUint(first, 10, 16)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if !isRange {
+		return uint16(from), uint16(from), nil
+	}
+
+	to, err := strconv.ParseUint(last, 10, 16)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if to < from {
+		return 0, 0, errors.New("last port of range less than first port")
+	}
+
+	return uint16(from), uint16(to), nil
+}
+
+// mergeServices adds the name and aliases of every service of others to the aliases of the first service of services
+// with the same port and transport protocol, and adds the services of others that services doesn't have. The result is sorted by port.
+func mergeServices(services, others []Service) []Service {
+	type portProtocol struct {
+		port     uint16
+		protocol string
+	}
+
+	index := make(map[portProtocol]int, len(services))
+	for i, service := range services {
+		key := portProtocol{service.Port, service.Protocol}
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+
+	for _, other := range others {
+		key := portProtocol{other.Port, other.Protocol}
+		i, ok := index[key]
+		if !ok {
+			other.Aliases = slices.Clone(other.Aliases)
+			services = append(services, other)
+			index[key] = len(services) - 1
+			continue
+		}
+
+		for _, name := range append([]string{other.Name}, other.Aliases...) {
+			known := strings.EqualFold(services[i].Name, name) || slices.ContainsFunc(services[i].Aliases, func(alias string) bool {
+				return strings.EqualFold(alias, name)
+			})
+			if !known {
+				services[i].Aliases = append(services[i].Aliases, name)
+			}
+		}
+	}
+
+	slices.SortStableFunc(services, func(a, b Service) int {
+		return int(a.Port) - int(b.Port)
+	})
+
+	return services
+}
@@ -0,0 +1,283 @@
+package validate
+
+import (
+	"bytes"
+	_ "embed"
+)
+
+// serviceRegistryCSV is a snapshot of the IANA Service Name and Transport Protocol Port Number Registry
+// from https://www.iana.org/assignments/service-names-port-numbers, in the CSV format of the registry.
+//
+// The snapshot was fetched by gopacket (github.com/google/gopacket/layers/iana_ports.go) on 2017-10-23.
+// It has the first service name of every TCP, UDP and SCTP port number, but not the entries registered for port ranges.
+// Replace the file with the current service-names-port-numbers.csv of IANA to update the registry.
+//
+//go:embed service_names_port_numbers.csv
+var serviceRegistryCSV []byte
+
+// ServiceRegistry is the list of the services of the IANA Service Name and Transport Protocol Port Number Registry,
+// with the names and aliases of the services file of the netbase package as aliases, and the traditional UNIX services of that file which IANA doesn't list.
+var ServiceRegistry = serviceRegistry()
+
+// serviceRegistry parses serviceRegistryCSV and merges netbaseServices into it.
+// The embedded registry is checked by the tests, so a parsing error only leaves the IANA services out.
+func serviceRegistry() []Service {
+	services, _ := ParseServiceRegistry(bytes.NewReader(serviceRegistryCSV))
+	return mergeServices(services, netbaseServices)
+}
+
+// netbaseServices is the list of the services of the services file of the netbase package,
+// which has the commonly used services of the IANA registry with their aliases.
+var netbaseServices = []Service{
+	{Name: "tcpmux", Port: 1, Protocol: "tcp"},
+	{Name: "echo", Port: 7, Protocol: "tcp"},
+	{Name: "echo", Port: 7, Protocol: "udp"},
+	{Name: "discard", Port: 9, Protocol: "tcp", Aliases: []string{"sink", "null"}},
+	{Name: "discard", Port: 9, Protocol: "udp", Aliases: []string{"sink", "null"}},
+	{Name: "systat", Port: 11, Protocol: "tcp", Aliases: []string{"users"}},
+	{Name: "daytime", Port: 13, Protocol: "tcp"},
+	{Name: "daytime", Port: 13, Protocol: "udp"},
+	{Name: "netstat", Port: 15, Protocol: "tcp"},
+	{Name: "qotd", Port: 17, Protocol: "tcp", Aliases: []string{"quote"}},
+	{Name: "chargen", Port: 19, Protocol: "tcp", Aliases: []string{"ttytst", "source"}},
+	{Name: "chargen", Port: 19, Protocol: "udp", Aliases: []string{"ttytst", "source"}},
+	{Name: "ftp-data", Port: 20, Protocol: "tcp"},
+	{Name: "ftp", Port: 21, Protocol: "tcp"},
+	{Name: "fsp", Port: 21, Protocol: "udp", Aliases: []string{"fspd"}},
+	{Name: "ssh", Port: 22, Protocol: "tcp"},
+	{Name: "telnet", Port: 23, Protocol: "tcp"},
+	{Name: "smtp", Port: 25, Protocol: "tcp", Aliases: []string{"mail"}},
+	{Name: "time", Port: 37, Protocol: "tcp", Aliases: []string{"timserver"}},
+	{Name: "time", Port: 37, Protocol: "udp", Aliases: []string{"timserver"}},
+	{Name: "whois", Port: 43, Protocol: "tcp", Aliases: []string{"nicname"}},
+	{Name: "tacacs", Port: 49, Protocol: "tcp"},
+	{Name: "tacacs", Port: 49, Protocol: "udp"},
+	{Name: "domain", Port: 53, Protocol: "tcp"},
+	{Name: "domain", Port: 53, Protocol: "udp"},
+	{Name: "bootps", Port: 67, Protocol: "udp"},
+	{Name: "bootpc", Port: 68, Protocol: "udp"},
+	{Name: "tftp", Port: 69, Protocol: "udp"},
+	{Name: "gopher", Port: 70, Protocol: "tcp"},
+	{Name: "finger", Port: 79, Protocol: "tcp"},
+	{Name: "http", Port: 80, Protocol: "tcp", Aliases: []string{"www"}},
+	{Name: "kerberos", Port: 88, Protocol: "tcp", Aliases: []string{"kerberos5", "krb5", "kerberos-sec"}},
+	{Name: "kerberos", Port: 88, Protocol: "udp", Aliases: []string{"kerberos5", "krb5", "kerberos-sec"}},
+	{Name: "iso-tsap", Port: 102, Protocol: "tcp", Aliases: []string{"tsap"}},
+	{Name: "acr-nema", Port: 104, Protocol: "tcp", Aliases: []string{"dicom"}},
+	{Name: "pop3", Port: 110, Protocol: "tcp", Aliases: []string{"pop-3"}},
+	{Name: "sunrpc", Port: 111, Protocol: "tcp", Aliases: []string{"portmapper"}},
+	{Name: "sunrpc", Port: 111, Protocol: "udp", Aliases: []string{"portmapper"}},
+	{Name: "auth", Port: 113, Protocol: "tcp", Aliases: []string{"authentication", "tap", "ident"}},
+	{Name: "nntp", Port: 119, Protocol: "tcp", Aliases: []string{"readnews", "untp"}},
+	{Name: "ntp", Port: 123, Protocol: "udp"},
+	{Name: "epmap", Port: 135, Protocol: "tcp", Aliases: []string{"loc-srv"}},
+	{Name: "netbios-ns", Port: 137, Protocol: "udp"},
+	{Name: "netbios-dgm", Port: 138, Protocol: "udp"},
+	{Name: "netbios-ssn", Port: 139, Protocol: "tcp"},
+	{Name: "imap2", Port: 143, Protocol: "tcp", Aliases: []string{"imap"}},
+	{Name: "snmp", Port: 161, Protocol: "tcp"},
+	{Name: "snmp", Port: 161, Protocol: "udp"},
+	{Name: "snmp-trap", Port: 162, Protocol: "tcp", Aliases: []string{"snmptrap"}},
+	{Name: "snmp-trap", Port: 162, Protocol: "udp", Aliases: []string{"snmptrap"}},
+	{Name: "cmip-man", Port: 163, Protocol: "tcp"},
+	{Name: "cmip-man", Port: 163, Protocol: "udp"},
+	{Name: "cmip-agent", Port: 164, Protocol: "tcp"},
+	{Name: "cmip-agent", Port: 164, Protocol: "udp"},
+	{Name: "mailq", Port: 174, Protocol: "tcp"},
+	{Name: "xdmcp", Port: 177, Protocol: "udp"},
+	{Name: "bgp", Port: 179, Protocol: "tcp"},
+	{Name: "smux", Port: 199, Protocol: "tcp"},
+	{Name: "qmtp", Port: 209, Protocol: "tcp"},
+	{Name: "z3950", Port: 210, Protocol: "tcp", Aliases: []string{"wais"}},
+	{Name: "ipx", Port: 213, Protocol: "udp"},
+	{Name: "ptp-event", Port: 319, Protocol: "udp"},
+	{Name: "ptp-general", Port: 320, Protocol: "udp"},
+	{Name: "pawserv", Port: 345, Protocol: "tcp"},
+	{Name: "zserv", Port: 346, Protocol: "tcp"},
+	{Name: "rpc2portmap", Port: 369, Protocol: "tcp"},
+	{Name: "rpc2portmap", Port: 369, Protocol: "udp"},
+	{Name: "codaauth2", Port: 370, Protocol: "tcp"},
+	{Name: "codaauth2", Port: 370, Protocol: "udp"},
+	{Name: "clearcase", Port: 371, Protocol: "udp", Aliases: []string{"Clearcase"}},
+	{Name: "ldap", Port: 389, Protocol: "tcp"},
+	{Name: "ldap", Port: 389, Protocol: "udp"},
+	{Name: "svrloc", Port: 427, Protocol: "tcp"},
+	{Name: "svrloc", Port: 427, Protocol: "udp"},
+	{Name: "https", Port: 443, Protocol: "tcp"},
+	{Name: "https", Port: 443, Protocol: "udp"},
+	{Name: "snpp", Port: 444, Protocol: "tcp"},
+	{Name: "microsoft-ds", Port: 445, Protocol: "tcp"},
+	{Name: "kpasswd", Port: 464, Protocol: "tcp"},
+	{Name: "kpasswd", Port: 464, Protocol: "udp"},
+	{Name: "submissions", Port: 465, Protocol: "tcp", Aliases: []string{"ssmtp", "smtps", "urd"}},
+	{Name: "saft", Port: 487, Protocol: "tcp"},
+	{Name: "isakmp", Port: 500, Protocol: "udp"},
+	{Name: "rtsp", Port: 554, Protocol: "tcp"},
+	{Name: "rtsp", Port: 554, Protocol: "udp"},
+	{Name: "nqs", Port: 607, Protocol: "tcp"},
+	{Name: "asf-rmcp", Port: 623, Protocol: "udp"},
+	{Name: "qmqp", Port: 628, Protocol: "tcp"},
+	{Name: "ipp", Port: 631, Protocol: "tcp"},
+	{Name: "ldp", Port: 646, Protocol: "tcp"},
+	{Name: "ldp", Port: 646, Protocol: "udp"},
+	{Name: "exec", Port: 512, Protocol: "tcp"},
+	{Name: "biff", Port: 512, Protocol: "udp", Aliases: []string{"comsat"}},
+	{Name: "login", Port: 513, Protocol: "tcp"},
+	{Name: "who", Port: 513, Protocol: "udp", Aliases: []string{"whod"}},
+	{Name: "shell", Port: 514, Protocol: "tcp", Aliases: []string{"cmd", "syslog"}},
+	{Name: "syslog", Port: 514, Protocol: "udp"},
+	{Name: "printer", Port: 515, Protocol: "tcp", Aliases: []string{"spooler"}},
+	{Name: "talk", Port: 517, Protocol: "udp"},
+	{Name: "ntalk", Port: 518, Protocol: "udp"},
+	{Name: "route", Port: 520, Protocol: "udp", Aliases: []string{"router", "routed"}},
+	{Name: "gdomap", Port: 538, Protocol: "tcp"},
+	{Name: "gdomap", Port: 538, Protocol: "udp"},
+	{Name: "uucp", Port: 540, Protocol: "tcp", Aliases: []string{"uucpd"}},
+	{Name: "klogin", Port: 543, Protocol: "tcp"},
+	{Name: "kshell", Port: 544, Protocol: "tcp", Aliases: []string{"krcmd"}},
+	{Name: "dhcpv6-client", Port: 546, Protocol: "udp"},
+	{Name: "dhcpv6-server", Port: 547, Protocol: "udp"},
+	{Name: "afpovertcp", Port: 548, Protocol: "tcp"},
+	{Name: "nntps", Port: 563, Protocol: "tcp", Aliases: []string{"snntp"}},
+	{Name: "submission", Port: 587, Protocol: "tcp"},
+	{Name: "ldaps", Port: 636, Protocol: "tcp"},
+	{Name: "ldaps", Port: 636, Protocol: "udp"},
+	{Name: "tinc", Port: 655, Protocol: "tcp"},
+	{Name: "tinc", Port: 655, Protocol: "udp"},
+	{Name: "silc", Port: 706, Protocol: "tcp"},
+	{Name: "kerberos-adm", Port: 749, Protocol: "tcp"},
+	{Name: "domain-s", Port: 853, Protocol: "tcp"},
+	{Name: "domain-s", Port: 853, Protocol: "udp"},
+	{Name: "rsync", Port: 873, Protocol: "tcp"},
+	{Name: "ftps-data", Port: 989, Protocol: "tcp"},
+	{Name: "ftps", Port: 990, Protocol: "tcp"},
+	{Name: "telnets", Port: 992, Protocol: "tcp"},
+	{Name: "imaps", Port: 993, Protocol: "tcp"},
+	{Name: "pop3s", Port: 995, Protocol: "tcp"},
+	{Name: "socks", Port: 1080, Protocol: "tcp"},
+	{Name: "proofd", Port: 1093, Protocol: "tcp"},
+	{Name: "rootd", Port: 1094, Protocol: "tcp"},
+	{Name: "openvpn", Port: 1194, Protocol: "tcp"},
+	{Name: "openvpn", Port: 1194, Protocol: "udp"},
+	{Name: "rmiregistry", Port: 1099, Protocol: "tcp"},
+	{Name: "lotusnote", Port: 1352, Protocol: "tcp", Aliases: []string{"lotusnotes"}},
+	{Name: "ms-sql-s", Port: 1433, Protocol: "tcp"},
+	{Name: "ms-sql-m", Port: 1434, Protocol: "udp"},
+	{Name: "ingreslock", Port: 1524, Protocol: "tcp"},
+	{Name: "datametrics", Port: 1645, Protocol: "tcp", Aliases: []string{"old-radius"}},
+	{Name: "datametrics", Port: 1645, Protocol: "udp", Aliases: []string{"old-radius"}},
+	{Name: "sa-msg-port", Port: 1646, Protocol: "tcp", Aliases: []string{"old-radacct"}},
+	{Name: "sa-msg-port", Port: 1646, Protocol: "udp", Aliases: []string{"old-radacct"}},
+	{Name: "kermit", Port: 1649, Protocol: "tcp"},
+	{Name: "groupwise", Port: 1677, Protocol: "tcp"},
+	{Name: "l2f", Port: 1701, Protocol: "udp", Aliases: []string{"l2tp"}},
+	{Name: "radius", Port: 1812, Protocol: "tcp"},
+	{Name: "radius", Port: 1812, Protocol: "udp"},
+	{Name: "radius-acct", Port: 1813, Protocol: "tcp", Aliases: []string{"radacct"}},
+	{Name: "radius-acct", Port: 1813, Protocol: "udp", Aliases: []string{"radacct"}},
+	{Name: "cisco-sccp", Port: 2000, Protocol: "tcp"},
+	{Name: "nfs", Port: 2049, Protocol: "tcp"},
+	{Name: "nfs", Port: 2049, Protocol: "udp"},
+	{Name: "gnunet", Port: 2086, Protocol: "tcp"},
+	{Name: "gnunet", Port: 2086, Protocol: "udp"},
+	{Name: "rtcm-sc104", Port: 2101, Protocol: "tcp"},
+	{Name: "rtcm-sc104", Port: 2101, Protocol: "udp"},
+	{Name: "gsigatekeeper", Port: 2119, Protocol: "tcp"},
+	{Name: "gris", Port: 2135, Protocol: "tcp"},
+	{Name: "cvspserver", Port: 2401, Protocol: "tcp"},
+	{Name: "venus", Port: 2430, Protocol: "tcp"},
+	{Name: "venus", Port: 2430, Protocol: "udp"},
+	{Name: "venus-se", Port: 2431, Protocol: "tcp"},
+	{Name: "venus-se", Port: 2431, Protocol: "udp"},
+	{Name: "codasrv", Port: 2432, Protocol: "tcp"},
+	{Name: "codasrv", Port: 2432, Protocol: "udp"},
+	{Name: "codasrv-se", Port: 2433, Protocol: "tcp"},
+	{Name: "codasrv-se", Port: 2433, Protocol: "udp"},
+	{Name: "mon", Port: 2583, Protocol: "tcp"},
+	{Name: "mon", Port: 2583, Protocol: "udp"},
+	{Name: "dict", Port: 2628, Protocol: "tcp"},
+	{Name: "f5-globalsite", Port: 2792, Protocol: "tcp"},
+	{Name: "gsiftp", Port: 2811, Protocol: "tcp"},
+	{Name: "gpsd", Port: 2947, Protocol: "tcp"},
+	{Name: "gds-db", Port: 3050, Protocol: "tcp", Aliases: []string{"gds_db"}},
+	{Name: "icpv2", Port: 3130, Protocol: "udp", Aliases: []string{"icp"}},
+	{Name: "isns", Port: 3205, Protocol: "tcp"},
+	{Name: "isns", Port: 3205, Protocol: "udp"},
+	{Name: "iscsi-target", Port: 3260, Protocol: "tcp"},
+	{Name: "mysql", Port: 3306, Protocol: "tcp"},
+	{Name: "ms-wbt-server", Port: 3389, Protocol: "tcp"},
+	{Name: "nut", Port: 3493, Protocol: "tcp"},
+	{Name: "nut", Port: 3493, Protocol: "udp"},
+	{Name: "distcc", Port: 3632, Protocol: "tcp"},
+	{Name: "daap", Port: 3689, Protocol: "tcp"},
+	{Name: "svn", Port: 3690, Protocol: "tcp", Aliases: []string{"subversion"}},
+	{Name: "suucp", Port: 4031, Protocol: "tcp"},
+	{Name: "sysrqd", Port: 4094, Protocol: "tcp"},
+	{Name: "sieve", Port: 4190, Protocol: "tcp"},
+	{Name: "epmd", Port: 4369, Protocol: "tcp"},
+	{Name: "remctl", Port: 4373, Protocol: "tcp"},
+	{Name: "f5-iquery", Port: 4353, Protocol: "tcp"},
+	{Name: "ntske", Port: 4460, Protocol: "tcp"},
+	{Name: "ipsec-nat-t", Port: 4500, Protocol: "udp"},
+	{Name: "iax", Port: 4569, Protocol: "udp"},
+	{Name: "mtn", Port: 4691, Protocol: "tcp"},
+	{Name: "radmin-port", Port: 4899, Protocol: "tcp"},
+	{Name: "sip", Port: 5060, Protocol: "tcp"},
+	{Name: "sip", Port: 5060, Protocol: "udp"},
+	{Name: "sip-tls", Port: 5061, Protocol: "tcp"},
+	{Name: "sip-tls", Port: 5061, Protocol: "udp"},
+	{Name: "xmpp-client", Port: 5222, Protocol: "tcp", Aliases: []string{"jabber-client"}},
+	{Name: "xmpp-server", Port: 5269, Protocol: "tcp", Aliases: []string{"jabber-server"}},
+	{Name: "cfengine", Port: 5308, Protocol: "tcp"},
+	{Name: "mdns", Port: 5353, Protocol: "udp"},
+	{Name: "postgresql", Port: 5432, Protocol: "tcp", Aliases: []string{"postgres"}},
+	{Name: "freeciv", Port: 5556, Protocol: "tcp", Aliases: []string{"rptp"}},
+	{Name: "amqps", Port: 5671, Protocol: "tcp"},
+	{Name: "amqp", Port: 5672, Protocol: "tcp"},
+	{Name: "amqp", Port: 5672, Protocol: "sctp"},
+	{Name: "x11", Port: 6000, Protocol: "tcp", Aliases: []string{"x11-0"}},
+	{Name: "x11-1", Port: 6001, Protocol: "tcp"},
+	{Name: "x11-2", Port: 6002, Protocol: "tcp"},
+	{Name: "x11-3", Port: 6003, Protocol: "tcp"},
+	{Name: "x11-4", Port: 6004, Protocol: "tcp"},
+	{Name: "x11-5", Port: 6005, Protocol: "tcp"},
+	{Name: "x11-6", Port: 6006, Protocol: "tcp"},
+	{Name: "x11-7", Port: 6007, Protocol: "tcp"},
+	{Name: "gnutella-svc", Port: 6346, Protocol: "tcp"},
+	{Name: "gnutella-svc", Port: 6346, Protocol: "udp"},
+	{Name: "gnutella-rtr", Port: 6347, Protocol: "tcp"},
+	{Name: "gnutella-rtr", Port: 6347, Protocol: "udp"},
+	{Name: "redis", Port: 6379, Protocol: "tcp"},
+	{Name: "sge-qmaster", Port: 6444, Protocol: "tcp", Aliases: []string{"sge_qmaster"}},
+	{Name: "sge-execd", Port: 6445, Protocol: "tcp", Aliases: []string{"sge_execd"}},
+	{Name: "mysql-proxy", Port: 6446, Protocol: "tcp"},
+	{Name: "babel", Port: 6696, Protocol: "udp"},
+	{Name: "ircs-u", Port: 6697, Protocol: "tcp"},
+	{Name: "bbs", Port: 7000, Protocol: "tcp"},
+	{Name: "afs3-fileserver", Port: 7000, Protocol: "udp"},
+	{Name: "afs3-callback", Port: 7001, Protocol: "udp"},
+	{Name: "afs3-prserver", Port: 7002, Protocol: "udp"},
+	{Name: "afs3-vlserver", Port: 7003, Protocol: "udp"},
+	{Name: "afs3-kaserver", Port: 7004, Protocol: "udp"},
+	{Name: "afs3-volser", Port: 7005, Protocol: "udp"},
+	{Name: "afs3-bos", Port: 7007, Protocol: "udp"},
+	{Name: "afs3-update", Port: 7008, Protocol: "udp"},
+	{Name: "afs3-rmtsys", Port: 7009, Protocol: "udp"},
+	{Name: "font-service", Port: 7100, Protocol: "tcp", Aliases: []string{"xfs"}},
+	{Name: "http-alt", Port: 8080, Protocol: "tcp", Aliases: []string{"webcache"}},
+	{Name: "puppet", Port: 8140, Protocol: "tcp"},
+	{Name: "bacula-dir", Port: 9101, Protocol: "tcp"},
+	{Name: "bacula-fd", Port: 9102, Protocol: "tcp"},
+	{Name: "bacula-sd", Port: 9103, Protocol: "tcp"},
+	{Name: "xmms2", Port: 9667, Protocol: "tcp"},
+	{Name: "nbd", Port: 10809, Protocol: "tcp"},
+	{Name: "zabbix-agent", Port: 10050, Protocol: "tcp"},
+	{Name: "zabbix-trapper", Port: 10051, Protocol: "tcp"},
+	{Name: "amanda", Port: 10080, Protocol: "tcp"},
+	{Name: "dicom", Port: 11112, Protocol: "tcp"},
+	{Name: "hkp", Port: 11371, Protocol: "tcp"},
+	{Name: "db-lsp", Port: 17500, Protocol: "tcp"},
+	{Name: "dcap", Port: 22125, Protocol: "tcp"},
+	{Name: "gsidcap", Port: 22128, Protocol: "tcp"},
+	{Name: "wnn6", Port: 22273, Protocol: "tcp"},
+}
@@ -0,0 +1,203 @@
+package validate_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+)
+
+func TestServiceByPort(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		port     uint16
+		protocol string
+		expected string
+	}{
+		"HTTPS over TCP":      {443, "tcp", "https"},
+		"HTTPS over UDP":      {443, "udp", "https"},
+		"SSH":                 {22, "tcp", "ssh"},
+		"DNS":                 {53, "udp", "domain"},
+		"Any protocol":        {25, "", "smtp"},
+		"Upper case protocol": {80, "TCP", "http"},
+		"Syslog":              {514, "udp", "syslog"},
+		"Shell":               {514, "tcp", "shell"},
+		"Port 3000":           {3000, "tcp", "hbci"},
+		"Port 8443":           {8443, "tcp", "pcsync-https"},
+		"SSH over SCTP":       {22, "sctp", "ssh"},
+		"Only in netbase":     {6000, "tcp", "x11"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			service, ok := validate.ServiceByPort(tc.port, tc.protocol)
+			if !ok {
+				t.Fatalf("expected a service for port %d/%s", tc.port, tc.protocol)
+			}
+			if service.Name != tc.expected {
+				t.Errorf("expected service %s for port %d/%s, but got %s", tc.expected, tc.port, tc.protocol, service.Name)
+			}
+		})
+	}
+
+	for _, port := range []uint16{0, 4, 8004, 49152, 65535} {
+		if service, ok := validate.ServiceByPort(port, ""); ok {
+			t.Errorf("expected no service for port %d, but got %s", port, service.Name)
+		}
+	}
+
+	if service, ok := validate.ServiceByPort(5432, "sctp"); ok {
+		t.Errorf("expected no service for port 5432/sctp, but got %s", service.Name)
+	}
+}
+
+func TestServiceByName(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		name     string
+		protocol string
+		port     uint16
+	}{
+		"HTTPS":         {"https", "tcp", 443},
+		"Alias":         {"www", "tcp", 80},
+		"Case":          {"SSH", "", 22},
+		"IMAPS":         {"imaps", "tcp", 993},
+		"Submission":    {"submission", "tcp", 587},
+		"Any protocol":  {"ntp", "", 123},
+		"NTP over UDP":  {"ntp", "udp", 123},
+		"Kerberos":      {"kerberos", "udp", 88},
+		"PostgreSQL":    {"postgresql", "tcp", 5432},
+		"Alias of name": {"postgres", "tcp", 5432},
+		"Netbase name":  {"nfs", "tcp", 2049},
+		"Only in IANA":  {"pcsync-https", "tcp", 8443},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			service, ok := validate.ServiceByName(tc.name, tc.protocol)
+			if !ok {
+				t.Fatalf("expected a service named %s/%s", tc.name, tc.protocol)
+			}
+			if service.Port != tc.port {
+				t.Errorf("expected port %d for %s/%s, but got %d", tc.port, tc.name, tc.protocol, service.Port)
+			}
+		})
+	}
+
+	for _, name := range []string{"", "no-such-service", "ssh/tcp"} {
+		if service, ok := validate.ServiceByName(name, ""); ok {
+			t.Errorf("expected no service named %q, but got %s", name, service.Name)
+		}
+	}
+}
+
+func TestPortNotRegistered(t *testing.T) {
+	t.Parallel()
+
+	for _, port := range []uint16{0, 4, 8004, 49152, 65535} {
+		if err := validate.PortNotRegistered(port, ""); err != nil {
+			t.Errorf("expected no error for unregistered port %d, but got error: %v", port, err)
+		}
+	}
+
+	if err := validate.PortNotRegistered(5432, "sctp"); err != nil {
+		t.Errorf("expected no error for port 5432/sctp, but got error: %v", err)
+	}
+
+	for _, port := range []uint16{22, 80, 443, 3000, 5432, 8443, 9200} {
+		if err := validate.PortNotRegistered(port, "tcp"); err == nil {
+			t.Errorf("expected error for registered port %d/tcp, but got no error", port)
+		}
+	}
+}
+
+func TestRegisteredPorts(t *testing.T) {
+	t.Parallel()
+
+	ports := validate.RegisteredPorts("")
+	for _, service := range validate.ServiceRegistry {
+		if !ports.Contains(service.Port) {
+			t.Errorf("expected registered ports to contain %d of %s/%s", service.Port, service.Name, service.Protocol)
+		}
+	}
+
+	sctp := validate.RegisteredPorts("sctp")
+	if sctp.Contains(5432) {
+		t.Error("expected registered SCTP ports not to contain 5432 of postgresql/tcp")
+	}
+	if !sctp.Contains(22) {
+		t.Error("expected registered SCTP ports to contain 22 of ssh/sctp")
+	}
+
+	if n := validate.RegisteredPorts("tcp").Len(); n < 5000 {
+		t.Errorf("expected the IANA registry to have more than 5000 TCP ports, but got %d", n)
+	}
+}
+
+func TestParseServiceRegistrySuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	registry := `Service Name,Port Number,Transport Protocol,Description,Assignee
+,0,tcp,Reserved,
+https,443,tcp,"http protocol over TLS/SSL",[IANA]
+https,443,UDP,"http protocol over TLS/SSL
+(DTLS)",[IANA]
+x11,6000-6003,tcp,X Window System,
+,6004-6005,udp,Unassigned,
+ntske,,tcp,"no port, name only",
+`
+	expected := []validate.Service{
+		{Name: "https", Port: 443, Protocol: "tcp"},
+		{Name: "https", Port: 443, Protocol: "udp"},
+		{Name: "x11", Port: 6000, Protocol: "tcp"},
+		{Name: "x11", Port: 6001, Protocol: "tcp"},
+		{Name: "x11", Port: 6002, Protocol: "tcp"},
+		{Name: "x11", Port: 6003, Protocol: "tcp"},
+	}
+
+	services, err := validate.ParseServiceRegistry(strings.NewReader(registry))
+	if err != nil {
+		t.Fatalf("expected no error for valid registry, but got error: %v", err)
+	}
+	if !slices.EqualFunc(services, expected, func(a, b validate.Service) bool {
+		return a.Name == b.Name && a.Port == b.Port && a.Protocol == b.Protocol
+	}) {
+		t.Errorf("expected services %v, but got %v", expected, services)
+	}
+
+	reordered := "Transport Protocol,Service Name,Port Number\nudp,domain,53\n"
+	services, err = validate.ParseServiceRegistry(strings.NewReader(reordered))
+	if err != nil {
+		t.Fatalf("expected no error for registry with reordered columns, but got error: %v", err)
+	}
+	if len(services) != 1 || services[0].Name != "domain" || services[0].Port != 53 || services[0].Protocol != "udp" {
+		t.Errorf("expected domain/udp on port 53, but got %v", services)
+	}
+}
+
+func TestParseServiceRegistryFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"Empty":                   "",
+		"Missing protocol column": "Service Name,Port Number\nhttps,443\n",
+		"Port out of range":       "Service Name,Port Number,Transport Protocol\nhttps,65536,tcp\n",
+		"Port not a number":       "Service Name,Port Number,Transport Protocol\nhttps,https,tcp\n",
+		"Inverted port range":     "Service Name,Port Number,Transport Protocol\nx11,6063-6000,tcp\n",
+		"Unterminated quote":      "Service Name,Port Number,Transport Protocol\n\"https,443,tcp\n",
+	}
+
+	for name, registry := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := validate.ParseServiceRegistry(strings.NewReader(registry)); err == nil {
+				t.Errorf("expected error for invalid registry %q, but got no error", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package validate
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	shamirShareVersion   byte = 1
+	shamirHeaderLength   int  = 7 // version, threshold, index and identifier
+	shamirChecksumLength int  = 4
+	shamirMinThreshold   byte = 2
+)
+
+var shamirEncoding = base32.NewEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ").WithPadding(base32.NoPadding)
+
+var shamirDecodingReplacer = strings.NewReplacer("-", "", " ", "", "O", "0", "I", "1", "L", "1")
+
+// shamirShare holds the header of a decoded share.
+type shamirShare struct {
+	threshold   byte
+	index       byte
+	identifier  string
+	valueLength int
+}
+
+// ShamirShare validates if the provided share is a well-formed share generated by the shamir subpackage of the random module.
+//
+// The share must:
+//   - Be Crockford base32, ignoring case, hyphens and spaces, and reading O, I and L as 0, 1 and 1.
+//   - Have a valid checksum, which detects mistyped characters.
+//   - Have version 1, a threshold of at least 2, a non-zero index and a value of at least one byte.
+func ShamirShare(share string) error {
+	_, err := parseShamirShare(share)
+	return err
+}
+
+// ShamirShares validates if the provided shares can be combined to reconstruct their secret.
+// Each share must be valid according to ShamirShare, all shares must have the same identifier, threshold and length,
+// their indexes must be different, and there must be at least threshold shares.
+func ShamirShares(shares []string) error {
+	if len(shares) == 0 {
+		return errors.New("no shares")
+	}
+
+	var errs []error
+	var first *shamirShare
+	indexes := make(map[byte]int, len(shares))
+
+	for i, share := range shares {
+		s, err := parseShamirShare(share)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid share %d: %w", i, err))
+			continue
+		}
+
+		if first == nil {
+			first = &s
+		} else if s.identifier != first.identifier {
+			errs = append(errs, fmt.Errorf("share %d belongs to a different secret", i))
+			continue
+		} else if s.threshold != first.threshold || s.valueLength != first.valueLength {
+			errs = append(errs, fmt.Errorf("share %d has a different threshold or length", i))
+			continue
+		}
+
+		if j, ok := indexes[s.index]; ok {
+			errs = append(errs, fmt.Errorf("share %d has the same index %d as share %d", i, s.index, j))
+			continue
+		}
+		indexes[s.index] = i
+	}
+
+	if first != nil && len(indexes) < int(first.threshold) {
+		errs = append(errs, fmt.Errorf("%d distinct shares, expected at least %d", len(indexes), first.threshold))
+	}
+
+	return errors.Join(errs...)
+}
+
+func parseShamirShare(share string) (shamirShare, error) {
+	data, err := shamirEncoding.DecodeString(shamirDecodingReplacer.Replace(strings.ToUpper(share)))
+	if err != nil {
+		return shamirShare{}, fmt.Errorf("invalid base32 share: %w", err)
+	}
+
+	if len(data) < shamirHeaderLength+1+shamirChecksumLength {
+		return shamirShare{}, fmt.Errorf("share of %d bytes too short", len(data))
+	}
+
+	payload, checksum := data[:len(data)-shamirChecksumLength], data[len(data)-shamirChecksumLength:]
+	if sum := sha256.Sum256(payload); !bytes.Equal(sum[:shamirChecksumLength], checksum) {
+		return shamirShare{}, errors.New("invalid share checksum, the share is mistyped or corrupted")
+	}
+
+	if payload[0] != shamirShareVersion {
+		return shamirShare{}, fmt.Errorf("unsupported share version %d", payload[0])
+	}
+
+	s := shamirShare{
+		threshold:   payload[1],
+		index:       payload[2],
+		identifier:  string(payload[3:shamirHeaderLength]),
+		valueLength: len(payload) - shamirHeaderLength,
+	}
+
+	if s.threshold < shamirMinThreshold {
+		return shamirShare{}, fmt.Errorf("threshold %d less than %d", s.threshold, shamirMinThreshold)
+	}
+
+	if s.index == 0 {
+		return shamirShare{}, errors.New("share index 0 would reveal the secret")
+	}
+
+	return s, nil
+}
@@ -0,0 +1,110 @@
+package validate_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+)
+
+// Shares of the same secret split twice into 3 shares with threshold 2, and of a shorter secret.
+var (
+	testShamirShares = []string{
+		"04102-TQ6F0-ZJSMM-VD45X-QZTKS-KGT0D-5YRQN-4YM8D-1N34Q-RECP5-23A9N-RWBM2-NXG",
+		"04104-TQ6F0-ZZT3N-V8JWG-GYE67-DMCVZ-E8Y5M-KTFM6-PMD1V-E8PWM-NZNY6-4E916-ZM0",
+		"04106-TQ6F0-ZV5CT-JBZBV-1WNNK-ZK1ZE-GK2KG-H66ZZ-VMQ2Y-Y5940-7BZCG-S734R-XS8",
+	}
+	testShamirOtherShares = []string{
+		"04102-54G0S-NH9SJ-7RBF2-MME3P-DX6WA-17DAT-TEKX8-AM4AG-FRDYD-XSCDW-ZQZFJ-TTG",
+		"04104-54G0S-NRTSG-R144F-2FQXR-N2MNH-F1PKB-ZC0PQ-0P3C0-7MFC5-ATFPM-AVH89-038",
+	}
+	testShamirShorterShares = []string{
+		"04103-HP1A2-MQ2FB-SDRCG-NDHD4-2NHD4-7ZHNG-YN5CB-9HVHM-GRPG3-9C1Y1-VPPDR-W",
+	}
+)
+
+func TestShamirShareSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"Share":                    testShamirShares[0],
+		"Lower case":               strings.ToLower(testShamirShares[1]),
+		"Spaces instead of dashes": strings.ReplaceAll(testShamirShares[2], "-", " "),
+		"Without dashes":           strings.ReplaceAll(testShamirShares[2], "-", ""),
+		"Confusable characters":    strings.ReplaceAll(strings.ReplaceAll(testShamirShares[0], "0", "O"), "1", "l"),
+	}
+
+	for name, share := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.ShamirShare(share); err != nil {
+				t.Errorf("expected no error for valid share \"%s\", but got error: %v", share, err)
+			}
+		})
+	}
+}
+
+func TestShamirShareFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"Empty":              "",
+		"Invalid character":  strings.Replace(testShamirShares[0], "T", "U", 1),
+		"Mistyped character": strings.Replace(testShamirShares[0], "TQ6F0", "TQ6F1", 1),
+		"Swapped characters": strings.Replace(testShamirShares[0], "TQ6F0", "QT6F0", 1),
+		"Truncated":          testShamirShares[0][:20],
+		"Missing group":      strings.Replace(testShamirShares[0], "-ZJSMM", "", 1),
+	}
+
+	for name, share := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.ShamirShare(share); err == nil {
+				t.Errorf("expected error for invalid share %q, but got nil", name)
+			}
+		})
+	}
+}
+
+func TestShamirSharesSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string][]string{
+		"Threshold shares": testShamirShares[1:],
+		"All shares":       testShamirShares,
+		"Other secret":     testShamirOtherShares,
+		"Reversed order":   {testShamirShares[2], testShamirShares[0]},
+	}
+
+	for name, shares := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.ShamirShares(shares); err != nil {
+				t.Errorf("expected no error for valid shares, but got error: %v", err)
+			}
+		})
+	}
+}
+
+func TestShamirSharesFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string][]string{
+		"No shares":             nil,
+		"Below threshold":       testShamirShares[:1],
+		"Duplicate share":       {testShamirShares[0], testShamirShares[0]},
+		"Duplicate index":       {testShamirShares[0], strings.ToLower(testShamirShares[0])},
+		"Shares of two secrets": {testShamirShares[0], testShamirOtherShares[1]},
+		"Different length":      {testShamirShares[0], testShamirShorterShares[0]},
+		"Invalid share":         {testShamirShares[0], testShamirShares[1][:20]},
+	}
+
+	for name, shares := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.ShamirShares(shares); err == nil {
+				t.Errorf("expected error for invalid shares %q, but got nil", name)
+			}
+		})
+	}
+}
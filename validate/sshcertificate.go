@@ -0,0 +1,146 @@
+package validate
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SSHCertificateType defines the types of OpenSSH certificates.
+type SSHCertificateType int
+
+// List of OpenSSH certificate types.
+const (
+	SSHCertificateTypeUser SSHCertificateType = iota
+	SSHCertificateTypeHost
+)
+
+var sshCertificateTypeString = map[SSHCertificateType]string{
+	SSHCertificateTypeUser: "user",
+	SSHCertificateTypeHost: "host",
+}
+
+var sshCertificateTypeWire = map[SSHCertificateType]uint32{
+	SSHCertificateTypeUser: ssh.UserCert,
+	SSHCertificateTypeHost: ssh.HostCert,
+}
+
+func (c SSHCertificateType) String() string {
+	return sshCertificateTypeString[c]
+}
+
+// SSHCriticalOptions lists the critical options understood by OpenSSH. Certificates with other critical options are rejected by SSHCertificate,
+// since a server must refuse a certificate carrying a critical option it doesn't recognize.
+var SSHCriticalOptions = []string{"force-command", "source-address", "verify-required"}
+
+// SSHCertificate parses an OpenSSH certificate in authorized_keys format and validates it.
+// It checks the certificate type, that the certificate has a key ID and at least one principal, that user principals are
+// valid Linux usernames and host principals valid Linux hostnames or domains (with an optional leading wildcard label),
+// that the certificate is valid at the specified time, that it has only known critical options, and that it is signed by caPublicKey.
+//
+// If principal is not empty, it must be one of the principals of the certificate. If at is zero, the current time is used.
+func SSHCertificate(certificate []byte, certificateType SSHCertificateType, caPublicKey crypto.PublicKey, principal string, at time.Time) error {
+	wireType, ok := sshCertificateTypeWire[certificateType]
+	if !ok {
+		return errors.New("unsupported SSH certificate type")
+	}
+
+	if caPublicKey == nil {
+		return errors.New("nil CA public key")
+	}
+
+	caSSHPublicKey, err := ssh.NewPublicKey(caPublicKey)
+	if err != nil {
+		return fmt.Errorf("invalid CA public key: %w", err)
+	}
+
+	sshPublicKey, _, _, _, err := ssh.ParseAuthorizedKey(certificate)
+	if err != nil {
+		return fmt.Errorf("invalid SSH certificate: %w", err)
+	}
+
+	sshCertificate, ok := sshPublicKey.(*ssh.Certificate)
+	if !ok {
+		return fmt.Errorf("public key of type %s is not a certificate", sshPublicKey.Type())
+	}
+
+	if sshCertificate.CertType != wireType {
+		return fmt.Errorf("different certificate type, expected %s certificate", certificateType)
+	}
+
+	var errs []error
+
+	if sshCertificate.KeyId == "" {
+		errs = append(errs, errors.New("empty key ID"))
+	}
+
+	if len(sshCertificate.ValidPrincipals) == 0 {
+		errs = append(errs, errors.New("no principals in certificate"))
+	}
+
+	for _, validPrincipal := range sshCertificate.ValidPrincipals {
+		if err := sshPrincipal(validPrincipal, certificateType); err != nil {
+			errs = append(errs, fmt.Errorf("invalid principal \"%s\": %w", validPrincipal, err))
+		}
+	}
+
+	if sshCertificate.ValidBefore != ssh.CertTimeInfinity && sshCertificate.ValidAfter >= sshCertificate.ValidBefore {
+		errs = append(errs, errors.New("certificate valid after the end of its validity period"))
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	isAuthority := func(auth ssh.PublicKey) bool {
+		return bytes.Equal(auth.Marshal(), caSSHPublicKey.Marshal())
+	}
+
+	checker := &ssh.CertChecker{
+		SupportedCriticalOptions: SSHCriticalOptions,
+		Clock:                    func() time.Time { return at },
+	}
+
+	if certificateType == SSHCertificateTypeUser {
+		checker.IsUserAuthority = isAuthority
+	} else {
+		checker.IsHostAuthority = func(auth ssh.PublicKey, _ string) bool { return isAuthority(auth) }
+	}
+
+	if !isAuthority(sshCertificate.SignatureKey) {
+		return errors.New("certificate not signed by the CA public key")
+	}
+
+	if principal == "" {
+		principal = sshCertificate.ValidPrincipals[0]
+	}
+
+	if err := checker.CheckCert(principal, sshCertificate); err != nil {
+		return fmt.Errorf("invalid certificate: %w", err)
+	}
+
+	return nil
+}
+
+// sshPrincipal validates a principal of a certificate of the specified type.
+func sshPrincipal(principal string, certificateType SSHCertificateType) error {
+	if certificateType == SSHCertificateTypeUser {
+		// Linux usernames share the character set of hostnames, except that they may also contain underscores and end with a dollar sign.
+		return LinuxHostname(strings.ReplaceAll(strings.TrimSuffix(principal, "$"), "_", "-"), 0, 0)
+	}
+
+	if err := LinuxHostname(principal, 0, 0); err == nil {
+		return nil
+	}
+
+	return Domain(strings.TrimPrefix(principal, "*."), 0, 0)
+}
@@ -0,0 +1,147 @@
+package validate_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/copartner6412/input/validate"
+	"golang.org/x/crypto/ssh"
+)
+
+func createSSHCertificate(t *testing.T, caKey ed25519.PrivateKey, modify func(*ssh.Certificate)) []byte {
+	t.Helper()
+
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating key pair: %v", err)
+	}
+
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("error converting public key: %v", err)
+	}
+
+	now := time.Now()
+
+	certificate := &ssh.Certificate{
+		Key:             sshPublicKey,
+		Serial:          1,
+		CertType:        ssh.UserCert,
+		KeyId:           "user-0000000000000001",
+		ValidPrincipals: []string{"alice", "bob"},
+		ValidAfter:      uint64(now.Add(-time.Minute).Unix()),
+		ValidBefore:     uint64(now.Add(time.Hour).Unix()),
+		Permissions: ssh.Permissions{
+			Extensions: map[string]string{"permit-pty": ""},
+		},
+	}
+
+	if modify != nil {
+		modify(certificate)
+	}
+
+	signer, err := ssh.NewSignerFromKey(caKey)
+	if err != nil {
+		t.Fatalf("error creating signer: %v", err)
+	}
+
+	if err := certificate.SignCert(rand.Reader, signer); err != nil {
+		t.Fatalf("error signing certificate: %v", err)
+	}
+
+	return ssh.MarshalAuthorizedKey(certificate)
+}
+
+func TestSSHCertificateSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	caPublicKey, caKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating CA key pair: %v", err)
+	}
+
+	hostCertificate := func(c *ssh.Certificate) {
+		c.CertType = ssh.HostCert
+		c.ValidPrincipals = []string{"server", "*.example.com"}
+	}
+
+	testCases := map[string]struct {
+		certificate     []byte
+		certificateType validate.SSHCertificateType
+		principal       string
+		at              time.Time
+	}{
+		"User certificate":               {createSSHCertificate(t, caKey, nil), validate.SSHCertificateTypeUser, "", time.Time{}},
+		"User certificate for principal": {createSSHCertificate(t, caKey, nil), validate.SSHCertificateTypeUser, "bob", time.Now()},
+		"Host certificate":               {createSSHCertificate(t, caKey, hostCertificate), validate.SSHCertificateTypeHost, "server", time.Now()},
+		"Known critical option": {createSSHCertificate(t, caKey, func(c *ssh.Certificate) {
+			c.CriticalOptions = map[string]string{"force-command": "/bin/true"}
+		}), validate.SSHCertificateTypeUser, "alice", time.Now()},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.SSHCertificate(tc.certificate, tc.certificateType, caPublicKey, tc.principal, tc.at); err != nil {
+				t.Errorf("expected no error for valid certificate, but got error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSSHCertificateFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	caPublicKey, caKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating CA key pair: %v", err)
+	}
+
+	otherCAPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating CA key pair: %v", err)
+	}
+
+	valid := createSSHCertificate(t, caKey, nil)
+
+	testCases := map[string]struct {
+		certificate     []byte
+		certificateType validate.SSHCertificateType
+		caPublicKey     ed25519.PublicKey
+		principal       string
+		at              time.Time
+	}{
+		"Not a certificate":      {[]byte("not a certificate"), validate.SSHCertificateTypeUser, caPublicKey, "", time.Now()},
+		"Wrong certificate type": {valid, validate.SSHCertificateTypeHost, caPublicKey, "", time.Now()},
+		"Wrong CA":               {valid, validate.SSHCertificateTypeUser, otherCAPublicKey, "", time.Now()},
+		"Unknown principal":      {valid, validate.SSHCertificateTypeUser, caPublicKey, "mallory", time.Now()},
+		"Expired":                {valid, validate.SSHCertificateTypeUser, caPublicKey, "", time.Now().Add(2 * time.Hour)},
+		"Not yet valid":          {valid, validate.SSHCertificateTypeUser, caPublicKey, "", time.Now().Add(-time.Hour)},
+		"Empty key ID": {createSSHCertificate(t, caKey, func(c *ssh.Certificate) {
+			c.KeyId = ""
+		}), validate.SSHCertificateTypeUser, caPublicKey, "", time.Now()},
+		"No principals": {createSSHCertificate(t, caKey, func(c *ssh.Certificate) {
+			c.ValidPrincipals = nil
+		}), validate.SSHCertificateTypeUser, caPublicKey, "", time.Now()},
+		"Invalid principal": {createSSHCertificate(t, caKey, func(c *ssh.Certificate) {
+			c.ValidPrincipals = []string{"-alice"}
+		}), validate.SSHCertificateTypeUser, caPublicKey, "", time.Now()},
+		"Inverted validity window": {createSSHCertificate(t, caKey, func(c *ssh.Certificate) {
+			c.ValidAfter, c.ValidBefore = c.ValidBefore, c.ValidAfter
+		}), validate.SSHCertificateTypeUser, caPublicKey, "", time.Now()},
+		"Unknown critical option": {createSSHCertificate(t, caKey, func(c *ssh.Certificate) {
+			c.CriticalOptions = map[string]string{"unknown-option": ""}
+		}), validate.SSHCertificateTypeUser, caPublicKey, "", time.Now()},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.SSHCertificate(tc.certificate, tc.certificateType, tc.caPublicKey, tc.principal, tc.at); err == nil {
+				t.Errorf("expected error for invalid certificate %q, but got nil", name)
+			}
+		})
+	}
+}
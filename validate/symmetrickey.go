@@ -0,0 +1,161 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SymmetricAlgorithm defines the supported symmetric key algorithms.
+type SymmetricAlgorithm int
+
+// List of supported symmetric key algorithms.
+const (
+	SymmetricAlgorithmAES128GCM SymmetricAlgorithm = iota
+	SymmetricAlgorithmAES256GCM
+	SymmetricAlgorithmChaCha20Poly1305
+	SymmetricAlgorithmHMACSHA256
+	SymmetricAlgorithmHMACSHA512
+	SymmetricAlgorithmHS256
+	SymmetricAlgorithmHS384
+	SymmetricAlgorithmHS512
+)
+
+var symmetricAlgorithmString = map[SymmetricAlgorithm]string{
+	SymmetricAlgorithmAES128GCM:        "AES-128-GCM",
+	SymmetricAlgorithmAES256GCM:        "AES-256-GCM",
+	SymmetricAlgorithmChaCha20Poly1305: "ChaCha20-Poly1305",
+	SymmetricAlgorithmHMACSHA256:       "HMAC-SHA256",
+	SymmetricAlgorithmHMACSHA512:       "HMAC-SHA512",
+	SymmetricAlgorithmHS256:            "HS256",
+	SymmetricAlgorithmHS384:            "HS384",
+	SymmetricAlgorithmHS512:            "HS512",
+}
+
+func (a SymmetricAlgorithm) String() string {
+	return symmetricAlgorithmString[a]
+}
+
+// symmetricKeySize holds the minimum and maximum key size in bytes of each symmetric algorithm.
+// AEAD keys have a fixed size. HMAC keys must be at least as long as the hash output (RFC 2104, RFC 7518 section 3.2)
+// and at most as long as the hash block, since longer keys are hashed first.
+var symmetricKeySize = map[SymmetricAlgorithm][2]int{
+	SymmetricAlgorithmAES128GCM:        {16, 16},
+	SymmetricAlgorithmAES256GCM:        {32, 32},
+	SymmetricAlgorithmChaCha20Poly1305: {32, 32},
+	SymmetricAlgorithmHMACSHA256:       {32, 64},
+	SymmetricAlgorithmHMACSHA512:       {64, 128},
+	SymmetricAlgorithmHS256:            {32, 64},
+	SymmetricAlgorithmHS384:            {48, 128},
+	SymmetricAlgorithmHS512:            {64, 128},
+}
+
+// symmetricNonceSize holds the nonce size in bytes of each AEAD algorithm.
+var symmetricNonceSize = map[SymmetricAlgorithm]int{
+	SymmetricAlgorithmAES128GCM:        12,
+	SymmetricAlgorithmAES256GCM:        12,
+	SymmetricAlgorithmChaCha20Poly1305: 12,
+}
+
+// KeySize returns the size in bytes of generated keys of the algorithm, which is the minimum key size accepted by SymmetricKey.
+func (a SymmetricAlgorithm) KeySize() (int, error) {
+	size, ok := symmetricKeySize[a]
+	if !ok {
+		return 0, errors.New("unsupported symmetric algorithm")
+	}
+
+	return size[0], nil
+}
+
+// NonceSize returns the nonce size in bytes of an AEAD algorithm. HMAC algorithms have no nonce.
+func (a SymmetricAlgorithm) NonceSize() (int, error) {
+	size, ok := symmetricNonceSize[a]
+	if !ok {
+		return 0, fmt.Errorf("algorithm %s has no nonce", a)
+	}
+
+	return size, nil
+}
+
+// SymmetricKey validates if the provided key is suitable for the specified symmetric algorithm.
+//
+// The key must:
+//   - Be 16 bytes long for AES-128-GCM and 32 bytes long for AES-256-GCM and ChaCha20-Poly1305.
+//   - Be at least as long as the hash output and at most as long as the hash block for HMAC and JWT HS* algorithms.
+//   - Not have obviously low entropy: it must not repeat a short pattern (such as all-zero keys), count up or down
+//     in constant steps, have fewer distinct bytes than half its length, or, if it is at least 32 bytes long,
+//     consist only of printable ASCII characters like a password.
+//
+// These checks reject keys which are not the output of a CSPRNG with overwhelming probability, while random keys practically never fail them.
+func SymmetricKey(key []byte, algorithm SymmetricAlgorithm) error {
+	size, ok := symmetricKeySize[algorithm]
+	if !ok {
+		return errors.New("unsupported symmetric algorithm")
+	}
+
+	if len(key) < size[0] || len(key) > size[1] {
+		if size[0] == size[1] {
+			return fmt.Errorf("key of %d bytes, expected %d bytes for %s", len(key), size[0], algorithm)
+		}
+		return fmt.Errorf("key of %d bytes, expected %d to %d bytes for %s", len(key), size[0], size[1], algorithm)
+	}
+
+	return keyEntropy(key)
+}
+
+// minPrintableCheckLength is the minimum length of keys rejected for consisting only of printable ASCII characters.
+// About 1 in 7.7 million random 16-byte keys are printable, but only about 1 in 6e13 random 32-byte keys are.
+const minPrintableCheckLength int = 32
+
+// keyEntropy returns an error if the key has obviously low entropy.
+func keyEntropy(key []byte) error {
+	var errs []error
+
+	// A period of at most half the key length covers constant keys and repeated patterns.
+	for period := 1; period <= len(key)/2; period++ {
+		repeated := true
+		for i := period; i < len(key); i++ {
+			if key[i] != key[i-period] {
+				repeated = false
+				break
+			}
+		}
+		if repeated {
+			errs = append(errs, fmt.Errorf("key repeats a pattern of %d bytes", period))
+			break
+		}
+	}
+
+	progression := true
+	for i := 2; i < len(key); i++ {
+		if key[i]-key[i-1] != key[1]-key[0] {
+			progression = false
+			break
+		}
+	}
+	if progression {
+		errs = append(errs, errors.New("key bytes count in constant steps"))
+	}
+
+	distinct := make(map[byte]struct{}, len(key))
+	printable := true
+	for _, b := range key {
+		distinct[b] = struct{}{}
+		if b < 0x20 || b > 0x7e {
+			printable = false
+		}
+	}
+
+	if len(distinct) < min(len(key), 256)/2 {
+		errs = append(errs, fmt.Errorf("key has only %d distinct bytes", len(distinct)))
+	}
+
+	if printable && len(key) >= minPrintableCheckLength {
+		errs = append(errs, errors.New("key consists only of printable ASCII characters"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("key with low entropy: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package validate_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+)
+
+// Random bytes generated once with crypto/rand.
+var testSymmetricKey = []byte{
+	0x3f, 0xa2, 0x91, 0x0c, 0x5e, 0xd7, 0x48, 0xb3, 0x16, 0xe9, 0x7a, 0x24, 0xcf, 0x60, 0x85, 0x1b,
+	0xf4, 0x2d, 0x98, 0x53, 0xba, 0x07, 0x6e, 0xc1, 0x39, 0x8c, 0xe2, 0x4f, 0xa5, 0x10, 0xdb, 0x76,
+	0x0a, 0x93, 0x5c, 0xe7, 0x21, 0xbe, 0x64, 0xf8, 0x4d, 0x82, 0x1f, 0xc6, 0x75, 0x2a, 0x9e, 0x03,
+	0xd1, 0x68, 0xab, 0x37, 0xfc, 0x49, 0x8e, 0x12, 0x57, 0xe0, 0x9b, 0x2c, 0x73, 0xc8, 0x05, 0xb6,
+}
+
+func TestSymmetricKeySuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		key       []byte
+		algorithm validate.SymmetricAlgorithm
+	}{
+		"AES-128-GCM":               {testSymmetricKey[:16], validate.SymmetricAlgorithmAES128GCM},
+		"AES-256-GCM":               {testSymmetricKey[:32], validate.SymmetricAlgorithmAES256GCM},
+		"ChaCha20-Poly1305":         {testSymmetricKey[32:], validate.SymmetricAlgorithmChaCha20Poly1305},
+		"HMAC-SHA256":               {testSymmetricKey[:32], validate.SymmetricAlgorithmHMACSHA256},
+		"HMAC-SHA256 of block size": {testSymmetricKey, validate.SymmetricAlgorithmHMACSHA256},
+		"HMAC-SHA512":               {testSymmetricKey, validate.SymmetricAlgorithmHMACSHA512},
+		"HS256":                     {testSymmetricKey[:32], validate.SymmetricAlgorithmHS256},
+		"HS384":                     {testSymmetricKey[:48], validate.SymmetricAlgorithmHS384},
+		"HS512":                     {testSymmetricKey, validate.SymmetricAlgorithmHS512},
+		"printable AES-128-GCM":     {[]byte("k3Y!p9#Qz@7wL$2m"), validate.SymmetricAlgorithmAES128GCM},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.SymmetricKey(tc.key, tc.algorithm); err != nil {
+				t.Errorf("expected no error for valid %s key, but got error: %v", tc.algorithm, err)
+			}
+		})
+	}
+}
+
+func TestSymmetricKeyFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	counting := make([]byte, 32)
+	for i := range counting {
+		counting[i] = byte(7 * i)
+	}
+
+	fewDistinct := bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07}, 5)[:32]
+	fewDistinct[31] = 0xff
+
+	testCases := map[string]struct {
+		key       []byte
+		algorithm validate.SymmetricAlgorithm
+	}{
+		"Unsupported algorithm":     {testSymmetricKey[:32], validate.SymmetricAlgorithm(-1)},
+		"Empty key":                 {nil, validate.SymmetricAlgorithmAES128GCM},
+		"AES-128-GCM with 32 bytes": {testSymmetricKey[:32], validate.SymmetricAlgorithmAES128GCM},
+		"AES-256-GCM with 16 bytes": {testSymmetricKey[:16], validate.SymmetricAlgorithmAES256GCM},
+		"HMAC-SHA512 with 32 bytes": {testSymmetricKey[:32], validate.SymmetricAlgorithmHMACSHA512},
+		"HS384 with 32 bytes":       {testSymmetricKey[:32], validate.SymmetricAlgorithmHS384},
+		"HMAC-SHA256 over block":    {append(testSymmetricKey, 0x42), validate.SymmetricAlgorithmHMACSHA256},
+		"All-zero key":              {make([]byte, 32), validate.SymmetricAlgorithmAES256GCM},
+		"Repeated pattern":          {bytes.Repeat([]byte{0xde, 0xad, 0xbe, 0xef}, 8), validate.SymmetricAlgorithmAES256GCM},
+		"Counting bytes":            {counting, validate.SymmetricAlgorithmChaCha20Poly1305},
+		"Few distinct bytes":        {fewDistinct, validate.SymmetricAlgorithmHS256},
+		"Printable password":        {[]byte("my-super-secret-jwt-signing-key!"), validate.SymmetricAlgorithmHS256},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.SymmetricKey(tc.key, tc.algorithm); err == nil {
+				t.Errorf("expected error for invalid key %q, but got nil", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package validate
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/netip"
+)
+
+var ulaPrefix = netip.MustParsePrefix("fd00::/8")
+
+// ULA validates if the provided prefix is a well-formed locally assigned unique local IPv6 prefix (RFC 4193),
+// i.e. a /48 ULA prefix or one of its subnets.
+//
+// The prefix must:
+//   - Be an IPv6 prefix in fd00::/8, i.e. have the L bit set. The fc00::/8 half is reserved for a future definition.
+//   - Have a prefix length of at least 48 bits and no host bits set.
+//   - Have a Global ID other than zero or all ones, which are not the output of a random algorithm.
+func ULA(prefix netip.Prefix) error {
+	if !prefix.IsValid() {
+		return errors.New("invalid prefix")
+	}
+
+	if !prefix.Addr().Is6() || prefix.Addr().Is4In6() {
+		return fmt.Errorf("prefix %s is not an IPv6 prefix", prefix)
+	}
+
+	if !ulaPrefix.Contains(prefix.Addr()) {
+		return fmt.Errorf("prefix %s is not within %s", prefix, ulaPrefix)
+	}
+
+	if prefix.Bits() < 48 {
+		return fmt.Errorf("prefix length of %d is less than 48", prefix.Bits())
+	}
+
+	if masked := prefix.Masked(); masked != prefix {
+		return fmt.Errorf("prefix %s has host bits set, expected %s", prefix, masked)
+	}
+
+	ip := prefix.Addr().As16()
+	globalID := binary.BigEndian.Uint64(append([]byte{0, 0, 0}, ip[1:6]...))
+	if globalID == 0 || globalID == 1<<40-1 {
+		return fmt.Errorf("Global ID %010x is not random", globalID)
+	}
+
+	return nil
+}
+
+// InterfaceIdentifier validates if the interface identifier, i.e. the last 64 bits, of the provided IPv6 address
+// is not reserved (RFC 5453). Reserved identifiers are the subnet-router anycast identifier (all zeros),
+// the reserved subnet anycast identifiers FDFF:FFFF:FFFF:FF80 to FDFF:FFFF:FFFF:FFFF and the proxy mobile
+// identifiers 0200:5EFF:FE00:0000 to 0200:5EFF:FE00:FFFF, which include 0200:5EFF:FE00:5213.
+func InterfaceIdentifier(addr netip.Addr) error {
+	if !addr.Is6() || addr.Is4In6() {
+		return errors.New("not an IPv6 address")
+	}
+
+	ip := addr.As16()
+	iid := binary.BigEndian.Uint64(ip[8:])
+
+	switch {
+	case iid == 0:
+		return errors.New("subnet-router anycast interface identifier")
+	case iid >= 0xfdffffffffffff80 && iid <= 0xfdffffffffffffff:
+		return fmt.Errorf("reserved subnet anycast interface identifier %016x", iid)
+	case iid>>16 == 0x02005efffe00:
+		return fmt.Errorf("reserved proxy mobile interface identifier %016x", iid)
+	}
+
+	return nil
+}
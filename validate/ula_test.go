@@ -0,0 +1,99 @@
+package validate_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+)
+
+func TestULASuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := []string{
+		"fd12:3456:789a::/48",
+		"fd12:3456:789a:1::/64",
+		"fdff:ffff:fffe::/48",
+		"fd00:0:1::/48",
+		"fd12:3456:789a:1::1/128",
+	}
+
+	for _, prefix := range testCases {
+		t.Run(prefix, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.ULA(netip.MustParsePrefix(prefix)); err != nil {
+				t.Errorf("expected no error for valid ULA prefix %s, but got error: %v", prefix, err)
+			}
+		})
+	}
+}
+
+func TestULAFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]netip.Prefix{
+		"Zero prefix":        {},
+		"IPv4":               netip.MustParsePrefix("10.0.0.0/8"),
+		"IPv4-mapped":        netip.MustParsePrefix("::ffff:10.0.0.0/120"),
+		"Global unicast":     netip.MustParsePrefix("2001:db8::/48"),
+		"Reserved fc00::/8":  netip.MustParsePrefix("fc12:3456:789a::/48"),
+		"Link-local":         netip.MustParsePrefix("fe80::/64"),
+		"Shorter than /48":   netip.MustParsePrefix("fd12:3456::/32"),
+		"Whole ULA range":    netip.MustParsePrefix("fd00::/8"),
+		"Host bits set":      netip.MustParsePrefix("fd12:3456:789a::1/48"),
+		"Zero Global ID":     netip.MustParsePrefix("fd00::/48"),
+		"All ones Global ID": netip.MustParsePrefix("fdff:ffff:ffff::/48"),
+	}
+
+	for name, prefix := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.ULA(prefix); err == nil {
+				t.Errorf("expected error for invalid ULA prefix %s, but got nil", prefix)
+			}
+		})
+	}
+}
+
+func TestInterfaceIdentifierSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := []string{
+		"fd12:3456:789a:1:211:22ff:fe33:4455",
+		"2001:db8::1",
+		"fe80::fdff:ffff:ffff:ff7f",
+		"fe80::200:5eff:fe01:0",
+	}
+
+	for _, addr := range testCases {
+		t.Run(addr, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.InterfaceIdentifier(netip.MustParseAddr(addr)); err != nil {
+				t.Errorf("expected no error for valid interface identifier of %s, but got error: %v", addr, err)
+			}
+		})
+	}
+}
+
+func TestInterfaceIdentifierFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]netip.Addr{
+		"Zero address":          {},
+		"IPv4":                  netip.MustParseAddr("10.0.0.1"),
+		"Subnet-router anycast": netip.MustParseAddr("2001:db8::"),
+		"First subnet anycast":  netip.MustParseAddr("2001:db8::fdff:ffff:ffff:ff80"),
+		"Last subnet anycast":   netip.MustParseAddr("2001:db8::fdff:ffff:ffff:ffff"),
+		"Proxy mobile":          netip.MustParseAddr("2001:db8::200:5eff:fe00:5213"),
+		"Reserved proxy mobile": netip.MustParseAddr("2001:db8::200:5eff:fe00:1"),
+	}
+
+	for name, addr := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.InterfaceIdentifier(addr); err == nil {
+				t.Errorf("expected error for reserved interface identifier of %s, but got nil", addr)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package validate
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ecdh"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const wireGuardKeySize int = 32
+
+// wireGuardInterfaceKeys holds the keys allowed in the [Interface] section of wg-quick configuration files, in lower case.
+var wireGuardInterfaceKeys = map[string]struct{}{
+	"privatekey": {}, "listenport": {}, "fwmark": {}, "address": {}, "dns": {}, "mtu": {}, "table": {},
+	"preup": {}, "postup": {}, "predown": {}, "postdown": {}, "saveconfig": {},
+}
+
+// wireGuardPeerKeys holds the keys allowed in [Peer] sections of wg-quick configuration files, in lower case.
+var wireGuardPeerKeys = map[string]struct{}{
+	"publickey": {}, "presharedkey": {}, "allowedips": {}, "endpoint": {}, "persistentkeepalive": {},
+}
+
+// WireGuardKey validates if the provided key is a base64-encoded 32-byte WireGuard key, as generated by wg genkey, wg pubkey and wg genpsk.
+func WireGuardKey(key string) error {
+	decoded, err := base64.StdEncoding.Strict().DecodeString(key)
+	if err != nil {
+		return fmt.Errorf("invalid base64 WireGuard key: %w", err)
+	}
+
+	if len(decoded) != wireGuardKeySize {
+		return fmt.Errorf("WireGuard key of %d bytes, expected %d", len(decoded), wireGuardKeySize)
+	}
+
+	return nil
+}
+
+// WireGuardConfig parses a wg-quick configuration file and validates it.
+//
+// The configuration must:
+//   - Have exactly one [Interface] section, followed by any number of [Peer] sections, with only the keys known to wg-quick.
+//   - Have a valid private key in the [Interface] section, and a valid public key in every [Peer] section.
+//     Preshared keys are optional. A peer must not have the public key of the interface or of another peer.
+//   - Have addresses and allowed IPs as comma-separated CIDRs or bare host addresses, and listen ports, MTUs and persistent keepalive intervals in range.
+//   - Have endpoints as host:port, with an IP address (in brackets for IPv6), a Linux hostname or a domain as host, and a non-zero port.
+//
+// Keys are case-insensitive and lines starting with '#' are comments, as in wg-quick.
+func WireGuardConfig(config []byte) error {
+	type section struct {
+		name   string
+		line   int
+		values map[string]string
+	}
+
+	var sections []section
+	var errs []error
+
+	scanner := bufio.NewScanner(bytes.NewReader(config))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.ToLower(line[1 : len(line)-1])
+			if name != "interface" && name != "peer" {
+				errs = append(errs, fmt.Errorf("line %d: unknown section %s", lineNumber, line))
+			}
+			sections = append(sections, section{name: name, line: lineNumber, values: make(map[string]string)})
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			errs = append(errs, fmt.Errorf("line %d: expected key = value", lineNumber))
+			continue
+		}
+
+		if len(sections) == 0 {
+			errs = append(errs, fmt.Errorf("line %d: key outside of a section", lineNumber))
+			continue
+		}
+
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+		current := sections[len(sections)-1]
+
+		allowed := wireGuardPeerKeys
+		if current.name == "interface" {
+			allowed = wireGuardInterfaceKeys
+		}
+		if _, ok := allowed[key]; !ok {
+			errs = append(errs, fmt.Errorf("line %d: unknown key \"%s\" in [%s] section", lineNumber, key, current.name))
+			continue
+		}
+
+		// wg-quick accepts repeated Address, DNS and AllowedIPs keys and joins their values.
+		if previous, ok := current.values[key]; ok {
+			if key != "address" && key != "dns" && key != "allowedips" && !strings.HasPrefix(key, "pre") && !strings.HasPrefix(key, "post") {
+				errs = append(errs, fmt.Errorf("line %d: duplicate key \"%s\"", lineNumber, key))
+				continue
+			}
+			value = previous + "," + value
+		}
+		current.values[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading WireGuard configuration: %w", err)
+	}
+
+	if len(sections) == 0 || sections[0].name != "interface" {
+		return errors.Join(append(errs, errors.New("configuration doesn't start with an [Interface] section"))...)
+	}
+
+	publicKeys := make(map[string]int)
+
+	for _, s := range sections {
+		if s.name == "interface" && s.line != sections[0].line {
+			errs = append(errs, fmt.Errorf("line %d: more than one [Interface] section", s.line))
+			continue
+		}
+
+		var err error
+		switch s.name {
+		case "interface":
+			err = wireGuardInterface(s.values, publicKeys)
+		case "peer":
+			err = wireGuardPeer(s.values, publicKeys, s.line)
+		default:
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid [%s] section at line %d: %w", s.name, s.line, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func wireGuardInterface(values map[string]string, publicKeys map[string]int) error {
+	var errs []error
+
+	privateKey, ok := values["privatekey"]
+	if !ok {
+		errs = append(errs, errors.New("missing PrivateKey"))
+	} else if err := WireGuardKey(privateKey); err != nil {
+		errs = append(errs, fmt.Errorf("invalid PrivateKey: %w", err))
+	} else {
+		decoded, _ := base64.StdEncoding.DecodeString(privateKey)
+		x25519PrivateKey, err := ecdh.X25519().NewPrivateKey(decoded)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid PrivateKey: %w", err))
+		} else {
+			publicKeys[base64.StdEncoding.EncodeToString(x25519PrivateKey.PublicKey().Bytes())] = 0
+		}
+	}
+
+	if address, ok := values["address"]; ok {
+		if err := wireGuardCIDRs(address); err != nil {
+			errs = append(errs, fmt.Errorf("invalid Address: %w", err))
+		}
+	}
+
+	if listenPort, ok := values["listenport"]; ok {
+		if _, err := strconv.ParseUint(listenPort, 10, 16); err != nil {
+			errs = append(errs, fmt.Errorf("invalid ListenPort \"%s\"", listenPort))
+		}
+	}
+
+	if mtu, ok := values["mtu"]; ok {
+		// IPv6 requires an MTU of at least 1280 bytes, and WireGuard adds 80 bytes of overhead to a jumbo frame of at most 9000 bytes.
+		if n, err := strconv.ParseUint(mtu, 10, 16); err != nil || n < 1280 || n > 8920 {
+			errs = append(errs, fmt.Errorf("invalid MTU \"%s\"", mtu))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func wireGuardPeer(values map[string]string, publicKeys map[string]int, line int) error {
+	var errs []error
+
+	publicKey, ok := values["publickey"]
+	if !ok {
+		errs = append(errs, errors.New("missing PublicKey"))
+	} else if err := WireGuardKey(publicKey); err != nil {
+		errs = append(errs, fmt.Errorf("invalid PublicKey: %w", err))
+	} else if previous, ok := publicKeys[publicKey]; ok {
+		if previous == 0 {
+			errs = append(errs, errors.New("public key of the interface"))
+		} else {
+			errs = append(errs, fmt.Errorf("same public key as the peer at line %d", previous))
+		}
+	} else {
+		publicKeys[publicKey] = line
+	}
+
+	if presharedKey, ok := values["presharedkey"]; ok {
+		if err := WireGuardKey(presharedKey); err != nil {
+			errs = append(errs, fmt.Errorf("invalid PresharedKey: %w", err))
+		}
+	}
+
+	if allowedIPs, ok := values["allowedips"]; ok {
+		if err := wireGuardCIDRs(allowedIPs); err != nil {
+			errs = append(errs, fmt.Errorf("invalid AllowedIPs: %w", err))
+		}
+	}
+
+	if endpoint, ok := values["endpoint"]; ok {
+		if err := wireGuardEndpoint(endpoint); err != nil {
+			errs = append(errs, fmt.Errorf("invalid Endpoint: %w", err))
+		}
+	}
+
+	if keepalive, ok := values["persistentkeepalive"]; ok && keepalive != "off" {
+		if _, err := strconv.ParseUint(keepalive, 10, 16); err != nil {
+			errs = append(errs, fmt.Errorf("invalid PersistentKeepalive \"%s\"", keepalive))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// wireGuardCIDRs validates a comma-separated list of CIDRs.
+// As in wg-quick, an address without a prefix length is a host prefix (/32 for IPv4 and /128 for IPv6).
+func wireGuardCIDRs(list string) error {
+	var errs []error
+
+	for _, cidr := range strings.Split(list, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if !strings.Contains(cidr, "/") {
+			if net.ParseIP(cidr) == nil {
+				errs = append(errs, fmt.Errorf("invalid address \"%s\"", cidr))
+			}
+			continue
+		}
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			errs = append(errs, fmt.Errorf("invalid CIDR \"%s\"", cidr))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// wireGuardEndpoint validates an endpoint of the form host:port.
+func wireGuardEndpoint(endpoint string) error {
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return err
+	}
+
+	if n, err := strconv.ParseUint(port, 10, 16); err != nil || n == 0 {
+		return fmt.Errorf("invalid port \"%s\"", port)
+	}
+
+	// net.SplitHostPort requires IPv6 addresses to be in brackets.
+	if net.ParseIP(host) != nil {
+		return nil
+	}
+
+	if LinuxHostname(host, 0, 0) == nil {
+		return nil
+	}
+
+	if err := Domain(host, 0, 0); err != nil {
+		return fmt.Errorf("invalid host \"%s\": %w", host, err)
+	}
+
+	return nil
+}
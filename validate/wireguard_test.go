@@ -0,0 +1,100 @@
+package validate_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/copartner6412/input/validate"
+)
+
+const (
+	testWireGuardPrivateKey   = "yAnz5TF+lXXJte14tji3zlMNq+hd2rYUIgJBgB3fBmk="
+	testWireGuardPublicKey    = "HIgo9xNzJMWLKASShiTqIybxZ0U3wGLiUeJ1PKf8ykw="
+	testWireGuardPeerKey      = "xTIBA5rboUvnH4htodjb6e697QjLERt1NAB4mZqp8Dg="
+	testWireGuardOtherPeerKey = "TrMvSoP4jYQlY6RIzBgbssQqY3vxI2Pi+y71lOWWXX0="
+	testWireGuardPresharedKey = "FpCyhws9cxwWoV4xELtfJvjJN+zQVRPISllRWgeopVE="
+)
+
+var testWireGuardConfig = `[Interface]
+# Site A
+PrivateKey = ` + testWireGuardPrivateKey + `
+Address = 10.192.122.1/24, fd00::1/64
+ListenPort = 51820
+MTU = 1420
+
+[Peer]
+PublicKey = ` + testWireGuardPeerKey + `
+PresharedKey = ` + testWireGuardPresharedKey + `
+AllowedIPs = 10.192.122.3/32, 10.192.124.0/24
+Endpoint = 192.95.5.69:51820
+
+[Peer]
+PublicKey = ` + testWireGuardOtherPeerKey + `
+AllowedIPs = fd00::2/128
+Endpoint = vpn.example.com:51820
+PersistentKeepalive = 25
+`
+
+func TestWireGuardConfigSuccessfulForValidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"Full configuration":        testWireGuardConfig,
+		"Interface only":            "[Interface]\nPrivateKey = " + testWireGuardPrivateKey + "\n",
+		"Case-insensitive":          "[interface]\nprivatekey = " + testWireGuardPrivateKey + "\n",
+		"IPv6 endpoint":             "[Interface]\nPrivateKey = " + testWireGuardPrivateKey + "\n[Peer]\nPublicKey = " + testWireGuardPeerKey + "\nEndpoint = [2001:db8::1]:51820\n",
+		"Hostname endpoint":         "[Interface]\nPrivateKey = " + testWireGuardPrivateKey + "\n[Peer]\nPublicKey = " + testWireGuardPeerKey + "\nEndpoint = gateway:51820\n",
+		"Repeated AllowedIPs":       "[Interface]\nPrivateKey = " + testWireGuardPrivateKey + "\n[Peer]\nPublicKey = " + testWireGuardPeerKey + "\nAllowedIPs = 10.0.0.2/32\nAllowedIPs = 10.0.1.0/24\n",
+		"Address without prefix":    strings.Replace(testWireGuardConfig, "10.192.122.1/24, fd00::1/64", "10.192.122.1, fd00::1", 1),
+		"AllowedIPs without prefix": strings.Replace(testWireGuardConfig, "AllowedIPs = fd00::2/128", "AllowedIPs = fd00::2", 1),
+		"Keepalive turned off":      "[Interface]\nPrivateKey = " + testWireGuardPrivateKey + "\n[Peer]\nPublicKey = " + testWireGuardPeerKey + "\nPersistentKeepalive = off\n",
+	}
+
+	for name, config := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.WireGuardConfig([]byte(config)); err != nil {
+				t.Errorf("expected no error for valid configuration, but got error: %v", err)
+			}
+		})
+	}
+}
+
+func TestWireGuardConfigFailsForInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"Empty":                      "",
+		"Peer before interface":      "[Peer]\nPublicKey = " + testWireGuardPeerKey + "\n[Interface]\nPrivateKey = " + testWireGuardPrivateKey + "\n",
+		"Two interfaces":             testWireGuardConfig + "[Interface]\nPrivateKey = " + testWireGuardPrivateKey + "\n",
+		"Unknown section":            testWireGuardConfig + "[Server]\n",
+		"Unknown key":                strings.Replace(testWireGuardConfig, "MTU", "MaxTransmissionUnit", 1),
+		"Line without value":         strings.Replace(testWireGuardConfig, "MTU = 1420", "MTU", 1),
+		"Missing private key":        "[Interface]\nAddress = 10.0.0.1/24\n",
+		"Short private key":          strings.Replace(testWireGuardConfig, testWireGuardPrivateKey, "yAnz5TF+lXXJte14tji3zlMNq+hd2rYUIgJBgB3f", 1),
+		"Private key not base64":     strings.Replace(testWireGuardConfig, testWireGuardPrivateKey, strings.Repeat("*", 44), 1),
+		"Missing public key":         strings.Replace(testWireGuardConfig, "PublicKey = "+testWireGuardOtherPeerKey, "", 1),
+		"Duplicate peer public key":  strings.Replace(testWireGuardConfig, testWireGuardOtherPeerKey, testWireGuardPeerKey, 1),
+		"Peer with interface key":    strings.Replace(testWireGuardConfig, testWireGuardOtherPeerKey, testWireGuardPublicKey, 1),
+		"Invalid preshared key":      strings.Replace(testWireGuardConfig, testWireGuardPresharedKey, "AAAA", 1),
+		"Invalid address":            strings.Replace(testWireGuardConfig, "10.192.122.1/24", "10.192.122.256", 1),
+		"Invalid allowed IP":         strings.Replace(testWireGuardConfig, "10.192.124.0/24", "10.192.124.0/33", 1),
+		"Listen port out of range":   strings.Replace(testWireGuardConfig, "ListenPort = 51820", "ListenPort = 70000", 1),
+		"MTU too small":              strings.Replace(testWireGuardConfig, "MTU = 1420", "MTU = 576", 1),
+		"Endpoint without port":      strings.Replace(testWireGuardConfig, "192.95.5.69:51820", "192.95.5.69", 1),
+		"Endpoint with zero port":    strings.Replace(testWireGuardConfig, "192.95.5.69:51820", "192.95.5.69:0", 1),
+		"Endpoint with IPv6 bare":    strings.Replace(testWireGuardConfig, "192.95.5.69:51820", "2001:db8::1:51820", 1),
+		"Endpoint with invalid host": strings.Replace(testWireGuardConfig, "vpn.example.com", "-vpn.example.com", 1),
+		"Invalid keepalive":          strings.Replace(testWireGuardConfig, "PersistentKeepalive = 25", "PersistentKeepalive = never", 1),
+		"Duplicate key":              strings.Replace(testWireGuardConfig, "MTU = 1420", "MTU = 1420\nMTU = 1280", 1),
+	}
+
+	for name, config := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := validate.WireGuardConfig([]byte(config)); err == nil {
+				t.Errorf("expected error for invalid configuration %q, but got nil", name)
+			}
+		})
+	}
+}